@@ -0,0 +1,85 @@
+package zoom
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+)
+
+// Account is the calendar of one of several accounts, such as your work and personal
+// Google accounts.
+type Account struct {
+	// Name labels the account's meetings, e.g. "work".
+	Name string
+
+	// Source lists the account's meetings.
+	Source CalendarSource
+}
+
+// AccountsSource is a CalendarSource which merges the meetings of several accounts,
+// setting each meeting's Account to the name of the account it came from.
+type AccountsSource struct {
+	// Accounts are the accounts whose meetings are merged. A meeting in more than one
+	// account, such as one you were invited to at both, is labelled with the first.
+	Accounts []Account
+
+	// OnError is called when an account's meetings can't be listed, in which case the
+	// other accounts' meetings are still listed. If every account fails, UpcomingEvents
+	// returns the first account's error instead. If nil, errors are ignored.
+	OnError func(account string, err error)
+}
+
+// NewAccountsSource returns a source which merges the accounts' meetings.
+func NewAccountsSource(accounts ...Account) *AccountsSource {
+	return &AccountsSource{Accounts: accounts}
+}
+
+// UpcomingEvents lists each account's meetings in the window at the same time, and merges
+// them sorted by start time.
+func (s *AccountsSource) UpcomingEvents(ctx context.Context, window Window) ([]Meeting, error) {
+	results := make([][]Meeting, len(s.Accounts))
+	errs := make([]error, len(s.Accounts))
+
+	var wg sync.WaitGroup
+	for i, account := range s.Accounts {
+		wg.Add(1)
+		go func(i int, account Account) {
+			defer wg.Done()
+			results[i], errs[i] = account.Source.UpcomingEvents(ctx, window)
+		}(i, account)
+	}
+	wg.Wait()
+
+	var merged []Meeting
+	var firstErr error
+	failed := 0
+	seen := map[string]bool{}
+	for i, account := range s.Accounts {
+		if errs[i] != nil {
+			failed++
+			if firstErr == nil {
+				firstErr = fmt.Errorf("account %s: %w", account.Name, errs[i])
+			}
+			if s.OnError != nil {
+				s.OnError(account.Name, errs[i])
+			}
+			continue
+		}
+		for _, meeting := range results[i] {
+			key := meeting.ID + "@" + meeting.Start.String()
+			if meeting.ID != "" && seen[key] {
+				continue
+			}
+			seen[key] = true
+			meeting.Account = account.Name
+			merged = append(merged, meeting)
+		}
+	}
+	if len(s.Accounts) > 0 && failed == len(s.Accounts) {
+		return nil, firstErr
+	}
+
+	sort.SliceStable(merged, func(i, j int) bool { return merged[i].Start.Before(merged[j].Start) })
+	return merged, nil
+}
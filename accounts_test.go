@@ -0,0 +1,81 @@
+package zoom
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// failingSource is a CalendarSource which always fails.
+type failingSource struct {
+	err error
+}
+
+func (s failingSource) UpcomingEvents(ctx context.Context, window Window) ([]Meeting, error) {
+	return nil, s.err
+}
+
+func TestAccountsSource(t *testing.T) {
+	now := time.Date(2018, 10, 10, 10, 0, 0, 0, time.UTC)
+	standup := Meeting{ID: "standup", Title: "Standup", Start: now.Add(time.Hour)}
+	dentist := Meeting{ID: "dentist", Title: "Dentist", Start: now.Add(30 * time.Minute)}
+	// A meeting you were invited to at both accounts.
+	party := Meeting{ID: "party", Title: "Party", Start: now.Add(2 * time.Hour)}
+
+	source := NewAccountsSource(
+		Account{Name: "work", Source: meetingsSource{standup, party}},
+		Account{Name: "personal", Source: meetingsSource{dentist, party}},
+	)
+	meetings, err := source.UpcomingEvents(context.Background(), Window{Start: now})
+	require.NoError(t, err)
+
+	var labels []string
+	for _, meeting := range meetings {
+		labels = append(labels, meeting.Title+" ("+meeting.Account+")")
+	}
+	assert.Equal(t, []string{"Dentist (personal)", "Standup (work)", "Party (work)"}, labels)
+}
+
+func TestAccountsSource_Errors(t *testing.T) {
+	now := time.Date(2018, 10, 10, 10, 0, 0, 0, time.UTC)
+	standup := Meeting{ID: "standup", Title: "Standup", Start: now.Add(time.Hour)}
+
+	var failed []string
+	source := NewAccountsSource(
+		Account{Name: "work", Source: meetingsSource{standup}},
+		Account{Name: "personal", Source: failingSource{ErrAuthExpired}},
+	)
+	source.OnError = func(account string, err error) {
+		failed = append(failed, account)
+		assert.True(t, errors.Is(err, ErrAuthExpired))
+	}
+	meetings, err := source.UpcomingEvents(context.Background(), Window{Start: now})
+	require.NoError(t, err, "the other account's meetings are still listed")
+	require.Len(t, meetings, 1)
+	assert.Equal(t, "work", meetings[0].Account)
+	assert.Equal(t, []string{"personal"}, failed)
+
+	source.OnError = nil
+	source.Accounts[0].Source = failingSource{ErrCalendarUnavailable}
+	_, err = source.UpcomingEvents(context.Background(), Window{Start: now})
+	assert.EqualError(t, err, "account work: calendar unavailable")
+	assert.True(t, errors.Is(err, ErrCalendarUnavailable))
+}
+
+func TestTodayAgendaFromSource(t *testing.T) {
+	now := time.Date(2018, 10, 10, 10, 0, 0, 0, time.UTC)
+	// Meetings in different accounts can still conflict.
+	source := NewAccountsSource(
+		Account{Name: "work", Source: meetingsSource{{ID: "standup", Title: "Standup", Start: now, End: now.Add(30 * time.Minute)}}},
+		Account{Name: "personal", Source: meetingsSource{{ID: "dentist", Title: "Dentist", Start: now.Add(15 * time.Minute), End: now.Add(time.Hour)}}},
+	)
+	meetings, err := TodayAgendaFromSource(context.Background(), source, now)
+	require.NoError(t, err)
+	require.Len(t, meetings, 2)
+	assert.True(t, meetings[0].Conflicting)
+	assert.True(t, meetings[1].Conflicting)
+}
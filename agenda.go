@@ -0,0 +1,117 @@
+package zoom
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"text/tabwriter"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// TodayAgenda returns all of today's meetings in the calendars selected by the options,
+// including those which have already ended, sorted by start time. Meetings which overlap
+// another are marked Conflicting. "Today" is the calendar day in Location.
+func TodayAgenda(service *calendar.Service, opts Options) ([]Meeting, error) {
+	return TodayAgendaContext(context.Background(), service, opts)
+}
+
+// TodayAgendaContext is like TodayAgenda, but the calendar API calls are bound to the context.
+func TodayAgendaContext(ctx context.Context, service *calendar.Service, opts Options) ([]Meeting, error) {
+	// Every meeting today is listed, however long ago it started.
+	opts.SkipInProgressAfter = 0
+
+	meetings, err := NewGoogleCalendarSource(service, opts).UpcomingEvents(ctx, TodayWindow(time.Now()))
+	if err != nil {
+		return nil, err
+	}
+	markConflicts(meetings)
+	return meetings, nil
+}
+
+// TodayAgendaFromSource is like TodayAgendaContext, but lists the meetings the source
+// lists, such as an AccountsSource. The source's options decide which meetings are listed.
+func TodayAgendaFromSource(ctx context.Context, source CalendarSource, now time.Time) ([]Meeting, error) {
+	meetings, err := source.UpcomingEvents(ctx, TodayWindow(now))
+	if err != nil {
+		return nil, err
+	}
+	markConflicts(meetings)
+	return meetings, nil
+}
+
+// TodayWindow returns the window from midnight to midnight of the day containing now, in Location.
+func TodayWindow(now time.Time) Window {
+	now = InLocation(now)
+	year, month, day := now.Date()
+	return Window{
+		Start: time.Date(year, month, day, 0, 0, 0, 0, now.Location()),
+		End:   time.Date(year, month, day+1, 0, 0, 0, 0, now.Location()),
+	}
+}
+
+// markConflicts marks each meeting which overlaps another as Conflicting. The meetings
+// must be sorted by start time.
+func markConflicts(meetings []Meeting) {
+	for _, group := range conflictGroups(meetings) {
+		for _, i := range group {
+			meetings[i].Conflicting = true
+		}
+	}
+}
+
+// Duration returns how long the meeting is scheduled to last, or zero if its start or end is unknown.
+func (m Meeting) Duration() time.Duration {
+	if m.Start.IsZero() || m.End.IsZero() {
+		return 0
+	}
+	return m.End.Sub(m.Start)
+}
+
+// WriteAgenda writes the meetings to w as a table of start times, durations, titles, and
+// join URLs chosen by the options. Conflicting meetings are followed by a note such as
+// "⚠ overlaps with 'Design review'", and others by one such as "back-to-back with
+// 'Design review'" if the next meeting starts within BackToBackGap of their end.
+func WriteAgenda(w io.Writer, meetings []Meeting, opts URLOptions) error {
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	for _, meeting := range meetings {
+		start, duration := "", formatDuration(meeting.Duration())
+		if meeting.AllDay {
+			start, duration = "all day", ""
+		} else if !meeting.Start.IsZero() {
+			start = Formatter.AbsoluteTime(InLocation(meeting.Start))
+		}
+		note := ""
+		if meeting.Conflicting {
+			note = ConflictSummary(meeting, meetings)
+		} else {
+			note = BackToBackSummary(meeting, meetings, BackToBackGap)
+		}
+		title := meeting.Title
+		if meeting.Account != "" {
+			title += " [" + meeting.Account + "]"
+		}
+		joinURL := ""
+		if u := meeting.URL(opts); u != nil {
+			joinURL = u.String()
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\n", start, duration, title, joinURL, note)
+	}
+	return table.Flush()
+}
+
+// formatDuration formats a duration compactly, e.g. "45m", "1h", or "1h30m". It is empty for zero.
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	hours, minutes := int(d/time.Hour), int(d%time.Hour/time.Minute)
+	switch {
+	case hours == 0 && minutes == 0:
+		return ""
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dh%dm", hours, minutes)
+}
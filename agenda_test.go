@@ -0,0 +1,84 @@
+package zoom
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTodayAgenda(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	today := TodayWindow(time.Now())
+	at := func(hour, minute int) string {
+		return today.Start.Add(time.Duration(hour)*time.Hour + time.Duration(minute)*time.Minute).Format(time.RFC3339)
+	}
+
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, today.Start.Format(time.RFC3339), query.Get("timeMin"))
+		assert.Equal(t, today.End.Format(time.RFC3339), query.Get("timeMax"))
+		fmt.Fprintf(w, `{"items": [
+			{"summary": "Standup", "start": {"dateTime": %q}, "end": {"dateTime": %q}, "location": "https://jithub.zoom.us/j/12345"},
+			{"summary": "Design review", "start": {"dateTime": %q}, "end": {"dateTime": %q}},
+			{"summary": "1:1", "start": {"dateTime": %q}, "end": {"dateTime": %q}},
+			{"summary": "Lunch", "start": {"dateTime": %q}, "end": {"dateTime": %q}}
+		]}`, at(9, 0), at(9, 15), at(10, 0), at(11, 0), at(10, 30), at(11, 0), at(12, 0), at(13, 0))
+	})
+
+	meetings, err := TodayAgenda(service, Options{})
+	require.NoError(t, err)
+	require.Len(t, meetings, 4)
+
+	assert.Equal(t, "Standup", meetings[0].Title)
+	assert.Equal(t, "https://jithub.zoom.us/j/12345", meetings[0].JoinURL.String())
+	assert.Equal(t, 15*time.Minute, meetings[0].Duration())
+
+	var conflicting []bool
+	for _, meeting := range meetings {
+		conflicting = append(conflicting, meeting.Conflicting)
+	}
+	assert.Equal(t, []bool{false, true, true, false}, conflicting)
+}
+
+func TestTodayWindow(t *testing.T) {
+	defer func(location *time.Location) { Location = location }(Location)
+	Location = time.FixedZone("UTC-5", -5*60*60)
+
+	window := TodayWindow(time.Date(2018, 10, 11, 2, 0, 0, 0, time.UTC))
+	assert.Equal(t, "2018-10-10T00:00:00-05:00", window.Start.Format(time.RFC3339))
+	assert.Equal(t, "2018-10-11T00:00:00-05:00", window.End.Format(time.RFC3339))
+}
+
+func TestWriteAgenda(t *testing.T) {
+	defer func(location *time.Location) { Location = location }(Location)
+	Location = time.UTC
+
+	joinURL, _ := url.Parse("https://jithub.zoom.us/j/12345")
+	at := func(hour, minute int) time.Time { return time.Date(2018, 10, 10, hour, minute, 0, 0, time.UTC) }
+
+	var output bytes.Buffer
+	require.NoError(t, WriteAgenda(&output, []Meeting{
+		{Title: "Standup", Start: at(9, 0), End: at(9, 15), JoinURL: joinURL},
+		{Title: "Planning", Start: at(9, 15), End: at(9, 55)},
+		{Title: "Design review", Start: at(10, 0), End: at(11, 30), Conflicting: true},
+		{Title: "1:1", Start: at(11, 0), End: at(11, 30), Conflicting: true},
+		{Title: "Offsite", Start: at(13, 0), Account: "personal"},
+	}, URLOptions{}))
+
+	assert.Equal(t, ""+
+		"9:00 AM   15m    Standup             https://jithub.zoom.us/j/12345  back-to-back with 'Planning'\n"+
+		"9:15 AM   40m    Planning                                            back-to-back with 'Design review'\n"+
+		"10:00 AM  1h30m  Design review                                       ⚠ overlaps with '1:1'\n"+
+		"11:00 AM  30m    1:1                                                 ⚠ overlaps with 'Design review'\n"+
+		"1:00 PM          Offsite [personal]                                  \n", output.String())
+}
@@ -0,0 +1,144 @@
+package zoom
+
+import (
+	"fmt"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// The response statuses of attendees, as reported by Google Calendar.
+const (
+	ResponseNeedsAction = "needsAction"
+	ResponseDeclined    = "declined"
+	ResponseTentative   = "tentative"
+	ResponseAccepted    = "accepted"
+)
+
+// AttendeesWithResponse returns the attendees whose response status is the given one, e.g. ResponseAccepted.
+func (m Meeting) AttendeesWithResponse(status string) []Attendee {
+	var attendees []Attendee
+	for _, attendee := range m.Attendees {
+		if attendee.ResponseStatus == status {
+			attendees = append(attendees, attendee)
+		}
+	}
+	return attendees
+}
+
+// MyResponse returns your own response status, or "" if you are not an attendee, such
+// as for events you created without inviting anyone.
+func (m Meeting) MyResponse() string {
+	for _, attendee := range m.Attendees {
+		if attendee.Self {
+			return attendee.ResponseStatus
+		}
+	}
+	return ""
+}
+
+// AttendeeSummary describes how many people are invited and how many accepted, e.g.
+// "12 attendees, 8 accepted". It is empty if nobody is invited.
+func (m Meeting) AttendeeSummary() string {
+	return summarizeAttendees(m.Attendees)
+}
+
+// SummaryOptions controls what MeetingSummaryWithOptions includes.
+type SummaryOptions struct {
+	// Attendees includes how many people are invited and accepted, and your own response.
+	Attendees bool
+}
+
+// MeetingSummaryWithOptions is like MeetingSummary, but includes the details selected by the options, e.g.
+// `Your next meeting is "Planning", organized by Mona Lisa. 12 attendees, 8 accepted. You haven't responded.`
+func MeetingSummaryWithOptions(event *calendar.Event, opts SummaryOptions) string {
+	summary := MeetingSummary(event)
+	if event == nil || !opts.Attendees {
+		return summary
+	}
+
+	attendees := attendeesFromEvent(event)
+	if attendeeSummary := summarizeAttendees(attendees); attendeeSummary != "" {
+		summary += " " + attendeeSummary + "."
+	}
+	if response := (Meeting{Attendees: attendees}).MyResponse(); response != "" {
+		summary += " " + describeResponse(response)
+	}
+	return summary
+}
+
+// Summary is like MeetingSummaryWithOptions, but summarizes a Meeting, such as one listed
+// by a CalendarSource.
+func (m Meeting) Summary(opts SummaryOptions) string {
+	summary := "You have a meeting coming up"
+	if m.Title != "" {
+		summary = fmt.Sprintf("Your next meeting is %q", m.Title)
+	}
+	if m.Organizer.Name != "" {
+		summary += ", organized by " + m.Organizer.Name
+	}
+	summary += "."
+	if !opts.Attendees {
+		return summary
+	}
+
+	if attendeeSummary := m.AttendeeSummary(); attendeeSummary != "" {
+		summary += " " + attendeeSummary + "."
+	}
+	if response := m.MyResponse(); response != "" {
+		summary += " " + describeResponse(response)
+	}
+	return summary
+}
+
+// describeResponse describes your response status in a sentence.
+func describeResponse(status string) string {
+	switch status {
+	case ResponseAccepted:
+		return "You accepted."
+	case ResponseDeclined:
+		return "You declined."
+	case ResponseTentative:
+		return "You might attend."
+	}
+	return "You haven't responded."
+}
+
+// attendeesFromEvent returns the people invited to the event.
+func attendeesFromEvent(event *calendar.Event) []Attendee {
+	if event == nil {
+		return nil
+	}
+
+	var attendees []Attendee
+	for _, attendee := range event.Attendees {
+		if attendee == nil {
+			continue
+		}
+		attendees = append(attendees, Attendee{
+			Person:         Person{Name: attendee.DisplayName, Email: attendee.Email},
+			ResponseStatus: attendee.ResponseStatus,
+			Optional:       attendee.Optional,
+			Self:           attendee.Self,
+		})
+	}
+	return attendees
+}
+
+// summarizeAttendees describes how many people are invited and how many accepted.
+func summarizeAttendees(attendees []Attendee) string {
+	if len(attendees) == 0 {
+		return ""
+	}
+
+	accepted := 0
+	for _, attendee := range attendees {
+		if attendee.ResponseStatus == ResponseAccepted {
+			accepted++
+		}
+	}
+
+	if len(attendees) == 1 {
+		return fmt.Sprintf("1 attendee, %d accepted", accepted)
+	}
+	return fmt.Sprintf("%d attendees, %d accepted", len(attendees), accepted)
+}
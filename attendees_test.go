@@ -0,0 +1,64 @@
+package zoom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestMeetingAttendees(t *testing.T) {
+	meeting := MeetingFromEvent(&calendar.Event{Attendees: []*calendar.EventAttendee{
+		{Email: "parkr@jithub.com", ResponseStatus: "declined", Self: true},
+		{Email: "mona@jithub.com", ResponseStatus: "accepted"},
+		{Email: "hubot@jithub.com", ResponseStatus: "accepted", Optional: true},
+		{Email: "kevin@jithub.com", ResponseStatus: "tentative"},
+	}}, nil)
+
+	assert.Equal(t, "4 attendees, 2 accepted", meeting.AttendeeSummary())
+	assert.Equal(t, ResponseDeclined, meeting.MyResponse())
+
+	accepted := meeting.AttendeesWithResponse(ResponseAccepted)
+	if assert.Len(t, accepted, 2) {
+		assert.Equal(t, "mona@jithub.com", accepted[0].Email)
+		assert.True(t, accepted[1].Optional)
+	}
+	assert.Empty(t, meeting.AttendeesWithResponse(ResponseNeedsAction))
+
+	assert.Equal(t, "", Meeting{}.AttendeeSummary())
+	assert.Equal(t, "", Meeting{}.MyResponse())
+}
+
+func TestMeetingSummaryWithOptions(t *testing.T) {
+	event := &calendar.Event{
+		Summary:   "Planning",
+		Organizer: &calendar.EventOrganizer{DisplayName: "Mona Lisa"},
+		Attendees: []*calendar.EventAttendee{
+			{Email: "parkr@jithub.com", ResponseStatus: "needsAction", Self: true},
+			{Email: "mona@jithub.com", ResponseStatus: "accepted"},
+		},
+	}
+
+	assert.Equal(t, `Your next meeting is "Planning", organized by Mona Lisa.`, MeetingSummaryWithOptions(event, SummaryOptions{}))
+	assert.Equal(t, `Your next meeting is "Planning", organized by Mona Lisa. 2 attendees, 1 accepted. You haven't responded.`,
+		MeetingSummaryWithOptions(event, SummaryOptions{Attendees: true}))
+	assert.Equal(t, `Your next meeting is "Solo".`, MeetingSummaryWithOptions(&calendar.Event{Summary: "Solo"}, SummaryOptions{Attendees: true}))
+	assert.Equal(t, "", MeetingSummaryWithOptions(nil, SummaryOptions{Attendees: true}))
+}
+
+func TestMeetingSummaryWithOptions_Meeting(t *testing.T) {
+	meeting := MeetingFromEvent(&calendar.Event{
+		Summary:   "Planning",
+		Organizer: &calendar.EventOrganizer{DisplayName: "Mona Lisa"},
+		Attendees: []*calendar.EventAttendee{
+			{Email: "parkr@jithub.com", ResponseStatus: "needsAction", Self: true},
+			{Email: "mona@jithub.com", ResponseStatus: "accepted"},
+		},
+	}, nil)
+
+	assert.Equal(t, `Your next meeting is "Planning", organized by Mona Lisa.`, meeting.Summary(SummaryOptions{}))
+	assert.Equal(t, `Your next meeting is "Planning", organized by Mona Lisa. 2 attendees, 1 accepted. You haven't responded.`,
+		meeting.Summary(SummaryOptions{Attendees: true}))
+	assert.Equal(t, `Your next meeting is "Solo".`, Meeting{Title: "Solo"}.Summary(SummaryOptions{Attendees: true}))
+	assert.Equal(t, "You have a meeting coming up.", Meeting{}.Summary(SummaryOptions{}))
+}
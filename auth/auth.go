@@ -0,0 +1,200 @@
+// Package auth authorizes access to Google Calendar: it runs the OAuth flow the first time,
+// keeps the token in the OS keychain or a file, and refreshes it as it expires.
+package auth
+
+import (
+	"context"
+	"errors"
+	"io"
+	"os"
+
+	"github.com/skratchdot/open-golang/open"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// Flow is a way of asking the user to authorize access to their calendar.
+type Flow int
+
+const (
+	// LocalRedirect opens the authorization page in a browser, and receives the result on
+	// a temporary server listening on the loopback interface.
+	LocalRedirect Flow = iota
+
+	// DeviceCode prints a URL and a code to enter there, on any device, and waits for the
+	// user to do so. It suits machines without a browser, such as over SSH.
+	DeviceCode
+)
+
+// String returns the flow's name, e.g. "device code".
+func (f Flow) String() string {
+	switch f {
+	case LocalRedirect:
+		return "local redirect"
+	case DeviceCode:
+		return "device code"
+	}
+	return "unknown"
+}
+
+// Config describes how to authorize access to Google Calendar.
+type Config struct {
+	// OAuth is the client configuration, e.g. from google.ConfigFromJSON. It must include
+	// the calendar scope.
+	OAuth *oauth2.Config
+
+	// Store keeps the token between runs. Nil means DefaultStore.
+	Store TokenStore
+
+	// Flow is used to authorize when Store has no token.
+	Flow Flow
+
+	// Output is where instructions for the user are written. Nil means os.Stderr.
+	Output io.Writer
+
+	// OpenBrowser opens the authorization page for the LocalRedirect flow. Nil means the
+	// system's default browser.
+	OpenBrowser func(url string) error
+
+	// DeviceAuthURL is the device authorization endpoint for the DeviceCode flow.
+	// Empty means Google's.
+	DeviceAuthURL string
+
+	// ServiceAccount, if set, authenticates as a service account instead, ignoring the
+	// other fields. Set its Subject to impersonate a user or room resource in your domain
+	// using domain-wide delegation. See config.ReadGoogleServiceAccountConfigFromFile.
+	ServiceAccount *jwt.Config
+
+	// Logger, if set, is told where the token came from and when it is refreshed.
+	Logger Logger
+}
+
+// Logger receives debug messages, each followed by alternating keys and values, so a
+// *slog.Logger or a zoom.Logger can be used.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+}
+
+// NewService returns a Google Calendar service authorized by the config. If no token has
+// been stored yet, the user is asked to authorize access first. Tokens are refreshed as
+// they expire, and the refreshed token is stored again.
+func NewService(ctx context.Context, config Config) (*calendar.Service, error) {
+	if config.ServiceAccount != nil {
+		config.logger().Debug("authenticating as a service account", "email", config.ServiceAccount.Email, "subject", config.ServiceAccount.Subject)
+		service, err := calendar.New(config.ServiceAccount.Client(ctx))
+		return service, err
+	}
+	if config.OAuth == nil {
+		return nil, errors.New("missing OAuth client config")
+	}
+	store := config.store()
+
+	token, err := store.Token()
+	if errors.Is(err, ErrNoToken) {
+		config.logger().Debug("no token stored, asking for authorization")
+		token, err = Login(ctx, config)
+	} else if err == nil {
+		config.logger().Debug("using stored token", "expiry", token.Expiry)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	source := &storingTokenSource{
+		base:   config.OAuth.TokenSource(ctx, token),
+		store:  store,
+		last:   token,
+		logger: config.logger(),
+	}
+	service, err := calendar.New(oauth2.NewClient(ctx, oauth2.ReuseTokenSource(token, source)))
+	return service, err
+}
+
+// Login asks the user to authorize access using the config's flow, and stores the resulting token.
+func Login(ctx context.Context, config Config) (*oauth2.Token, error) {
+	if config.OAuth == nil {
+		return nil, errors.New("missing OAuth client config")
+	}
+
+	config.logger().Debug("starting authorization", "flow", config.Flow)
+	var token *oauth2.Token
+	var err error
+	switch config.Flow {
+	case DeviceCode:
+		token, err = deviceCodeLogin(ctx, config)
+	default:
+		token, err = localRedirectLogin(ctx, config)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := config.store().StoreToken(token); err != nil {
+		return nil, err
+	}
+	return token, nil
+}
+
+func (c Config) store() TokenStore {
+	if c.Store == nil {
+		return DefaultStore()
+	}
+	return c.Store
+}
+
+func (c Config) output() io.Writer {
+	if c.Output == nil {
+		return os.Stderr
+	}
+	return c.Output
+}
+
+func (c Config) logger() Logger {
+	if c.Logger == nil {
+		return nopLogger{}
+	}
+	return c.Logger
+}
+
+// nopLogger discards every message.
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, args ...interface{}) {}
+
+func (c Config) openBrowser(url string) error {
+	if c.OpenBrowser == nil {
+		return open.Run(url)
+	}
+	return c.OpenBrowser(url)
+}
+
+// storingTokenSource stores every new token obtained from the base source, so that a
+// refreshed token is not lost when the program exits.
+type storingTokenSource struct {
+	base   oauth2.TokenSource
+	store  TokenStore
+	last   *oauth2.Token
+	logger Logger
+}
+
+// Token returns a token from the base source, storing it if it has changed.
+func (s *storingTokenSource) Token() (*oauth2.Token, error) {
+	token, err := s.base.Token()
+	if err != nil {
+		return nil, err
+	}
+
+	if s.last == nil || token.AccessToken != s.last.AccessToken {
+		// Keep the old refresh token if the server didn't send a new one.
+		if token.RefreshToken == "" && s.last != nil {
+			token.RefreshToken = s.last.RefreshToken
+		}
+		s.logger.Debug("storing refreshed token", "expiry", token.Expiry)
+		if err := s.store.StoreToken(token); err != nil {
+			return nil, err
+		}
+		s.last = token
+	}
+	return token, nil
+}
@@ -0,0 +1,291 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/jwt"
+)
+
+// memoryStore is a TokenStore which keeps the token in memory.
+type memoryStore struct {
+	token  *oauth2.Token
+	stored int
+	err    error
+}
+
+func (m *memoryStore) Token() (*oauth2.Token, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if m.token == nil {
+		return nil, ErrNoToken
+	}
+	return m.token, nil
+}
+
+func (m *memoryStore) StoreToken(token *oauth2.Token) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.token = token
+	m.stored++
+	return nil
+}
+
+func newOAuthServer(t *testing.T, mux *http.ServeMux) *oauth2.Config {
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+	return &oauth2.Config{
+		ClientID:     "client-id",
+		ClientSecret: "client-secret",
+		Scopes:       []string{"https://www.googleapis.com/auth/calendar.readonly"},
+		Endpoint: oauth2.Endpoint{
+			AuthURL:  server.URL + "/auth",
+			TokenURL: server.URL + "/token",
+		},
+	}
+}
+
+func TestLogin_LocalRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "authorization_code", r.Form.Get("grant_type"))
+		assert.Equal(t, "the-code", r.Form.Get("code"))
+		assert.True(t, strings.HasPrefix(r.Form.Get("redirect_uri"), "http://127.0.0.1:"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token": "access", "refresh_token": "refresh", "token_type": "Bearer", "expires_in": 3600}`)
+	})
+
+	store := &memoryStore{}
+	config := Config{
+		OAuth:  newOAuthServer(t, mux),
+		Store:  store,
+		Output: &bytes.Buffer{},
+		OpenBrowser: func(authURL string) error {
+			// Play the part of the browser: follow the redirect back with a code.
+			u, err := url.Parse(authURL)
+			require.NoError(t, err)
+			query := u.Query()
+			assert.Equal(t, "offline", query.Get("access_type"))
+
+			go func() {
+				resp, err := http.Get(query.Get("redirect_uri") + "?state=" + query.Get("state") + "&code=the-code")
+				if assert.NoError(t, err) {
+					resp.Body.Close()
+				}
+			}()
+			return nil
+		},
+	}
+
+	token, err := Login(context.Background(), config)
+	require.NoError(t, err)
+	assert.Equal(t, "access", token.AccessToken)
+	assert.Equal(t, "refresh", token.RefreshToken)
+	assert.Equal(t, token, store.token)
+}
+
+func TestLogin_DeviceCode(t *testing.T) {
+	defer func(interval time.Duration) { defaultPollInterval = interval }(defaultPollInterval)
+	defaultPollInterval = time.Millisecond
+
+	polls := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/device/code", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "client-id", r.Form.Get("client_id"))
+		assert.Equal(t, "https://www.googleapis.com/auth/calendar.readonly", r.Form.Get("scope"))
+		fmt.Fprint(w, `{"device_code": "device", "user_code": "ABCD-EFGH", "verification_url": "https://www.google.com/device", "expires_in": 60}`)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, deviceCodeGrantType, r.Form.Get("grant_type"))
+		assert.Equal(t, "device", r.Form.Get("device_code"))
+
+		polls++
+		if polls < 3 {
+			w.WriteHeader(http.StatusBadRequest)
+			fmt.Fprint(w, `{"error": "authorization_pending"}`)
+			return
+		}
+		fmt.Fprint(w, `{"access_token": "access", "refresh_token": "refresh", "token_type": "Bearer", "expires_in": 3600}`)
+	})
+
+	oauthConfig := newOAuthServer(t, mux)
+	var output bytes.Buffer
+	store := &memoryStore{}
+	token, err := Login(context.Background(), Config{
+		OAuth:         oauthConfig,
+		Store:         store,
+		Flow:          DeviceCode,
+		Output:        &output,
+		DeviceAuthURL: strings.TrimSuffix(oauthConfig.Endpoint.TokenURL, "/token") + "/device/code",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "access", token.AccessToken)
+	assert.Equal(t, 3, polls)
+	assert.Equal(t, token, store.token)
+	assert.Contains(t, output.String(), "https://www.google.com/device")
+	assert.Contains(t, output.String(), "ABCD-EFGH")
+}
+
+func TestLogin_DeviceCodeDenied(t *testing.T) {
+	defer func(interval time.Duration) { defaultPollInterval = interval }(defaultPollInterval)
+	defaultPollInterval = time.Millisecond
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/device/code", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"device_code": "device", "user_code": "ABCD-EFGH", "verification_url": "https://www.google.com/device"}`)
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		fmt.Fprint(w, `{"error": "access_denied"}`)
+	})
+
+	oauthConfig := newOAuthServer(t, mux)
+	_, err := Login(context.Background(), Config{
+		OAuth:         oauthConfig,
+		Store:         &memoryStore{},
+		Flow:          DeviceCode,
+		Output:        &bytes.Buffer{},
+		DeviceAuthURL: strings.TrimSuffix(oauthConfig.Endpoint.TokenURL, "/token") + "/device/code",
+	})
+	assert.EqualError(t, err, "authorization was denied")
+}
+
+func TestNewService_RefreshesAndStoresToken(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		assert.Equal(t, "refresh_token", r.Form.Get("grant_type"))
+		assert.Equal(t, "refresh", r.Form.Get("refresh_token"))
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token": "refreshed", "token_type": "Bearer", "expires_in": 3600}`)
+	})
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer refreshed", r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"items": []}`)
+	})
+
+	oauthConfig := newOAuthServer(t, mux)
+	store := &memoryStore{token: &oauth2.Token{AccessToken: "expired", RefreshToken: "refresh", Expiry: time.Now().Add(-time.Hour)}}
+
+	service, err := NewService(context.Background(), Config{OAuth: oauthConfig, Store: store})
+	require.NoError(t, err)
+	service.BasePath = strings.TrimSuffix(oauthConfig.Endpoint.TokenURL, "token")
+
+	_, err = service.Events.List("primary").Do()
+	require.NoError(t, err)
+	assert.Equal(t, 1, store.stored)
+	assert.Equal(t, "refreshed", store.token.AccessToken)
+	assert.Equal(t, "refresh", store.token.RefreshToken, "the refresh token is kept")
+}
+
+func TestNewService_Errors(t *testing.T) {
+	_, err := NewService(context.Background(), Config{})
+	assert.EqualError(t, err, "missing OAuth client config")
+
+	_, err = NewService(context.Background(), Config{OAuth: &oauth2.Config{}, Store: &memoryStore{err: errors.New("locked")}})
+	assert.EqualError(t, err, "locked")
+}
+
+func TestFileStore(t *testing.T) {
+	store := &FileStore{Path: filepath.Join(t.TempDir(), "zoom-go", "token.json")}
+
+	_, err := store.Token()
+	assert.Equal(t, ErrNoToken, err)
+
+	require.NoError(t, store.StoreToken(&oauth2.Token{AccessToken: "access", RefreshToken: "refresh"}))
+	token, err := store.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "access", token.AccessToken)
+	assert.Equal(t, "refresh", token.RefreshToken)
+}
+
+func TestNewAccountStore(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("HOME", dir)
+	t.Setenv("PATH", "") // no keychain
+
+	work, personal := NewAccountStore("work"), NewAccountStore("personal")
+	require.NoError(t, work.StoreToken(&oauth2.Token{AccessToken: "work"}))
+	_, err := personal.Token()
+	assert.Equal(t, ErrNoToken, err, "each account has its own token")
+
+	token, err := NewAccountStore("work").Token()
+	require.NoError(t, err)
+	assert.Equal(t, "work", token.AccessToken)
+}
+
+func TestFallbackStore(t *testing.T) {
+	primary := &memoryStore{err: errors.New("keychain is locked")}
+	secondary := &memoryStore{}
+	store := &FallbackStore{Primary: primary, Secondary: secondary}
+
+	require.NoError(t, store.StoreToken(&oauth2.Token{AccessToken: "access"}))
+	assert.Equal(t, 1, secondary.stored)
+
+	token, err := store.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "access", token.AccessToken)
+}
+
+func TestNewService_ServiceAccount(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		require.NoError(t, r.ParseForm())
+		parts := strings.Split(r.Form.Get("assertion"), ".")
+		require.Len(t, parts, 3)
+		claims, err := base64.RawURLEncoding.DecodeString(parts[1])
+		require.NoError(t, err)
+		assert.Contains(t, string(claims), `"sub":"boardroom@jithub.com"`)
+		assert.Contains(t, string(claims), `"iss":"kiosk@jithub.iam.gserviceaccount.com"`)
+
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprint(w, `{"access_token": "delegated", "token_type": "Bearer", "expires_in": 3600}`)
+	})
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer delegated", r.Header.Get("Authorization"))
+		fmt.Fprint(w, `{"items": []}`)
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	service, err := NewService(context.Background(), Config{ServiceAccount: &jwt.Config{
+		Email:      "kiosk@jithub.iam.gserviceaccount.com",
+		PrivateKey: pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der}),
+		Scopes:     []string{"https://www.googleapis.com/auth/calendar.readonly"},
+		TokenURL:   server.URL + "/token",
+		Subject:    "boardroom@jithub.com",
+	}})
+	require.NoError(t, err)
+	service.BasePath = server.URL + "/"
+
+	_, err = service.Events.List("primary").Do()
+	require.NoError(t, err)
+}
@@ -0,0 +1,157 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"golang.org/x/net/context/ctxhttp"
+	"golang.org/x/oauth2"
+)
+
+// googleDeviceAuthURL is Google's device authorization endpoint.
+const googleDeviceAuthURL = "https://oauth2.googleapis.com/device/code"
+
+// deviceCodeGrantType is the grant type used to poll for a device code's token.
+const deviceCodeGrantType = "urn:ietf:params:oauth:grant-type:device_code"
+
+// defaultPollInterval is how often to poll for a device token when the server doesn't say,
+// and how much longer to wait when it asks us to slow down. It is a variable so tests can poll faster.
+var defaultPollInterval = 5 * time.Second
+
+// deviceCode is the response from the device authorization endpoint.
+type deviceCode struct {
+	DeviceCode      string `json:"device_code"`
+	UserCode        string `json:"user_code"`
+	VerificationURL string `json:"verification_url"`
+	VerificationURI string `json:"verification_uri"`
+	ExpiresIn       int    `json:"expires_in"`
+	Interval        int    `json:"interval"`
+}
+
+// deviceTokenResponse is the response when polling the token endpoint.
+type deviceTokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+	Error        string `json:"error"`
+}
+
+// deviceCodeLogin asks the user to enter a code at a URL on any device, and polls until they have.
+func deviceCodeLogin(ctx context.Context, config Config) (*oauth2.Token, error) {
+	authURL := config.DeviceAuthURL
+	if authURL == "" {
+		authURL = googleDeviceAuthURL
+	}
+
+	code, err := requestDeviceCode(ctx, authURL, config.OAuth)
+	if err != nil {
+		return nil, err
+	}
+
+	verificationURL := code.VerificationURL
+	if verificationURL == "" {
+		verificationURL = code.VerificationURI
+	}
+	fmt.Fprintf(config.output(), "To authorize access to your calendar, visit %s and enter the code %s\n", verificationURL, code.UserCode)
+
+	interval := time.Duration(code.Interval) * time.Second
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+	if code.ExpiresIn > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, time.Duration(code.ExpiresIn)*time.Second)
+		defer cancel()
+	}
+
+	for {
+		select {
+		case <-time.After(interval):
+		case <-ctx.Done():
+			return nil, fmt.Errorf("waiting for authorization: %w", ctx.Err())
+		}
+
+		token, status, err := pollDeviceToken(ctx, config.OAuth, code.DeviceCode)
+		if err != nil {
+			return nil, err
+		}
+		config.logger().Debug("polled for device authorization", "status", status)
+		switch status {
+		case "":
+			return token, nil
+		case "authorization_pending":
+		case "slow_down":
+			interval += defaultPollInterval
+		case "access_denied":
+			return nil, errors.New("authorization was denied")
+		case "expired_token":
+			return nil, errors.New("authorization code expired")
+		default:
+			return nil, fmt.Errorf("authorization failed: %s", status)
+		}
+	}
+}
+
+// requestDeviceCode asks the authorization server for a code for the user to enter.
+func requestDeviceCode(ctx context.Context, authURL string, conf *oauth2.Config) (*deviceCode, error) {
+	resp, err := ctxhttp.PostForm(ctx, nil, authURL, url.Values{
+		"client_id": {conf.ClientID},
+		"scope":     {strings.Join(conf.Scopes, " ")},
+	})
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("requesting device code: %s", resp.Status)
+	}
+	code := &deviceCode{}
+	if err := json.NewDecoder(resp.Body).Decode(code); err != nil {
+		return nil, fmt.Errorf("requesting device code: %w", err)
+	}
+	return code, nil
+}
+
+// pollDeviceToken asks the token endpoint whether the device code has been authorized.
+// It returns the token, or the error code from the server such as "authorization_pending".
+func pollDeviceToken(ctx context.Context, conf *oauth2.Config, deviceCode string) (*oauth2.Token, string, error) {
+	resp, err := ctxhttp.PostForm(ctx, nil, conf.Endpoint.TokenURL, url.Values{
+		"client_id":     {conf.ClientID},
+		"client_secret": {conf.ClientSecret},
+		"device_code":   {deviceCode},
+		"grant_type":    {deviceCodeGrantType},
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+
+	var body deviceTokenResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, "", fmt.Errorf("polling for device token: %w", err)
+	}
+	if body.Error != "" {
+		return nil, body.Error, nil
+	}
+	if resp.StatusCode != http.StatusOK || body.AccessToken == "" {
+		return nil, "", fmt.Errorf("polling for device token: %s", resp.Status)
+	}
+
+	token := &oauth2.Token{
+		AccessToken:  body.AccessToken,
+		TokenType:    body.TokenType,
+		RefreshToken: body.RefreshToken,
+	}
+	if body.ExpiresIn > 0 {
+		token.Expiry = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	}
+	return token, "", nil
+}
@@ -0,0 +1,124 @@
+package auth
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"os/exec"
+	"runtime"
+	"strings"
+
+	"golang.org/x/oauth2"
+)
+
+// KeychainStore keeps the token in the OS keychain: the login keychain on macOS, via the
+// security command, or the Secret Service on Linux, via secret-tool.
+type KeychainStore struct {
+	// Service and Account identify the token within the keychain.
+	Service string
+	Account string
+
+	// GOOS selects the keychain to use. Empty means runtime.GOOS.
+	GOOS string
+
+	// Run runs a command with the given standard input and returns its standard output.
+	// Nil means running it with os/exec.
+	Run func(stdin string, name string, args ...string) (string, error)
+}
+
+// NewKeychainStore returns a store which keeps the token under the service and account in the OS keychain.
+func NewKeychainStore(service, account string) *KeychainStore {
+	return &KeychainStore{Service: service, Account: account}
+}
+
+// Available returns true if the keychain's command is installed.
+func (k *KeychainStore) Available() bool {
+	name := k.command()
+	if name == "" {
+		return false
+	}
+	if k.Run != nil {
+		return true
+	}
+	_, err := exec.LookPath(name)
+	return err == nil
+}
+
+// Token reads the token from the keychain.
+func (k *KeychainStore) Token() (*oauth2.Token, error) {
+	var out string
+	var err error
+	switch k.command() {
+	case "security":
+		out, err = k.run("", "security", "find-generic-password", "-s", k.Service, "-a", k.Account, "-w")
+	case "secret-tool":
+		out, err = k.run("", "secret-tool", "lookup", "service", k.Service, "account", k.Account)
+	default:
+		return nil, fmt.Errorf("no keychain is supported on %s", k.goos())
+	}
+	if err != nil || strings.TrimSpace(out) == "" {
+		// Both commands fail when the item is missing, which can't be told apart from
+		// other failures without parsing localized messages.
+		return nil, ErrNoToken
+	}
+
+	token := &oauth2.Token{}
+	if err := json.Unmarshal([]byte(strings.TrimSpace(out)), token); err != nil {
+		return nil, fmt.Errorf("reading token from keychain: %w", err)
+	}
+	return token, nil
+}
+
+// StoreToken writes the token to the keychain, replacing any token already there.
+func (k *KeychainStore) StoreToken(token *oauth2.Token) error {
+	b, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+
+	switch k.command() {
+	case "security":
+		_, err = k.run("", "security", "add-generic-password", "-U", "-s", k.Service, "-a", k.Account, "-w", string(b))
+	case "secret-tool":
+		_, err = k.run(string(b), "secret-tool", "store", "--label="+k.Service, "service", k.Service, "account", k.Account)
+	default:
+		return fmt.Errorf("no keychain is supported on %s", k.goos())
+	}
+	if err != nil {
+		return fmt.Errorf("storing token in keychain: %w", err)
+	}
+	return nil
+}
+
+// command returns the keychain command for the platform, or "" if there is none.
+func (k *KeychainStore) command() string {
+	switch k.goos() {
+	case "darwin":
+		return "security"
+	case "linux", "freebsd", "openbsd", "netbsd":
+		return "secret-tool"
+	}
+	return ""
+}
+
+func (k *KeychainStore) goos() string {
+	if k.GOOS == "" {
+		return runtime.GOOS
+	}
+	return k.GOOS
+}
+
+func (k *KeychainStore) run(stdin string, name string, args ...string) (string, error) {
+	if k.Run != nil {
+		return k.Run(stdin, name, args...)
+	}
+
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(stdin)
+	var stdout bytes.Buffer
+	cmd.Stdout = &stdout
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("running %s: %w", name, err)
+	}
+	return stdout.String(), nil
+}
@@ -0,0 +1,68 @@
+package auth
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/oauth2"
+)
+
+// fakeKeychain records the commands run by a KeychainStore and stores a single secret.
+type fakeKeychain struct {
+	commands []string
+	secret   string
+}
+
+func (f *fakeKeychain) run(stdin string, name string, args ...string) (string, error) {
+	f.commands = append(f.commands, name+" "+strings.Join(args, " "))
+	switch {
+	case name == "security" && args[0] == "add-generic-password":
+		f.secret = args[len(args)-1]
+	case name == "secret-tool" && args[0] == "store":
+		f.secret = stdin
+	case f.secret == "":
+		return "", errors.New("item not found")
+	}
+	return f.secret + "\n", nil
+}
+
+func TestKeychainStore_Darwin(t *testing.T) {
+	keychain := &fakeKeychain{}
+	store := &KeychainStore{Service: "zoom-go", Account: "google", GOOS: "darwin", Run: keychain.run}
+	assert.True(t, store.Available())
+
+	_, err := store.Token()
+	assert.Equal(t, ErrNoToken, err)
+
+	require.NoError(t, store.StoreToken(&oauth2.Token{AccessToken: "access"}))
+	token, err := store.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "access", token.AccessToken)
+
+	assert.Equal(t, "security find-generic-password -s zoom-go -a google -w", keychain.commands[0])
+	assert.True(t, strings.HasPrefix(keychain.commands[1], "security add-generic-password -U -s zoom-go -a google -w {"))
+}
+
+func TestKeychainStore_Linux(t *testing.T) {
+	keychain := &fakeKeychain{}
+	store := &KeychainStore{Service: "zoom-go", Account: "google", GOOS: "linux", Run: keychain.run}
+
+	require.NoError(t, store.StoreToken(&oauth2.Token{AccessToken: "access"}))
+	token, err := store.Token()
+	require.NoError(t, err)
+	assert.Equal(t, "access", token.AccessToken)
+
+	assert.Equal(t, []string{
+		"secret-tool store --label=zoom-go service zoom-go account google",
+		"secret-tool lookup service zoom-go account google",
+	}, keychain.commands)
+}
+
+func TestKeychainStore_Unsupported(t *testing.T) {
+	store := &KeychainStore{Service: "zoom-go", Account: "google", GOOS: "windows"}
+	assert.False(t, store.Available())
+	assert.Error(t, store.StoreToken(&oauth2.Token{}))
+}
@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"net/http"
+
+	"golang.org/x/oauth2"
+)
+
+// localRedirectLogin opens the authorization page in a browser and waits for it to
+// redirect back to a server on the loopback interface with the authorization code.
+func localRedirectLogin(ctx context.Context, config Config) (*oauth2.Token, error) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		return nil, err
+	}
+	defer listener.Close()
+
+	state, err := randomState()
+	if err != nil {
+		return nil, err
+	}
+
+	conf := *config.OAuth
+	conf.RedirectURL = "http://" + listener.Addr().String() + "/"
+
+	type result struct {
+		code string
+		err  error
+	}
+	results := make(chan result, 1)
+
+	server := &http.Server{Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		switch {
+		case query.Get("state") != state:
+			http.Error(w, "Invalid state.", http.StatusBadRequest)
+			return
+		case query.Get("error") != "":
+			http.Error(w, "Authorization failed: "+query.Get("error"), http.StatusForbidden)
+			results <- result{err: fmt.Errorf("authorization failed: %s", query.Get("error"))}
+			return
+		}
+		fmt.Fprintln(w, "Authorized. You can close this window and return to your terminal.")
+		results <- result{code: query.Get("code")}
+	})}
+	go server.Serve(listener)
+	defer server.Close()
+
+	authURL := conf.AuthCodeURL(state, oauth2.AccessTypeOffline)
+	fmt.Fprintf(config.output(), "Opening your browser to authorize access to your calendar. If it doesn't open, visit:\n\n%s\n\n", authURL)
+	if err := config.openBrowser(authURL); err != nil {
+		fmt.Fprintf(config.output(), "Unable to open your browser: %v\n", err)
+	}
+
+	select {
+	case res := <-results:
+		if res.err != nil {
+			return nil, res.err
+		}
+		token, err := conf.Exchange(ctx, res.code)
+		return token, err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// randomState returns an unguessable value which ties the redirect to this login.
+func randomState() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,117 @@
+package auth
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/oauth2"
+)
+
+// ErrNoToken indicates that no token has been stored yet.
+var ErrNoToken = errors.New("no token stored")
+
+// keychainService is the name tokens are stored under in the OS keychain.
+const keychainService = "zoom-go"
+
+// TokenStore keeps an OAuth token between runs.
+type TokenStore interface {
+	// Token returns the stored token, or ErrNoToken if there is none.
+	Token() (*oauth2.Token, error)
+
+	// StoreToken replaces the stored token.
+	StoreToken(*oauth2.Token) error
+}
+
+// DefaultStore returns a store which keeps the token in the OS keychain if one is
+// available, and otherwise in a file in your user config directory.
+func DefaultStore() TokenStore {
+	return newStore("google", tokenPath("token.json"))
+}
+
+// NewAccountStore returns a store like DefaultStore which keeps the token of the named
+// account, such as "work", apart from the tokens of your other accounts.
+func NewAccountStore(name string) TokenStore {
+	return newStore("google:"+name, tokenPath(filepath.Join("tokens", name+".json")))
+}
+
+// newStore returns a store which keeps the token under the keychain account if the
+// keychain is available, and otherwise in the file.
+func newStore(account, path string) TokenStore {
+	file := &FileStore{Path: path}
+	if keychain := NewKeychainStore(keychainService, account); keychain.Available() {
+		return &FallbackStore{Primary: keychain, Secondary: file}
+	}
+	return file
+}
+
+// tokenPath returns the file, relative to the zoom-go directory in your user config
+// directory, in which a token is stored when there is no keychain.
+func tokenPath(name string) string {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		dir = "."
+	}
+	return filepath.Join(dir, "zoom-go", name)
+}
+
+// FileStore keeps the token in a JSON file, readable only by you.
+type FileStore struct {
+	Path string
+}
+
+// Token reads the token from the file.
+func (f *FileStore) Token() (*oauth2.Token, error) {
+	b, err := ioutil.ReadFile(f.Path)
+	if os.IsNotExist(err) {
+		return nil, ErrNoToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	token := &oauth2.Token{}
+	if err := json.Unmarshal(b, token); err != nil {
+		return nil, fmt.Errorf("reading token from %s: %w", f.Path, err)
+	}
+	return token, nil
+}
+
+// StoreToken writes the token to the file, creating its directory if needed.
+func (f *FileStore) StoreToken(token *oauth2.Token) error {
+	b, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(f.Path), 0700); err != nil {
+		return err
+	}
+	return ioutil.WriteFile(f.Path, b, 0600)
+}
+
+// FallbackStore uses the Primary store, and the Secondary store whenever the primary
+// can't be used, such as a keychain which is locked or not running.
+type FallbackStore struct {
+	Primary   TokenStore
+	Secondary TokenStore
+}
+
+// Token returns the token from the primary store, or else from the secondary store.
+func (s *FallbackStore) Token() (*oauth2.Token, error) {
+	token, err := s.Primary.Token()
+	if err == nil {
+		return token, nil
+	}
+	return s.Secondary.Token()
+}
+
+// StoreToken stores the token in the primary store, or else in the secondary store.
+func (s *FallbackStore) StoreToken(token *oauth2.Token) error {
+	if err := s.Primary.StoreToken(token); err == nil {
+		return nil
+	}
+	return s.Secondary.StoreToken(token)
+}
@@ -0,0 +1,61 @@
+package zoom
+
+import (
+	"time"
+)
+
+// BackToBackGap is the longest break between two meetings which WriteAgenda still notes
+// as back to back.
+var BackToBackGap = 5 * time.Minute
+
+// BackToBack returns true if next starts no more than gap after previous ends, leaving
+// little or no break between them. Meetings which overlap conflict rather than being back
+// to back, and meetings without both a start and an end never are.
+func BackToBack(previous, next Meeting, gap time.Duration) bool {
+	if !isScheduled(previous) || !isScheduled(next) {
+		return false
+	}
+	between := next.Start.Sub(previous.End)
+	return between >= 0 && between <= gap
+}
+
+// NextBackToBack returns the first of the meetings which starts back to back with the end
+// of the meeting, given the longest break between them, and false if none does.
+func NextBackToBack(meeting Meeting, meetings []Meeting, gap time.Duration) (Meeting, bool) {
+	var next Meeting
+	found := false
+	for _, other := range meetings {
+		if BackToBack(meeting, other, gap) && (!found || other.Start.Before(next.Start)) {
+			next, found = other, true
+		}
+	}
+	return next, found
+}
+
+// BackToBackSummary describes the meeting which starts back to back with the end of the
+// meeting, such as "back-to-back with 'Design review'". It is empty if none of the
+// meetings does.
+func BackToBackSummary(meeting Meeting, meetings []Meeting, gap time.Duration) string {
+	next, ok := NextBackToBack(meeting, meetings, gap)
+	if !ok {
+		return ""
+	}
+	return "back-to-back with '" + next.Title + "'"
+}
+
+// TimeRange describes when the meeting is scheduled, such as "2:00 PM–2:30 PM (30m)", in
+// Location and with Formatter. It is only the start time if the end is unknown, empty if
+// the start is, and "all day" for all-day events.
+func (m Meeting) TimeRange() string {
+	if m.Start.IsZero() {
+		return ""
+	}
+	if m.AllDay {
+		return "all day"
+	}
+	start := Formatter.AbsoluteTime(InLocation(m.Start))
+	if m.End.IsZero() {
+		return start
+	}
+	return start + "–" + Formatter.AbsoluteTime(InLocation(m.End)) + " (" + formatDuration(m.Duration()) + ")"
+}
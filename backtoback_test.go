@@ -0,0 +1,46 @@
+package zoom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBackToBack(t *testing.T) {
+	at := func(hour, minute int) time.Time { return time.Date(2018, 10, 10, hour, minute, 0, 0, time.UTC) }
+	standup := Meeting{Title: "Standup", Start: at(9, 0), End: at(9, 30)}
+
+	testCases := []struct {
+		next     Meeting
+		expected bool
+	}{
+		{Meeting{Start: at(9, 30), End: at(10, 0)}, true},
+		{Meeting{Start: at(9, 35), End: at(10, 0)}, true},
+		{Meeting{Start: at(9, 36), End: at(10, 0)}, false},
+		{Meeting{Start: at(9, 15), End: at(10, 0)}, false},
+		{Meeting{Start: at(9, 30)}, false},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, BackToBack(standup, testCase.next, 5*time.Minute), "next starts at %s", testCase.next.Start)
+	}
+	assert.False(t, BackToBack(Meeting{Start: at(9, 0)}, Meeting{Start: at(9, 0), End: at(9, 30)}, time.Hour))
+
+	review := Meeting{Title: "Design review", Start: at(9, 35), End: at(10, 0)}
+	oneOnOne := Meeting{Title: "1:1", Start: at(9, 30), End: at(10, 0)}
+	next, ok := NextBackToBack(standup, []Meeting{standup, review, oneOnOne}, 5*time.Minute)
+	assert.True(t, ok)
+	assert.Equal(t, "1:1", next.Title)
+	assert.Equal(t, "back-to-back with '1:1'", BackToBackSummary(standup, []Meeting{standup, review, oneOnOne}, 5*time.Minute))
+	assert.Equal(t, "", BackToBackSummary(standup, []Meeting{standup, review}, time.Minute))
+}
+
+func TestMeetingTimeRange(t *testing.T) {
+	defer func(location *time.Location) { Location = location }(Location)
+	Location = time.UTC
+
+	start := time.Date(2018, 10, 10, 14, 0, 0, 0, time.UTC)
+	assert.Equal(t, "2:00 PM–2:30 PM (30m)", Meeting{Start: start, End: start.Add(30 * time.Minute)}.TimeRange())
+	assert.Equal(t, "2:00 PM", Meeting{Start: start}.TimeRange())
+	assert.Equal(t, "", Meeting{}.TimeRange())
+}
@@ -0,0 +1,130 @@
+package zoom
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// A day of a busy calendar, as the daemon and zoom agenda see it when they refresh: most
+// events have no meeting link at all, and the invitations which do are long, and often
+// HTML from Outlook with every link wrapped. Zoom invitations are zoomInvitation.
+const (
+	plainDescription = "Agenda:\n1. Review last week's action items\n2. Roadmap for Q3\n3. Open questions\n\n" +
+		"Please read the doc ahead of time, and add anything you'd like to discuss to the end of it. " +
+		"If you can't make it, let me know and I'll send out notes afterwards.\n"
+
+	teamsInvitation = `<html><head><meta http-equiv="Content-Type" content="text/html; charset=utf-8"></head><body>` +
+		`<div style="width:100%;height:20px;"><span style="white-space:nowrap;color:#5F5F5F;opacity:.36;">________________________________________________________________________________</span></div>` +
+		`<div class="me-email-text" lang="en-US" style="color:#252424;font-family:'Segoe UI','Helvetica Neue',Helvetica,Arial,sans-serif;">` +
+		`<div style="margin-top:24px;margin-bottom:20px;"><span style="font-size:24px;color:#252424;">Microsoft Teams meeting</span></div>` +
+		`<div style="margin-bottom:20px;"><div style="margin-top:0px;margin-bottom:0px;font-weight:bold;"><span style="font-size:14px;color:#252424;">Join on your computer, mobile app or room device</span></div>` +
+		`<a class="me-email-headline" href="https://nam12.safelinks.protection.outlook.com/?url=https%3A%2F%2Fteams.microsoft.com%2Fl%2Fmeetup-join%2F19%253ameeting_NjQ5ZGY0%2540thread.v2%2F0%3Fcontext%3D%257b%2522Tid%2522%253a%2522abc%2522%257d&amp;data=05%7C01%7Cparkr%40jithub.com%7C1234&amp;sdata=abc%3D&amp;reserved=0" target="_blank" rel="noreferrer noopener" style="font-size:14px;font-family:'Segoe UI Semibold','Segoe UI','Helvetica Neue',Helvetica,Arial,sans-serif;text-decoration:underline;color:#6264a7;">Click here to join the meeting</a></div>` +
+		`<div style="margin-bottom:20px;margin-top:20px;"><div style="margin-bottom:4px;"><span data-tid="meeting-code" style="font-size:14px;color:#252424;">Meeting ID: <span style="font-size:16px;color:#252424;">212 345 678 901</span></span><br><span style="font-size:14px;color:#252424;">Passcode: </span><span style="font-size:16px;color:#252424;">aBcDeF</span></div>` +
+		`<div style="font-size:14px;"><a class="me-email-link" style="font-size:14px;text-decoration:underline;color:#6264a7;font-family:'Segoe UI','Helvetica Neue',Helvetica,Arial,sans-serif;" target="_blank" href="https://nam12.safelinks.protection.outlook.com/?url=https%3A%2F%2Fwww.microsoft.com%2Fen-us%2Fmicrosoft-teams%2Fdownload-app&amp;data=05%7C01&amp;reserved=0" rel="noreferrer noopener">Download Teams</a> | <a class="me-email-link" style="font-size:14px;text-decoration:underline;color:#6264a7;" target="_blank" href="https://nam12.safelinks.protection.outlook.com/?url=https%3A%2F%2Fwww.microsoft.com%2Fmicrosoft-teams%2Fjoin-a-meeting&amp;data=05%7C01&amp;reserved=0" rel="noreferrer noopener">Join on the web</a></div></div>` +
+		`<div style="margin-bottom:24px;margin-top:20px;"><a class="me-email-link" target="_blank" href="https://nam12.safelinks.protection.outlook.com/?url=https%3A%2F%2Faka.ms%2FJoinTeamsMeeting&amp;data=05%7C01&amp;reserved=0" rel="noreferrer noopener" style="font-size:14px;text-decoration:underline;color:#6264a7;">Learn More</a> | <a class="me-email-link" target="_blank" href="https://nam12.safelinks.protection.outlook.com/?url=https%3A%2F%2Fteams.microsoft.com%2FmeetingOptions%2F%3ForganizerId%3Dabc&amp;data=05%7C01&amp;reserved=0" rel="noreferrer noopener" style="font-size:14px;text-decoration:underline;color:#6264a7;">Meeting options</a></div></div>` +
+		`<div style="font-size:14px;margin-bottom:4px;">&nbsp;</div></body></html>`
+
+	meetDescription = "-::~:~::~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~::~:~::-\n" +
+		"Join with Google Meet: https://meet.google.com/abc-defg-hij\n\nJoin by phone\n(US) +1 347-378-4452 PIN: 123456789#\n\n" +
+		"More phone numbers: https://tel.meet/abc-defg-hij?pin=1234567890\n\n" +
+		"Learn more about Meet at: https://support.google.com/a/users/answer/9282720\n\n" +
+		"Please do not edit this section.\n-::~:~::~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~:~::~:~::-"
+
+	docsDescription = `<p>Pre-reads:</p><ul>` +
+		`<li><a href="https://www.google.com/url?q=https://docs.google.com/document/d/1AbCdEfGhIjKlMnOpQrStUvWxYz/edit&amp;sa=D&amp;source=calendar&amp;ust=1539000000">Design doc</a></li>` +
+		`<li><a href="https://www.google.com/url?q=https://docs.google.com/spreadsheets/d/1ZyXwVuTsRqPoNmLkJiHgFeDcBa/edit&amp;sa=D&amp;source=calendar&amp;ust=1539000000">Metrics</a></li>` +
+		`<li><a href="https://www.google.com/url?q=https://github.com/benbalter/zoom-go/issues/123&amp;sa=D&amp;source=calendar&amp;ust=1539000000">Tracking issue</a></li>` +
+		`</ul><p>We'll use the <b>usual room</b> on the 3rd floor, or the Zoom link in the location if you're remote.</p>`
+)
+
+// benchmarkEvents returns n events, in the proportions of a typical calendar.
+func benchmarkEvents(n int) []*calendar.Event {
+	events := make([]*calendar.Event, 0, n)
+	for i := 0; len(events) < n; i++ {
+		id := fmt.Sprintf("event%d", i)
+		var event *calendar.Event
+		switch i % 10 {
+		case 0, 1, 2, 3:
+			event = &calendar.Event{Id: id, Summary: "Focus time", Description: plainDescription}
+		case 4, 5:
+			event = &calendar.Event{Id: id, Summary: "1:1", Location: "https://jithub.zoom.us/j/12345678901?pwd=abc123", Description: zoomInvitation}
+		case 6:
+			event = &calendar.Event{Id: id, Summary: "Vendor sync", Location: "Microsoft Teams Meeting", Description: teamsInvitation}
+		case 7, 8:
+			event = &calendar.Event{
+				Id:          id,
+				Summary:     "Planning",
+				HangoutLink: "https://meet.google.com/abc-defg-hij",
+				Description: meetDescription,
+				ConferenceData: &calendar.ConferenceData{EntryPoints: []*calendar.EntryPoint{
+					{EntryPointType: "video", Uri: "https://meet.google.com/abc-defg-hij"},
+				}},
+			}
+		default:
+			event = &calendar.Event{Id: id, Summary: "Design review", Location: "Boardroom", Description: docsDescription}
+		}
+		events = append(events, event)
+	}
+	return events
+}
+
+func TestBenchmarkEvents(t *testing.T) {
+	// The corpus should exercise every way a link is found, so the benchmarks are realistic.
+	providers := map[string]int{}
+	for _, event := range benchmarkEvents(10) {
+		providers[MeetingFromEvent(event, AllProviders).Provider]++
+	}
+	if providers["Zoom"] != 2 || providers["Microsoft Teams"] != 1 || providers["Google Meet"] != 2 || providers[""] != 5 {
+		t.Errorf("unexpected meetings in corpus: %v", providers)
+	}
+	if !strings.Contains(MeetingFromEvent(benchmarkEvents(10)[6], AllProviders).JoinURL.String(), "meetup-join") {
+		t.Error("the Teams link should be unwrapped from its safelink")
+	}
+}
+
+func BenchmarkMeetingFromEvent(b *testing.B) {
+	events := benchmarkEvents(200)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, event := range events {
+			MeetingFromEvent(event, AllProviders)
+		}
+	}
+}
+
+func BenchmarkMeetingFromEvent_Zoom(b *testing.B) {
+	events := benchmarkEvents(200)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, event := range events {
+			MeetingFromEvent(event, nil)
+		}
+	}
+}
+
+func BenchmarkMeetingURLCandidates(b *testing.B) {
+	events := benchmarkEvents(200)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, event := range events {
+			MeetingURLCandidates(event, AllProviders)
+		}
+	}
+}
+
+func BenchmarkMeetingURLsFromEvent(b *testing.B) {
+	events := benchmarkEvents(200)
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		for _, event := range events {
+			MeetingURLsFromEvent(event)
+		}
+	}
+}
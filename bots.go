@@ -0,0 +1,83 @@
+package zoom
+
+import (
+	"strings"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// BotScheduler describes a scheduling tool which creates calendar events on someone's behalf.
+type BotScheduler struct {
+	// Name is the human-friendly name of the tool, e.g. "Calendly".
+	Name string
+
+	// CreatorDomains are the email domains the tool uses when it creates or organizes an event.
+	CreatorDomains []string
+
+	// Signatures are snippets of text the tool leaves in the event description.
+	Signatures []string
+}
+
+// DefaultBotSchedulers is the built-in list of scheduling tools recognized by IsBotScheduled.
+var DefaultBotSchedulers = []BotScheduler{
+	{Name: "Calendly", CreatorDomains: []string{"calendly.com"}, Signatures: []string{"calendly.com/"}},
+	{Name: "SavvyCal", CreatorDomains: []string{"savvycal.com"}, Signatures: []string{"savvycal.com/"}},
+	{Name: "Doodle", CreatorDomains: []string{"doodle.com"}, Signatures: []string{"doodle.com/"}},
+	{Name: "Chili Piper", CreatorDomains: []string{"chilipiper.com"}, Signatures: []string{"chilipiper.com/"}},
+	{Name: "HubSpot Meetings", CreatorDomains: []string{"hubspot.com"}, Signatures: []string{"meetings.hubspot.com/"}},
+}
+
+// BotSchedulers is the list of scheduling tools consulted by IsBotScheduled.
+// Replace or append to it to change which tools are recognized. It is a copy of
+// DefaultBotSchedulers, so changing it leaves the defaults as they were.
+var BotSchedulers = append([]BotScheduler(nil), DefaultBotSchedulers...)
+
+// IsBotScheduled returns true if the event appears to have been created by a scheduling tool.
+func IsBotScheduled(event *calendar.Event) bool {
+	_, ok := BotSchedulerFromEvent(event)
+	return ok
+}
+
+// BotSchedulerFromEvent returns the scheduling tool which created the event, if any.
+func BotSchedulerFromEvent(event *calendar.Event) (BotScheduler, bool) {
+	if event == nil {
+		return BotScheduler{}, false
+	}
+
+	var emails []string
+	if event.Creator != nil {
+		emails = append(emails, event.Creator.Email)
+	}
+	if event.Organizer != nil {
+		emails = append(emails, event.Organizer.Email)
+	}
+	description := strings.ToLower(event.Description)
+
+	for _, bot := range BotSchedulers {
+		for _, email := range emails {
+			for _, domain := range bot.CreatorDomains {
+				if emailHasDomain(email, domain) {
+					return bot, true
+				}
+			}
+		}
+		for _, signature := range bot.Signatures {
+			if signature != "" && strings.Contains(description, strings.ToLower(signature)) {
+				return bot, true
+			}
+		}
+	}
+
+	return BotScheduler{}, false
+}
+
+// emailHasDomain returns true if the email address belongs to the domain or one of its subdomains.
+func emailHasDomain(email, domain string) bool {
+	at := strings.LastIndex(email, "@")
+	if at < 0 || domain == "" {
+		return false
+	}
+	host := strings.ToLower(email[at+1:])
+	domain = strings.ToLower(domain)
+	return host == domain || strings.HasSuffix(host, "."+domain)
+}
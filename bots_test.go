@@ -0,0 +1,65 @@
+package zoom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestIsBotScheduled(t *testing.T) {
+	testCases := []struct {
+		input    *calendar.Event
+		expected bool
+	}{
+		{nil, false},
+		{&calendar.Event{}, false},
+		{&calendar.Event{
+			Creator: &calendar.EventCreator{Email: "parkr@jithub.com"},
+		}, false},
+		{&calendar.Event{
+			Creator: &calendar.EventCreator{Email: "no-reply@calendly.com"},
+		}, true},
+		{&calendar.Event{
+			Organizer: &calendar.EventOrganizer{Email: "bot@mail.savvycal.com"},
+		}, true},
+		{&calendar.Event{
+			Creator: &calendar.EventCreator{Email: "someone@notcalendly.com"},
+		}, false},
+		{&calendar.Event{
+			Description: "Need to make changes to this event? Reschedule: https://Calendly.com/reschedulings/abc",
+		}, true},
+		{&calendar.Event{
+			Description: "Let's talk about whether to buy a calendly subscription.",
+		}, false},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, IsBotScheduled(testCase.input), "input: %+v", testCase.input)
+	}
+}
+
+func TestBotSchedulerFromEvent(t *testing.T) {
+	bot, ok := BotSchedulerFromEvent(&calendar.Event{
+		Creator: &calendar.EventCreator{Email: "no-reply@calendly.com"},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, "Calendly", bot.Name)
+}
+
+func TestIsBotScheduled_CustomList(t *testing.T) {
+	defer func(original []BotScheduler) { BotSchedulers = original }(BotSchedulers)
+
+	event := &calendar.Event{Creator: &calendar.EventCreator{Email: "scheduler@jithub.com"}}
+	assert.False(t, IsBotScheduled(event))
+
+	BotSchedulers = append(BotSchedulers, BotScheduler{Name: "Jithub Scheduler", CreatorDomains: []string{"jithub.com"}})
+	assert.True(t, IsBotScheduled(event))
+}
+
+func TestBotSchedulers_CopyOfDefaults(t *testing.T) {
+	defer func(original []BotScheduler) { BotSchedulers = original }(append([]BotScheduler(nil), BotSchedulers...))
+
+	name := DefaultBotSchedulers[0].Name
+	BotSchedulers[0] = BotScheduler{Name: "Jithub Scheduler"}
+	assert.Equal(t, name, DefaultBotSchedulers[0].Name, "changing BotSchedulers leaves the defaults alone")
+}
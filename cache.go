@@ -0,0 +1,122 @@
+package zoom
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// defaultCacheTTL is how long a cached next event is used when Cache.TTL is unset.
+const defaultCacheTTL = time.Minute
+
+// Cache remembers the next event for a while, so that calling NextEvent repeatedly, such
+// as from a status bar, doesn't use up your Calendar API quota. Set Options.Cache to use it.
+// A Cache should only be used with a single set of options.
+type Cache struct {
+	// TTL is how long the next event is remembered. Zero means one minute.
+	TTL time.Duration
+
+	// Path is a file in which the next event is also stored, so that it is shared between
+	// separate runs of a program. If empty, the event is only remembered in memory.
+	Path string
+
+	mu    sync.Mutex
+	entry *cacheEntry
+}
+
+// cacheEntry is the cached next event, as stored on disk.
+type cacheEntry struct {
+	FetchedAt time.Time       `json:"fetchedAt"`
+	Event     *calendar.Event `json:"event"`
+}
+
+// NewCache returns a Cache which remembers the next event for the TTL, both in memory and
+// in the file at path. If path is empty, the event is only remembered in memory.
+func NewCache(ttl time.Duration, path string) *Cache {
+	return &Cache{TTL: ttl, Path: path}
+}
+
+// DefaultCachePath returns the file in your user cache directory in which the next event is stored.
+func DefaultCachePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "zoom-go", "next-event.json"), nil
+}
+
+// get returns the cached next event, which may be nil, if it was fetched within the TTL of now.
+func (c *Cache) get(now time.Time) (*calendar.Event, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.entry == nil && c.Path != "" {
+		var entry cacheEntry
+		if readJSONFile(c.Path, &entry) {
+			c.entry = &entry
+		}
+	}
+	if c.entry == nil || now.Before(c.entry.FetchedAt) || now.Sub(c.entry.FetchedAt) >= c.ttl() {
+		return nil, false
+	}
+	return c.entry.Event, true
+}
+
+// put remembers the next event, writing it to disk if the cache has a path. Failing to
+// write the file is not an error, since the event can always be fetched again.
+func (c *Cache) put(event *calendar.Event, now time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entry = &cacheEntry{FetchedAt: now, Event: event}
+	if c.Path != "" {
+		writeJSONFile(c.Path, c.entry)
+	}
+}
+
+func (c *Cache) ttl() time.Duration {
+	if c.TTL <= 0 {
+		return defaultCacheTTL
+	}
+	return c.TTL
+}
+
+// readJSONFile decodes the file into v, returning false if it is missing or unreadable.
+func readJSONFile(path string, v interface{}) bool {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return false
+	}
+	return json.Unmarshal(b, v) == nil
+}
+
+// writeJSONFile replaces the file with v encoded as JSON, so concurrent readers never see a partial write.
+func writeJSONFile(path string, v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0700); err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
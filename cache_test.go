@@ -0,0 +1,82 @@
+package zoom
+
+import (
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestNextEventWithOptions_Cache(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	requests := 0
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprint(w, testEventResponse)
+	})
+
+	path := filepath.Join(t.TempDir(), "zoom-go", "next-event.json")
+	opts := Options{Cache: NewCache(time.Hour, path)}
+
+	first, err := NextEventWithOptions(service, opts)
+	require.NoError(t, err)
+	second, err := NextEventWithOptions(service, opts)
+	require.NoError(t, err)
+	assert.Equal(t, first, second)
+	assert.Equal(t, 1, requests)
+
+	// A separate cache reading the same file doesn't call the API either.
+	third, err := NextEventWithOptions(service, Options{Cache: NewCache(time.Hour, path)})
+	require.NoError(t, err)
+	require.NotNil(t, third)
+	assert.Equal(t, first.Summary, third.Summary)
+	assert.Equal(t, 1, requests)
+}
+
+func TestCache(t *testing.T) {
+	now := time.Now()
+	event := &calendar.Event{Summary: "Standup"}
+
+	c := NewCache(time.Minute, "")
+	_, ok := c.get(now)
+	assert.False(t, ok)
+
+	c.put(event, now)
+	cached, ok := c.get(now.Add(30 * time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, event, cached)
+
+	_, ok = c.get(now.Add(time.Minute))
+	assert.False(t, ok, "the entry expires after the TTL")
+
+	c.put(nil, now)
+	cached, ok = c.get(now)
+	assert.True(t, ok, "having no next event is cached too")
+	assert.Nil(t, cached)
+}
+
+func TestCache_File(t *testing.T) {
+	now := time.Now()
+	path := filepath.Join(t.TempDir(), "next-event.json")
+
+	NewCache(0, path).put(&calendar.Event{Summary: "Standup"}, now)
+
+	cached, ok := NewCache(0, path).get(now.Add(defaultCacheTTL - time.Second))
+	require.True(t, ok)
+	assert.Equal(t, "Standup", cached.Summary)
+
+	_, ok = NewCache(0, path).get(now.Add(defaultCacheTTL))
+	assert.False(t, ok)
+
+	_, ok = NewCache(0, filepath.Join(t.TempDir(), "missing.json")).get(now)
+	assert.False(t, ok)
+}
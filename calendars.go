@@ -0,0 +1,211 @@
+package zoom
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// primaryCalendarID is the alias Google Calendar uses for the authenticated user's calendar.
+const primaryCalendarID = "primary"
+
+// listEvents fetches the upcoming events allowed by the options from every selected
+// calendar, merging them in order of start time. When paginating, each calendar stops
+// listing events after the first page containing an event for which done returns true;
+// done may be nil.
+func listEvents(ctx context.Context, service *calendar.Service, opts Options, done func(*calendar.Event) bool) ([]*calendar.Event, error) {
+	return listEventsInWindow(ctx, service, opts.window(time.Now()), opts, done)
+}
+
+// listEventsInWindow fetches the events in the window allowed by the options from every
+// selected calendar, merging them in order of start time.
+func listEventsInWindow(ctx context.Context, service *calendar.Service, window Window, opts Options, done func(*calendar.Event) bool) ([]*calendar.Event, error) {
+	calendarIDs, err := calendarIDs(ctx, service, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	results, err := listCalendarsEvents(ctx, service, calendarIDs, window, opts, done)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(results) == 1 {
+		return results[0], nil
+	}
+	max := opts.maxResults()
+	if opts.paginates(window) {
+		max = 0
+	}
+	return mergeEvents(results, max), nil
+}
+
+// listCalendarsEvents fetches the events in the window from each calendar. The results
+// are in the same order as the calendar IDs.
+func listCalendarsEvents(ctx context.Context, service *calendar.Service, calendarIDs []string, window Window, opts Options, done func(*calendar.Event) bool) ([][]*calendar.Event, error) {
+	results := make([][]*calendar.Event, len(calendarIDs))
+	err := forEachCalendar(ctx, calendarIDs, opts, func(ctx context.Context, i int, calendarID string) (err error) {
+		results[i], err = listCalendarEvents(ctx, service, calendarID, window, opts, done)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return results, nil
+}
+
+// forEachCalendar calls f with the index and ID of each calendar, for up to
+// opts.Concurrency calendars at once. If f returns an error, the calls in progress are
+// cancelled, no more calls are made, and the first error is returned.
+func forEachCalendar(ctx context.Context, calendarIDs []string, opts Options, f func(ctx context.Context, i int, calendarID string) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		errOnce  sync.Once
+		firstErr error
+	)
+	sem := make(chan struct{}, opts.concurrency())
+	for i, calendarID := range calendarIDs {
+		wg.Add(1)
+		go func(i int, calendarID string) {
+			defer wg.Done()
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				return
+			}
+
+			if err := f(ctx, i, calendarID); err != nil {
+				errOnce.Do(func() {
+					firstErr = err
+					cancel()
+				})
+			}
+		}(i, calendarID)
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return firstErr
+	}
+	return ctx.Err()
+}
+
+// listCalendarEvents fetches the events in the window in a single calendar which are allowed by the options.
+// If the options paginate, it follows page tokens until the window is exhausted, a page
+// contains an event for which done returns true, or MaxPages pages have been listed.
+func listCalendarEvents(ctx context.Context, service *calendar.Service, calendarID string, window Window, opts Options, done func(*calendar.Event) bool) ([]*calendar.Event, error) {
+	call := service.Events.
+		List(calendarID).
+		ShowDeleted(false).
+		SingleEvents(true).
+		TimeMin(window.Start.Format(time.RFC3339)).
+		MaxResults(opts.maxResults()).
+		OrderBy("startTime").
+		Context(ctx)
+	if !window.End.IsZero() {
+		call = call.TimeMax(window.End.Format(time.RFC3339))
+	}
+
+	var allowed []*calendar.Event
+	for page := 1; ; page++ {
+		var events *calendar.Events
+		err := opts.Retry.do(ctx, opts.logger(), func() (err error) {
+			events, err = call.Do()
+			return err
+		})
+		if err != nil {
+			return nil, fmt.Errorf("listing events in calendar %q: %w", calendarID, calendarError(err))
+		}
+
+		withDefaultReminders(events.Items, events.DefaultReminders)
+		found := false
+		for _, event := range events.Items {
+			if opts.allows(event) {
+				allowed = append(allowed, event)
+				found = found || (done != nil && done(event))
+			}
+		}
+
+		if !opts.paginates(window) || found || events.NextPageToken == "" || page >= opts.maxPages() {
+			return allowed, nil
+		}
+		call = call.PageToken(events.NextPageToken)
+	}
+}
+
+// calendarIDs returns the IDs of the calendars selected by the options.
+func calendarIDs(ctx context.Context, service *calendar.Service, opts Options) ([]string, error) {
+	if !opts.AllCalendars {
+		if len(opts.CalendarIDs) == 0 {
+			return []string{primaryCalendarID}, nil
+		}
+		return opts.CalendarIDs, nil
+	}
+
+	var ids []string
+	err := service.CalendarList.
+		List().
+		MinAccessRole("reader").
+		Pages(ctx, func(list *calendar.CalendarList) error {
+			for _, entry := range list.Items {
+				if !entry.Deleted && !entry.Hidden {
+					ids = append(ids, entry.Id)
+				}
+			}
+			return nil
+		})
+	if err != nil {
+		return nil, fmt.Errorf("listing calendars: %w", calendarError(err))
+	}
+	return ids, nil
+}
+
+// mergeEvents combines the events from several calendars into a single list sorted by
+// start time, dropping duplicate copies of the same meeting and keeping at most max events.
+func mergeEvents(results [][]*calendar.Event, max int64) []*calendar.Event {
+	seen := map[string]bool{}
+
+	var merged []*calendar.Event
+	for _, events := range results {
+		for _, event := range events {
+			if key, ok := instanceKey(event); ok {
+				if seen[key] {
+					continue
+				}
+				seen[key] = true
+			}
+			merged = append(merged, event)
+		}
+	}
+
+	sortEventsByStartTime(merged)
+	if max > 0 && int64(len(merged)) > max {
+		merged = merged[:max]
+	}
+	return merged
+}
+
+// instanceKey identifies the occurrence of a meeting the event is, so that copies of it in
+// several calendars are recognized. Instances of a recurring series share an iCalendar
+// UID, so they are told apart by when they start, as an instant since each calendar gives
+// times in its own time zone, or by date for all-day events.
+func instanceKey(event *calendar.Event) (string, bool) {
+	if event.ICalUID == "" || event.Start == nil {
+		return "", false
+	}
+	if event.Start.DateTime == "" {
+		return event.ICalUID + " " + event.Start.Date, true
+	}
+	start, err := parseEventDateTime(event.Start)
+	if err != nil {
+		return event.ICalUID + " " + event.Start.DateTime, true
+	}
+	return event.ICalUID + " " + start.UTC().Format(time.RFC3339Nano), true
+}
@@ -0,0 +1,246 @@
+package zoom
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestNextEventWithOptions_CalendarIDs(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	mux.HandleFunc("/calendars/team@jithub.com/events", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items": [
+			{"summary": "Team lunch", "iCalUID": "lunch", "start": {"dateTime": "2018-10-10T12:00:00-07:00"}},
+			{"summary": "Team sync", "iCalUID": "sync", "location": "https://jithub.zoom.us/j/22222", "start": {"dateTime": "2018-10-10T14:00:00-07:00"}}
+		]}`)
+	})
+	mux.HandleFunc("/calendars/boss@jithub.com/events", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items": [
+			{"summary": "Team sync", "iCalUID": "sync", "location": "https://jithub.zoom.us/j/22222", "start": {"dateTime": "2018-10-10T14:00:00-07:00"}},
+			{"summary": "1:1", "iCalUID": "1on1", "location": "https://jithub.zoom.us/j/11111", "start": {"dateTime": "2018-10-10T13:00:00-07:00"}}
+		]}`)
+	})
+
+	opts := Options{CalendarIDs: []string{"team@jithub.com", "boss@jithub.com"}}
+
+	event, err := NextEventWithOptions(service, opts)
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, "1:1", event.Summary)
+
+	events, err := NextEvents(service, opts)
+	require.NoError(t, err)
+	var summaries []string
+	for _, event := range events {
+		summaries = append(summaries, event.Event.Summary)
+	}
+	assert.Equal(t, []string{"Team lunch", "1:1", "Team sync"}, summaries)
+}
+
+func TestNextEventWithOptions_AllCalendars(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	var mu sync.Mutex
+	requested := map[string]bool{}
+
+	mux.HandleFunc("/users/me/calendarList", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "reader", r.URL.Query().Get("minAccessRole"))
+		fmt.Fprint(w, `{"items": [
+			{"id": "parkr@jithub.com"},
+			{"id": "hidden@jithub.com", "hidden": true},
+			{"id": "team@jithub.com"}
+		]}`)
+	})
+	mux.HandleFunc("/calendars/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requested[r.URL.Path] = true
+		mu.Unlock()
+		fmt.Fprint(w, `{"items": []}`)
+	})
+
+	event, err := NextEventWithOptions(service, Options{AllCalendars: true})
+	require.NoError(t, err)
+	assert.Nil(t, event)
+	assert.Equal(t, map[string]bool{
+		"/calendars/parkr@jithub.com/events": true,
+		"/calendars/team@jithub.com/events":  true,
+	}, requested)
+}
+
+func TestNextEventWithOptions_CalendarError(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	mux.HandleFunc("/calendars/team@jithub.com/events", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items": []}`)
+	})
+	mux.HandleFunc("/calendars/missing@jithub.com/events", func(w http.ResponseWriter, r *http.Request) {
+		http.NotFound(w, r)
+	})
+
+	_, err := NextEventWithOptions(service, Options{CalendarIDs: []string{"team@jithub.com", "missing@jithub.com"}})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), `"missing@jithub.com"`)
+}
+
+func TestNextEventWithOptions_Concurrency(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	var mu sync.Mutex
+	inFlight, maxInFlight := 0, 0
+
+	mux.HandleFunc("/calendars/", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+
+		time.Sleep(10 * time.Millisecond)
+		fmt.Fprintf(w, `{"items": [{"summary": %q, "start": {"dateTime": "2018-10-10T12:00:00-07:00"}}]}`, r.URL.Path)
+
+		mu.Lock()
+		inFlight--
+		mu.Unlock()
+	})
+
+	var calendarIDs []string
+	for i := 0; i < 8; i++ {
+		calendarIDs = append(calendarIDs, fmt.Sprintf("team%d@jithub.com", i))
+	}
+
+	events, err := NextEvents(service, Options{CalendarIDs: calendarIDs, Concurrency: 3})
+	require.NoError(t, err)
+	assert.Len(t, events, 8)
+	assert.True(t, maxInFlight <= 3, "at most 3 calendars are listed at once, got %d", maxInFlight)
+	assert.True(t, maxInFlight > 1, "calendars are listed concurrently")
+}
+
+func TestNextEventWithOptions_Paginate(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	var pages []string
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "2", r.URL.Query().Get("maxResults"))
+		assert.NotEmpty(t, r.URL.Query().Get("timeMax"))
+
+		pageToken := r.URL.Query().Get("pageToken")
+		pages = append(pages, pageToken)
+		switch pageToken {
+		case "":
+			fmt.Fprint(w, `{"nextPageToken": "page-2", "items": [
+				{"summary": "Focus time", "start": {"dateTime": "2018-10-10T09:00:00-07:00"}},
+				{"summary": "Lunch", "start": {"dateTime": "2018-10-10T12:00:00-07:00"}}
+			]}`)
+		case "page-2":
+			fmt.Fprint(w, `{"nextPageToken": "page-3", "items": [
+				{"summary": "Standup", "location": "https://jithub.zoom.us/j/22222", "start": {"dateTime": "2018-10-10T13:00:00-07:00"}}
+			]}`)
+		default:
+			t.Errorf("unexpected page %q", pageToken)
+			fmt.Fprint(w, `{"items": []}`)
+		}
+	})
+
+	event, err := NextEventWithOptions(service, Options{MaxResults: 2, Paginate: true})
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, "Standup", event.Summary)
+	assert.Equal(t, []string{"", "page-2"}, pages)
+}
+
+func TestNextEvents_Paginate(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("pageToken") == "" {
+			fmt.Fprint(w, `{"nextPageToken": "page-2", "items": [
+				{"summary": "Standup", "location": "https://jithub.zoom.us/j/22222", "start": {"dateTime": "2018-10-10T09:00:00-07:00"}}
+			]}`)
+			return
+		}
+		fmt.Fprint(w, `{"items": [
+			{"summary": "1:1", "location": "https://jithub.zoom.us/j/11111", "start": {"dateTime": "2018-10-10T13:00:00-07:00"}}
+		]}`)
+	})
+
+	events, err := NextEvents(service, Options{MaxResults: 1, Paginate: true})
+	require.NoError(t, err)
+	var summaries []string
+	for _, event := range events {
+		summaries = append(summaries, event.Event.Summary)
+	}
+	assert.Equal(t, []string{"Standup", "1:1"}, summaries)
+}
+
+func TestNextEvents_MaxPages(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	requests := 0
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		fmt.Fprintf(w, `{"nextPageToken": "page-%d", "items": [
+			{"summary": "Meeting %d", "start": {"dateTime": "2018-10-10T%02d:00:00-07:00"}}
+		]}`, requests+1, requests, requests)
+	})
+
+	events, err := NextEvents(service, Options{MaxResults: 1, Horizon: 24 * time.Hour, MaxPages: 3})
+	require.NoError(t, err)
+	assert.Len(t, events, 3)
+	assert.Equal(t, 3, requests)
+}
+
+func TestMergeEvents_RecurringAllDay(t *testing.T) {
+	day := func(date string) *calendar.Event {
+		return &calendar.Event{ICalUID: "offsite@google.com", Summary: "Offsite", Start: &calendar.EventDateTime{Date: date}}
+	}
+
+	merged := mergeEvents([][]*calendar.Event{
+		{day("2018-10-10"), day("2018-10-11"), day("2018-10-12")},
+		{day("2018-10-11")},
+	}, 0)
+	require.Len(t, merged, 3, "each instance of the series is kept, and the copy dropped")
+	assert.Equal(t, "2018-10-10", merged[0].Start.Date)
+	assert.Equal(t, "2018-10-12", merged[2].Start.Date)
+}
+
+func TestMergeEvents_TimeZones(t *testing.T) {
+	at := func(dateTime, timeZone string) *calendar.Event {
+		return &calendar.Event{ICalUID: "standup@google.com", Summary: "Standup", Start: &calendar.EventDateTime{DateTime: dateTime, TimeZone: timeZone}}
+	}
+
+	merged := mergeEvents([][]*calendar.Event{
+		{at("2018-10-10T10:00:00-04:00", "America/New_York"), at("2018-10-11T10:00:00-04:00", "America/New_York")},
+		{at("2018-10-10T14:00:00Z", "UTC")},
+	}, 0)
+	require.Len(t, merged, 2, "the same meeting in calendars in different time zones is one meeting")
+	assert.Equal(t, "2018-10-11T10:00:00-04:00", merged[1].Start.DateTime)
+}
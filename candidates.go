@@ -0,0 +1,317 @@
+package zoom
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// Redirector is a link-tracking or safe-browsing service which wraps the links in event
+// descriptions, such as Google's https://www.google.com/url?q=... links.
+type Redirector struct {
+	// Host is the redirector's domain. Its subdomains are recognized too.
+	Host string
+
+	// Path is the path of wrapped links. Empty matches any path.
+	Path string
+
+	// Param is the query parameter which holds the wrapped URL.
+	Param string
+}
+
+// Redirectors are the services whose wrapped links are unwrapped before looking for
+// meeting URLs. Append to it to unwrap others.
+var Redirectors = []Redirector{
+	{Host: "google.com", Path: "/url", Param: "q"},
+	{Host: "safelinks.protection.outlook.com", Param: "url"},
+}
+
+// maxRedirects is how many times a link is unwrapped, for links wrapped more than once.
+const maxRedirects = 3
+
+var (
+	// linkRegexp matches the URLs in text.
+	linkRegexp = regexp.MustCompile(`https?://[^\s"'<>]+`)
+
+	// htmlTagRegexp matches HTML tags, but not a lone "<", as in "a < b", or a link in
+	// angle brackets, as in "<https://zoom.us/j/12345>".
+	htmlTagRegexp = regexp.MustCompile(`(?i)</?[a-z][a-z0-9]*(?:\s[^>]*)?/?>`)
+
+	// hrefRegexp matches the link in an HTML anchor tag.
+	hrefRegexp = regexp.MustCompile(`(?i)\bhref\s*=\s*(?:"([^"]*)"|'([^']*)')`)
+)
+
+// The fields of an event in which meeting URLs are found, from most to least likely to
+// hold the URL to join by.
+const (
+	FieldConferenceData = "conference data"
+	FieldHangoutLink    = "hangout link"
+	FieldLocation       = "location"
+	FieldDescription    = "description"
+	FieldAttachment     = "attachment"
+)
+
+// fieldConfidence is how confident a URL in each field is the one to join by. Conference
+// data is added by the provider's calendar integration, while descriptions often also
+// link to e.g. a recording of last week's meeting.
+var fieldConfidence = map[string]int{
+	FieldConferenceData: 100,
+	FieldHangoutLink:    90,
+	FieldLocation:       80,
+	FieldDescription:    60,
+	FieldAttachment:     40,
+}
+
+// URLCandidate is a meeting URL found in an event.
+type URLCandidate struct {
+	// URL is the meeting's web URL, unwrapped from any redirector.
+	URL *url.URL
+
+	// Provider is the service the URL belongs to.
+	Provider Provider
+
+	// Field is where in the event the URL was found, e.g. FieldLocation.
+	Field string
+
+	// Confidence is how likely the URL is the one to join the meeting by, from 0 to 100.
+	Confidence int
+}
+
+// MeetingURLCandidates returns every URL of one of the providers in the event's conference
+// data, Hangouts link, location, description, and attachments, most confident first. HTML
+// descriptions are reduced to their text and links, and links wrapped by one of the
+// Redirectors are unwrapped. A URL found in more than one field is returned once.
+func MeetingURLCandidates(event *calendar.Event, providers []Provider) []URLCandidate {
+	if event == nil {
+		return nil
+	}
+	if len(providers) == 0 {
+		providers = []Provider{ZoomProvider}
+	}
+
+	// Most events link to none of the providers, and needn't be cleaned up to find that out.
+	if domains, ok := providerDomains(providers); ok && videoEntryPoint(event) == nil && !(&lazyEventText{event: event}).mentions(domains) {
+		return nil
+	}
+
+	var candidates []URLCandidate
+	seen := map[string]bool{}
+	for _, field := range eventFields(event) {
+		for _, link := range linkRegexp.FindAllString(field.text, -1) {
+			u, provider, ok := matchLink(trimLink(link), providers)
+			if !ok || seen[u.String()] {
+				continue
+			}
+			seen[u.String()] = true
+			candidates = append(candidates, URLCandidate{
+				URL:        u,
+				Provider:   provider,
+				Field:      field.name,
+				Confidence: fieldConfidence[field.name],
+			})
+		}
+	}
+
+	sort.SliceStable(candidates, func(i, j int) bool { return candidates[i].Confidence > candidates[j].Confidence })
+	return candidates
+}
+
+// eventField is the cleaned-up text of one of an event's fields.
+type eventField struct {
+	name string
+	text string
+}
+
+// eventFields returns the text of each field of the event which may hold a meeting URL,
+// in order of confidence, with HTML stripped and redirectors unwrapped.
+func eventFields(event *calendar.Event) []eventField {
+	var fields []eventField
+	if entryPoint := videoEntryPoint(event); entryPoint != nil {
+		fields = append(fields, eventField{FieldConferenceData, entryPoint.Uri})
+	}
+	fields = append(fields,
+		eventField{FieldHangoutLink, event.HangoutLink},
+		eventField{FieldLocation, cleanText(event.Location)},
+		eventField{FieldDescription, cleanText(event.Description)},
+	)
+	for _, attachment := range event.Attachments {
+		if attachment != nil {
+			fields = append(fields, eventField{FieldAttachment, unwrapLinks(attachment.FileUrl)})
+		}
+	}
+	return fields
+}
+
+// eventText returns the event's location, description, Hangouts link, and attachment
+// URLs as one string to search for meeting URLs, with HTML stripped and redirectors
+// unwrapped. Conference data isn't included.
+func eventText(event *calendar.Event) string {
+	var text strings.Builder
+	for _, field := range eventFields(event) {
+		if field.name != FieldConferenceData && field.text != "" {
+			text.WriteString(field.text)
+			text.WriteString(" ")
+		}
+	}
+	return text.String()
+}
+
+// lazyEventText is an event's text, as eventText returns it, cleaned up when it is first
+// needed, so that it is cleaned up at most once however many providers look at it.
+type lazyEventText struct {
+	event *calendar.Event
+	text  string
+	done  bool
+}
+
+func (t *lazyEventText) String() string {
+	if !t.done {
+		t.text, t.done = eventText(t.event), true
+	}
+	return t.text
+}
+
+// mentions reports whether any of the event's fields contain any of the substrings before
+// they are cleaned up, which is a quick way to rule out finding a provider's URLs in the
+// cleaned-up text. Unwrapping and unescaping links leaves their domains as they were.
+func (t *lazyEventText) mentions(substrings []string) bool {
+	if containsAny(t.event.Location, substrings) || containsAny(t.event.Description, substrings) || containsAny(t.event.HangoutLink, substrings) {
+		return true
+	}
+	for _, attachment := range t.event.Attachments {
+		if attachment != nil && containsAny(attachment.FileUrl, substrings) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchLink returns the web URL if one of the providers recognizes the link.
+func matchLink(link string, providers []Provider) (*url.URL, Provider, bool) {
+	for _, provider := range providers {
+		if provider == ZoomProvider {
+			// The Zoom provider returns deep links, but candidates are web URLs.
+			if webURL, _, ok := meetingURLsFromText(link); ok {
+				return webURL, provider, true
+			}
+			continue
+		}
+		if p, ok := provider.(*regexpProvider); ok {
+			if u, ok := p.matchText(link); ok {
+				return u, provider, true
+			}
+			continue
+		}
+		if u, ok := provider.Match(&calendar.Event{Location: link}); ok {
+			return u, provider, true
+		}
+	}
+	return nil, nil, false
+}
+
+// trimLink removes punctuation which ends the sentence a link is in, rather than the link.
+func trimLink(link string) string {
+	return strings.TrimRight(link, ".,;:!?)]")
+}
+
+// cleanText reduces HTML to its text and the links of its anchors, and unwraps the links
+// wrapped by one of the Redirectors. Plain text is only unwrapped.
+func cleanText(text string) string {
+	return unwrapLinks(stripHTML(text))
+}
+
+// stripHTML replaces each HTML tag with a space, or with its link if it is an anchor, and
+// unescapes entities such as "&amp;". Text without tags is returned as it is.
+func stripHTML(text string) string {
+	if strings.IndexByte(text, '<') < 0 || !htmlTagRegexp.MatchString(text) {
+		return text
+	}
+	text = htmlTagRegexp.ReplaceAllStringFunc(text, func(tag string) string {
+		if matches := hrefRegexp.FindStringSubmatch(tag); matches != nil {
+			return " " + matches[1] + matches[2] + " "
+		}
+		return " "
+	})
+	return strings.ReplaceAll(html.UnescapeString(text), "\u00a0", " ")
+}
+
+// unwrapLinks replaces each link in the text wrapped by one of the Redirectors with the
+// link it wraps.
+func unwrapLinks(text string) string {
+	if !mayHaveRedirects(text) {
+		return text
+	}
+	return linkRegexp.ReplaceAllStringFunc(text, func(link string) string {
+		u, err := url.Parse(link)
+		if err != nil {
+			return link
+		}
+		unwrapped := unwrapRedirect(u)
+		if unwrapped == u {
+			return link
+		}
+		return unwrapped.String()
+	})
+}
+
+// mayHaveRedirects reports whether the text might have a link wrapped by one of the
+// Redirectors, which is much quicker to find out than unwrapping its links.
+func mayHaveRedirects(text string) bool {
+	if !strings.Contains(text, "://") {
+		return false
+	}
+	// Hosts aren't case-sensitive.
+	text = strings.ToLower(text)
+	for _, r := range Redirectors {
+		if strings.Contains(text, strings.ToLower(r.Host)) {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAny reports whether the text contains any of the substrings.
+func containsAny(text string, substrings []string) bool {
+	for _, substring := range substrings {
+		if strings.Contains(text, substring) {
+			return true
+		}
+	}
+	return false
+}
+
+// unwrapRedirect returns the URL wrapped by a redirector, or the URL itself if it isn't
+// a redirector's.
+func unwrapRedirect(u *url.URL) *url.URL {
+	for i := 0; i < maxRedirects; i++ {
+		wrapped := wrappedURL(u)
+		if wrapped == nil {
+			break
+		}
+		u = wrapped
+	}
+	return u
+}
+
+// wrappedURL returns the URL which the redirector URL wraps, or nil if it doesn't wrap one.
+func wrappedURL(u *url.URL) *url.URL {
+	host := strings.ToLower(u.Hostname())
+	for _, r := range Redirectors {
+		if host != r.Host && !strings.HasSuffix(host, "."+r.Host) {
+			continue
+		}
+		if r.Path != "" && u.Path != r.Path {
+			continue
+		}
+		wrapped, err := url.Parse(u.Query().Get(r.Param))
+		if err != nil || (wrapped.Scheme != "https" && wrapped.Scheme != "http") {
+			return nil
+		}
+		return wrapped
+	}
+	return nil
+}
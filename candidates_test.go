@@ -0,0 +1,78 @@
+package zoom
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestMeetingFromEvent_HTMLDescription(t *testing.T) {
+	// Google Calendar wraps links in descriptions it imports from Outlook invitations.
+	meeting := MeetingFromEvent(&calendar.Event{
+		Description: `<p>Join Zoom Meeting<br><a href="https://www.google.com/url?q=https%3A%2F%2Fjithub.zoom.us%2Fj%2F12345%3Fpwd%3Dsecret&amp;sa=D&amp;ust=1539000000">Click&nbsp;here</a></p>`,
+	}, nil)
+	require.NotNil(t, meeting.JoinURL)
+	assert.Equal(t, "https://jithub.zoom.us/j/12345?pwd=secret", meeting.JoinURL.String())
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=12345&pwd=secret", meeting.DeepLink.String())
+
+	meeting = MeetingFromEvent(&calendar.Event{
+		Description: "Join: https://nam12.safelinks.protection.outlook.com/?url=https%3A%2F%2Fmeet.google.com%2Fabc-defg-hij&data=05%7C01",
+	}, AllProviders)
+	assert.Equal(t, "Google Meet", meeting.Provider)
+	assert.Equal(t, "https://meet.google.com/abc-defg-hij", meeting.JoinURL.String())
+}
+
+func TestMeetingURLCandidates(t *testing.T) {
+	event := &calendar.Event{
+		HangoutLink: "https://meet.google.com/abc-defg-hij",
+		Location:    "Boardroom",
+		Description: `Join at <a href="https://jithub.zoom.us/j/12345">Zoom</a>, or by Teams (https://teams.microsoft.com/l/meetup-join/19%3ameeting_abc).` +
+			` Last week's recording: https://www.google.com/url?q=https://jithub.zoom.us/j/12345`,
+		Attachments: []*calendar.EventAttachment{{FileUrl: "https://jithub.zoom.us/j/67890"}},
+		ConferenceData: &calendar.ConferenceData{
+			EntryPoints: []*calendar.EntryPoint{{EntryPointType: "video", Uri: "https://jithub.zoom.us/j/12345"}},
+		},
+	}
+
+	type candidate struct {
+		URL, Provider, Field string
+		Confidence           int
+	}
+	var got []candidate
+	for _, c := range MeetingURLCandidates(event, AllProviders) {
+		got = append(got, candidate{c.URL.String(), c.Provider.Name(), c.Field, c.Confidence})
+	}
+	assert.Equal(t, []candidate{
+		{"https://jithub.zoom.us/j/12345", "Zoom", FieldConferenceData, 100},
+		{"https://meet.google.com/abc-defg-hij", "Google Meet", FieldHangoutLink, 90},
+		{"https://teams.microsoft.com/l/meetup-join/19%3ameeting_abc", "Microsoft Teams", FieldDescription, 60},
+		{"https://jithub.zoom.us/j/67890", "Zoom", FieldAttachment, 40},
+	}, got)
+
+	assert.Len(t, MeetingURLCandidates(event, nil), 2, "only Zoom URLs are found by default")
+	assert.Empty(t, MeetingURLCandidates(&calendar.Event{Location: "Boardroom"}, AllProviders))
+	assert.Nil(t, MeetingURLCandidates(nil, AllProviders))
+}
+
+func TestStripHTML(t *testing.T) {
+	assert.Equal(t, "if a < b, join https://zoom.us/j/1", stripHTML("if a < b, join https://zoom.us/j/1"), "plain text is left alone")
+	assert.Equal(t, " Join  https://zoom.us/j/1?a=1&b=2 here  ", stripHTML(`<p>Join <a href='https://zoom.us/j/1?a=1&amp;b=2'>here</a></p>`))
+	assert.Equal(t, "Join  <https://zoom.us/j/1>", stripHTML("Join<br/> <https://zoom.us/j/1>"))
+}
+
+func TestUnwrapRedirect(t *testing.T) {
+	unwrap := func(link string) string {
+		u, err := url.Parse(link)
+		require.NoError(t, err)
+		return unwrapRedirect(u).String()
+	}
+
+	assert.Equal(t, "https://zoom.us/j/1", unwrap("https://www.google.com/url?q=https%3A%2F%2Fzoom.us%2Fj%2F1&sa=D"))
+	assert.Equal(t, "https://zoom.us/j/1", unwrap("https://www.google.com/url?q=https%3A%2F%2Feur01.safelinks.protection.outlook.com%2F%3Furl%3Dhttps%253A%252F%252Fzoom.us%252Fj%252F1"), "links can be wrapped more than once")
+	assert.Equal(t, "https://www.google.com/search?q=https://zoom.us/j/1", unwrap("https://www.google.com/search?q=https://zoom.us/j/1"), "only the redirector's path is unwrapped")
+	assert.Equal(t, "https://www.google.com/url?q=javascript:alert(1)", unwrap("https://www.google.com/url?q=javascript:alert(1)"), "only web URLs are unwrapped")
+	assert.Equal(t, "https://notgoogle.com/url?q=https://zoom.us/j/1", unwrap("https://notgoogle.com/url?q=https://zoom.us/j/1"))
+}
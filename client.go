@@ -5,7 +5,6 @@ import (
 	"net/http"
 
 	"github.com/benbalter/zoom-go/config"
-	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
 	calendar "google.golang.org/api/calendar/v3"
 )
@@ -45,14 +44,19 @@ func GoogleCalendarAuthorizationURL(provider config.Provider) (string, error) {
 
 // HandleGoogleCalendarAuthorization takes an auth code and generates the necessary token and stores it on the provider.
 func HandleGoogleCalendarAuthorization(provider config.Provider, authCode string) error {
+	return HandleGoogleCalendarAuthorizationContext(context.Background(), provider, authCode)
+}
+
+// HandleGoogleCalendarAuthorizationContext is like HandleGoogleCalendarAuthorization, but the token exchange is bound to the context.
+func HandleGoogleCalendarAuthorizationContext(ctx context.Context, provider config.Provider, authCode string) error {
 	conf, err := provider.GoogleClientConfig()
 	if err != nil {
 		return err
 	}
 
-	tok, err := conf.Exchange(oauth2.NoContext, authCode)
+	tok, err := conf.Exchange(ctx, authCode)
 	if err != nil {
-		return errors.WithStack(err)
+		return err
 	}
 
 	return provider.StoreGoogleToken(tok)
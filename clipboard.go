@@ -0,0 +1,110 @@
+package zoom
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"runtime"
+	"strings"
+)
+
+// Clipboard puts text on the operating system's clipboard, with pbcopy on macOS, clip on
+// Windows, and wl-copy, xclip, or xsel, whichever is installed, elsewhere.
+type Clipboard struct {
+	// GOOS is the operating system whose clipboard to use. It defaults to runtime.GOOS.
+	GOOS string
+
+	// Run runs a command to completion with the input on its standard input. It defaults
+	// to running it with os/exec.
+	Run func(input, name string, args ...string) error
+
+	// Fallback, if set, copies the text when no clipboard command is installed, e.g. by
+	// asking the terminal to.
+	Fallback func(text string) error
+}
+
+// DefaultClipboard is the Clipboard used by CopyURL and CopyDialIn.
+var DefaultClipboard = &Clipboard{}
+
+// CopyURL puts the meeting's join URL on the clipboard, e.g. to paste it into a chat.
+func CopyURL(meeting Meeting) error {
+	return DefaultClipboard.CopyURL(meeting)
+}
+
+// CopyDialIn puts the meeting's first dial-in number on the clipboard.
+func CopyDialIn(meeting Meeting) error {
+	return DefaultClipboard.CopyDialIn(meeting)
+}
+
+// CopyURL puts the meeting's web URL on the clipboard, since it works for everyone you
+// paste it to, or its deep link if it has no web URL. It returns ErrNoMeetingURL if the
+// meeting has neither.
+func (c *Clipboard) CopyURL(meeting Meeting) error {
+	switch {
+	case meeting.JoinURL != nil:
+		return c.Copy(meeting.JoinURL.String())
+	case meeting.DeepLink != nil:
+		return c.Copy(meeting.DeepLink.String())
+	}
+	return ErrNoMeetingURL
+}
+
+// CopyDialIn puts the meeting's first dial-in number on the clipboard, with its access
+// code and passcode so a phone can dial it in one go, e.g.
+// "+16465588656,,12345678901#,,,,*123456#". It returns ErrNoDialIn if the meeting has no
+// dial-in numbers.
+func (c *Clipboard) CopyDialIn(meeting Meeting) error {
+	for _, dialIn := range meeting.DialIns {
+		if oneTap := dialIn.OneTap(); oneTap != "" {
+			return c.Copy(oneTap)
+		}
+	}
+	return ErrNoDialIn
+}
+
+// Copy puts the text on the clipboard. It returns ErrNoClipboard if no clipboard command
+// is installed and there is no Fallback.
+func (c *Clipboard) Copy(text string) error {
+	goos := c.GOOS
+	if goos == "" {
+		goos = runtime.GOOS
+	}
+	run := c.Run
+	if run == nil {
+		run = runCommandWithInput
+	}
+
+	var commands [][]string
+	switch goos {
+	case "darwin":
+		commands = [][]string{{"pbcopy"}}
+	case "windows":
+		commands = [][]string{{"clip"}}
+	default:
+		commands = [][]string{{"xclip", "-selection", "clipboard"}, {"xsel", "--clipboard", "--input"}}
+		if os.Getenv("WAYLAND_DISPLAY") != "" {
+			commands = append([][]string{{"wl-copy"}}, commands...)
+		}
+	}
+
+	for _, command := range commands {
+		err := run(text, command[0], command[1:]...)
+		if errors.Is(err, exec.ErrNotFound) {
+			continue
+		} else if err != nil {
+			return fmt.Errorf("unable to copy to the clipboard: %w", err)
+		}
+		return nil
+	}
+	if c.Fallback != nil {
+		return c.Fallback(text)
+	}
+	return ErrNoClipboard
+}
+
+func runCommandWithInput(input, name string, args ...string) error {
+	cmd := exec.Command(name, args...)
+	cmd.Stdin = strings.NewReader(input)
+	return cmd.Run()
+}
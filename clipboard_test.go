@@ -0,0 +1,86 @@
+package zoom
+
+import (
+	"errors"
+	"net/url"
+	"os/exec"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestClipboardCopyURL(t *testing.T) {
+	joinURL, _ := url.Parse("https://jithub.zoom.us/j/12345")
+	deepLink, _ := url.Parse("zoommtg://zoom.us/join?confno=12345")
+	t.Setenv("WAYLAND_DISPLAY", "")
+
+	testCases := []struct {
+		goos     string
+		meeting  Meeting
+		expected []string
+	}{
+		{"darwin", Meeting{JoinURL: joinURL, DeepLink: deepLink}, []string{"pbcopy", "https://jithub.zoom.us/j/12345"}},
+		{"windows", Meeting{JoinURL: joinURL}, []string{"clip", "https://jithub.zoom.us/j/12345"}},
+		{"linux", Meeting{DeepLink: deepLink}, []string{"xclip", "-selection", "clipboard", "zoommtg://zoom.us/join?confno=12345"}},
+	}
+	for _, testCase := range testCases {
+		var ran []string
+		clipboard := &Clipboard{GOOS: testCase.goos, Run: func(input, name string, args ...string) error {
+			ran = append(append([]string{name}, args...), input)
+			return nil
+		}}
+		require.NoError(t, clipboard.CopyURL(testCase.meeting))
+		assert.Equal(t, testCase.expected, ran, "GOOS: %s", testCase.goos)
+	}
+
+	assert.Equal(t, ErrNoMeetingURL, (&Clipboard{}).CopyURL(Meeting{}))
+}
+
+func TestClipboardCopy_Linux(t *testing.T) {
+	var tried []string
+	installed := map[string]bool{}
+	clipboard := &Clipboard{GOOS: "linux", Run: func(input, name string, args ...string) error {
+		tried = append(tried, name)
+		if !installed[name] {
+			return &exec.Error{Name: name, Err: exec.ErrNotFound}
+		}
+		return nil
+	}}
+
+	t.Setenv("WAYLAND_DISPLAY", "wayland-0")
+	assert.Equal(t, ErrNoClipboard, clipboard.Copy("hello"))
+	assert.Equal(t, []string{"wl-copy", "xclip", "xsel"}, tried)
+
+	tried = nil
+	installed["xsel"] = true
+	t.Setenv("WAYLAND_DISPLAY", "")
+	require.NoError(t, clipboard.Copy("hello"))
+	assert.Equal(t, []string{"xclip", "xsel"}, tried)
+
+	installed["xsel"] = false
+	var fellBack string
+	clipboard.Fallback = func(text string) error {
+		fellBack = text
+		return nil
+	}
+	require.NoError(t, clipboard.Copy("hello"))
+	assert.Equal(t, "hello", fellBack)
+
+	clipboard.Run = func(string, string, ...string) error { return errors.New("exit status 1") }
+	assert.EqualError(t, clipboard.Copy("hello"), "unable to copy to the clipboard: exit status 1")
+}
+
+func TestClipboardCopyDialIn(t *testing.T) {
+	var copied string
+	clipboard := &Clipboard{GOOS: "darwin", Run: func(input, name string, args ...string) error {
+		copied = input
+		return nil
+	}}
+
+	meeting := Meeting{DialIns: []DialIn{{}, {Number: "+1 646-558-8656", AccessCode: "123 4567 8901", Passcode: "654321"}}}
+	require.NoError(t, clipboard.CopyDialIn(meeting))
+	assert.Equal(t, "+16465588656,,12345678901#,,,,*654321#", copied)
+
+	assert.Equal(t, ErrNoDialIn, clipboard.CopyDialIn(Meeting{}))
+}
@@ -0,0 +1,942 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/benbalter/zoom-go"
+	"github.com/benbalter/zoom-go/auth"
+	"github.com/benbalter/zoom-go/config"
+	"github.com/benbalter/zoom-go/notifier"
+	"github.com/benbalter/zoom-go/server"
+	"github.com/benbalter/zoom-go/slack"
+	"github.com/benbalter/zoom-go/statusbar"
+	"github.com/benbalter/zoom-go/tui"
+	"github.com/benbalter/zoom-go/webhook"
+	"github.com/benbalter/zoom-go/zoomapi"
+)
+
+// defaultAgendaHorizon is how far ahead the agenda looks when no horizon is configured.
+const defaultAgendaHorizon = 24 * time.Hour
+
+// runNext prints your next meeting, and opens it if it starts soon.
+func runNext(a *app, args []string) {
+	fs := flag.NewFlagSet("next", flag.ExitOnError)
+	a.addCredentialFlags(fs)
+	cacheFor := fs.Duration("cache", 0, "Reuse the next meeting fetched within this long, e.g. when run from a status bar")
+	noOpen := fs.Bool("no-open", false, "Don't open the meeting, even if it starts soon")
+	asJSON := fs.Bool("json", false, "Print the meeting as JSON, and don't open it")
+	format := fs.String("format", "", "Print the meeting using a Go template, e.g. '{{.Summary}} {{.StartsIn}}', and don't open it")
+	attendees := fs.Bool("attendees", false, "Show how many people accepted the meeting, and your own response")
+	dialIn := fs.Bool("dial-in", false, "Show the phone numbers to join the meeting by, with tel: links")
+	links := fs.Bool("links", false, "Show every link to join the meeting by, such as a backup Google Meet link")
+	fs.Parse(args)
+
+	if fs.NArg() > 0 {
+		runNextMatching(a, strings.Join(fs.Args(), " "), *asJSON)
+		return
+	}
+
+	if len(a.settings.EnabledAccounts()) > 0 {
+		runNextFromAccounts(a, !*noOpen, *asJSON, *format, *attendees, *dialIn, *links)
+		return
+	}
+
+	a.useEventStore()
+	a.useCache(*cacheFor)
+
+	meeting, err := zoom.NextEventWithOptions(a.calendarService(context.Background()), a.opts)
+	if err != nil {
+		exitWithError("error fetching next meeting", err)
+	}
+
+	if *asJSON {
+		var out *zoom.Meeting
+		if meeting != nil {
+			m := zoom.MeetingFromEvent(meeting, a.opts.Providers)
+			out = &m
+		}
+		if err := zoom.WriteMeetingJSON(os.Stdout, out); err != nil {
+			exitWithError("error writing meeting", err)
+		}
+		return
+	}
+
+	if *format != "" {
+		summary, err := zoom.MeetingSummaryTemplate(meeting, *format)
+		if err != nil {
+			exitWithError("error formatting meeting", err)
+		}
+		fmt.Println(summary)
+		return
+	}
+
+	if meeting == nil {
+		fmt.Println("No upcoming events found.")
+		return
+	}
+
+	fmt.Println(zoom.MeetingSummaryWithOptions(meeting, zoom.SummaryOptions{Attendees: *attendees}))
+
+	startTime, _ := zoom.MeetingStartTime(meeting)
+	if startTime.Sub(time.Now()) < 0 {
+		fmt.Printf("It started %s.\n", zoom.HumanizedStartTime(meeting))
+	} else {
+		fmt.Printf("It starts %s.\n", zoom.HumanizedStartTime(meeting))
+	}
+
+	fmt.Printf("Calendar event URL: %s\n\n", meeting.HtmlLink)
+
+	if *dialIn {
+		for _, d := range zoom.DialInsFromEvent(meeting) {
+			fmt.Printf("Dial in: %s %s  %s\n", d.Number, d.RegionCode, d.TelURL())
+		}
+		fmt.Println()
+	}
+
+	if *links {
+		for _, link := range zoom.MeetingURLsFromEventWithProviders(meeting, a.opts.Providers) {
+			fmt.Printf("Link: %s  %s\n", link.Provider, link.URL(zoom.URLOptionsFromSettings(a.settings)))
+		}
+		fmt.Println()
+	}
+
+	m := zoom.MeetingFromEvent(meeting, a.opts.Providers)
+	if a.opts.PersonalRooms != nil {
+		// Personal meeting rooms which can't be resolved are opened in the browser instead.
+		a.opts.PersonalRooms.ResolveMeeting(context.Background(), &m)
+	}
+	url := m.URL(zoom.URLOptionsFromSettings(a.settings))
+	if url == nil {
+		fmt.Println("No meeting URL found in the meeting.")
+		os.Exit(1)
+	}
+	a.printZoomDetails(&m)
+
+	if zoom.IsMeetingSoon(meeting) && !*noOpen {
+		fmt.Printf("Opening %s...\n", url)
+		if err := a.openMeeting(m, url); err != nil {
+			exitWithError("error opening meeting", err)
+		}
+	} else {
+		fmt.Printf("Meeting URL: %s\n", url)
+	}
+}
+
+// runNextFromAccounts is like runNext, but prints your next meeting in any of your enabled
+// accounts. Only the meeting's preferred link is known, so -links shows just that one, and
+// -cache doesn't apply, since it keeps a single calendar's next event.
+func runNextFromAccounts(a *app, open, asJSON bool, format string, attendees, dialIn, links bool) {
+	meeting, ok, err := a.nextMeeting(context.Background())
+	if err != nil {
+		exitWithError("error fetching next meeting", err)
+	}
+	var next *zoom.Meeting
+	if ok {
+		next = &meeting
+	}
+
+	if asJSON {
+		if err := zoom.WriteMeetingJSON(os.Stdout, next); err != nil {
+			exitWithError("error writing meeting", err)
+		}
+		return
+	}
+
+	if format != "" {
+		summary, err := zoom.MeetingTemplate(next, format)
+		if err != nil {
+			exitWithError("error formatting meeting", err)
+		}
+		fmt.Println(summary)
+		return
+	}
+
+	if next == nil {
+		fmt.Println("No upcoming events found.")
+		return
+	}
+
+	fmt.Println(meeting.Summary(zoom.SummaryOptions{Attendees: attendees}))
+
+	now := time.Now()
+	if meeting.Start.Before(now) {
+		fmt.Printf("It started %s.\n", zoom.Formatter.RelativeTime(meeting.Start, now))
+	} else {
+		fmt.Printf("It starts %s.\n", zoom.Formatter.RelativeTime(meeting.Start, now))
+	}
+
+	fmt.Printf("Calendar event URL: %s\n\n", meeting.CalendarURL)
+
+	if dialIn {
+		for _, d := range meeting.DialIns {
+			fmt.Printf("Dial in: %s %s  %s\n", d.Number, d.RegionCode, d.TelURL())
+		}
+		fmt.Println()
+	}
+
+	url := meeting.URL(zoom.URLOptionsFromSettings(a.settings))
+	if links && url != nil {
+		fmt.Printf("Link: %s  %s\n\n", meeting.Provider, url)
+	}
+
+	if url == nil {
+		fmt.Println("No meeting URL found in the meeting.")
+		os.Exit(1)
+	}
+	a.printZoomDetails(&meeting)
+
+	if meeting.IsSoon(now) && open {
+		fmt.Printf("Opening %s...\n", url)
+		if err := a.openMeeting(meeting, url); err != nil {
+			exitWithError("error opening meeting", err)
+		}
+	} else {
+		fmt.Printf("Meeting URL: %s\n", url)
+	}
+}
+
+// runNextMatching prints your first meeting which hasn't started yet in the span of time
+// the query describes, such as "tomorrow morning".
+func runNextMatching(a *app, query string, asJSON bool) {
+	meetings, err := a.eventsMatching(query)
+	if err != nil {
+		exitWithError("error fetching meetings", err)
+	}
+
+	var next *zoom.Meeting
+	now := time.Now()
+	for i := range meetings {
+		if meetings[i].Start.After(now) {
+			next = &meetings[i]
+			break
+		}
+	}
+
+	switch {
+	case asJSON:
+		if err := zoom.WriteMeetingJSON(os.Stdout, next); err != nil {
+			exitWithError("error writing meeting", err)
+		}
+	case next == nil:
+		fmt.Printf("No upcoming meetings %s.\n", query)
+	default:
+		fmt.Printf("%s, %s %s\n", next.Title, zoom.InLocation(next.Start).Format("Mon Jan 2"), next.TimeRange())
+		if u := next.URL(zoom.URLOptionsFromSettings(a.settings)); u != nil {
+			fmt.Printf("Meeting URL: %s\n", u)
+		}
+	}
+}
+
+// runJoin opens your next meeting now, however far away it is.
+func runJoin(a *app, args []string) {
+	fs := flag.NewFlagSet("join", flag.ExitOnError)
+	a.addCredentialFlags(fs)
+	start := fs.Bool("start", false, "Start the meeting as its host, using the Zoom API")
+	fs.Parse(args)
+
+	a.useEventStore()
+	meeting, ok, err := a.nextMeeting(context.Background())
+	if err != nil {
+		exitWithError("error fetching next meeting", err)
+	}
+	if !ok {
+		fmt.Println("No upcoming events found.")
+		os.Exit(1)
+	}
+
+	url := meeting.URL(zoom.URLOptionsFromSettings(a.settings))
+	if url == nil {
+		fmt.Printf("No meeting URL found in %q.\n", meeting.Title)
+		os.Exit(1)
+	}
+
+	if *start {
+		startMeeting(a, meeting, url)
+		return
+	}
+
+	if rule, ok := zoom.MatchJoinRule(zoom.DefaultOpener.Rules, meeting, url); ok && rule.Action == zoom.JoinCopy {
+		fmt.Printf("Copying the link to %q, as your join rules say...\n", meeting.Title)
+	} else {
+		fmt.Printf("Joining %q at %s...\n", meeting.Title, url)
+	}
+	if err := a.openMeeting(meeting, url); err != nil {
+		exitWithError("error opening meeting", err)
+	}
+}
+
+// startMeeting opens the meeting's start URL, which starts it with you as the host, and
+// records it in your history at its join URL.
+func startMeeting(a *app, meeting zoom.Meeting, joinURL *url.URL) {
+	if a.zoomAPI == nil {
+		fmt.Println("Set zoom_account_id, zoom_client_id, and zoom_client_secret in your settings to start meetings.")
+		os.Exit(1)
+	}
+	startURL, err := a.zoomAPI.StartURL(context.Background(), meeting)
+	if errors.Is(err, zoomapi.ErrNotHost) || errors.Is(err, zoom.ErrNoMeetingURL) {
+		fmt.Printf("You can't start %q: %v.\n", meeting.Title, err)
+		os.Exit(1)
+	} else if err != nil {
+		exitWithError("error getting start URL", err)
+	}
+
+	// The start URL isn't printed, since anyone with it can start the meeting as you.
+	fmt.Printf("Starting %q...\n", meeting.Title)
+	if err := a.openURL(meeting, startURL, joinURL); err != nil {
+		exitWithError("error opening meeting", err)
+	}
+}
+
+// printZoomDetails prints the meeting's details from the Zoom API, if it is configured
+// and the meeting is in Zoom. Errors are printed, but otherwise ignored, since the
+// details are only nice to have.
+func (a *app) printZoomDetails(meeting *zoom.Meeting) {
+	if a.zoomAPI == nil {
+		return
+	}
+	if err := a.zoomAPI.Enrich(context.Background(), meeting); err != nil {
+		fmt.Fprintf(os.Stderr, "Unable to get the meeting's details from Zoom: %v\n", err)
+		return
+	}
+	details := meeting.Zoom
+	if details == nil {
+		return
+	}
+
+	if details.StartURL != nil {
+		fmt.Print("You are hosting it")
+	} else {
+		fmt.Printf("It is hosted by %s", details.HostEmail)
+	}
+	if details.Duration > 0 {
+		fmt.Printf(", for %s", shortDuration(details.Duration))
+	}
+	if details.WaitingRoom {
+		fmt.Print(", with a waiting room")
+	}
+	fmt.Println(".")
+	if details.StartURL != nil {
+		fmt.Println("Run 'zoom join -start' to start it.")
+	}
+	fmt.Println()
+}
+
+// runCopy puts your next meeting's link, or its dial-in number, on the clipboard.
+func runCopy(a *app, args []string) {
+	fs := flag.NewFlagSet("copy", flag.ExitOnError)
+	a.addCredentialFlags(fs)
+	dialIn := fs.Bool("dial-in", false, "Copy the meeting's dial-in number, with its access code and passcode, instead of its link")
+	fs.Parse(args)
+
+	a.useEventStore()
+	meeting, ok, err := a.nextMeeting(context.Background())
+	if err != nil {
+		exitWithError("error fetching next meeting", err)
+	}
+	if !ok {
+		fmt.Println("No upcoming events found.")
+		os.Exit(1)
+	}
+
+	what := "link"
+	if *dialIn {
+		what = "dial-in number"
+		err = zoom.CopyDialIn(meeting)
+	} else {
+		err = zoom.CopyURL(meeting)
+	}
+	if errors.Is(err, zoom.ErrNoClipboard) {
+		fmt.Println("No clipboard command found. Install xclip, xsel, or wl-clipboard.")
+		os.Exit(1)
+	} else if err != nil {
+		exitWithError(fmt.Sprintf("error copying the %s to %q", what, meeting.Title), err)
+	}
+	fmt.Printf("Copied the %s to %q.\n", what, meeting.Title)
+}
+
+// runRejoin opens the meeting you joined most recently again, even if it has ended.
+func runRejoin(a *app, args []string) {
+	fs := flag.NewFlagSet("rejoin", flag.ExitOnError)
+	fs.Parse(args)
+
+	if a.history == nil {
+		fmt.Println("No meetings joined yet.")
+		os.Exit(1)
+	}
+	entry, ok, err := a.history.LastJoined()
+	if err != nil {
+		exitWithError("error reading meeting history", err)
+	}
+	if !ok {
+		fmt.Println("No meetings joined yet.")
+		os.Exit(1)
+	}
+	url, err := entry.JoinURL()
+	if err != nil {
+		exitWithError("error reading meeting history", err)
+	}
+
+	fmt.Printf("Rejoining %q at %s...\n", entry.Title, url)
+	meeting := zoom.Meeting{ID: entry.ID, Title: entry.Title, Start: entry.Start, End: entry.End}
+	if err := a.openMeeting(meeting, url); err != nil {
+		exitWithError("error opening meeting", err)
+	}
+}
+
+// runSnooze mutes notifications about your next meeting for a while.
+func runSnooze(a *app, args []string) {
+	fs := flag.NewFlagSet("snooze", flag.ExitOnError)
+	a.addCredentialFlags(fs)
+	snoozeFor := fs.Duration("for", 10*time.Minute, "How long to snooze the meeting's notification")
+	fs.Parse(args)
+
+	meeting, mutes := a.nextMeetingToMute()
+	until := time.Now().Add(*snoozeFor)
+	if err := mutes.Snooze(meeting, until); err != nil {
+		exitWithError("error snoozing meeting", err)
+	}
+	fmt.Printf("Snoozed %q until %s.\n", meeting.Title, zoom.InLocation(until).Format("15:04"))
+}
+
+// runMute stops notifications about your next meeting, and every other meeting in its
+// recurring series, for good.
+func runMute(a *app, args []string) {
+	fs := flag.NewFlagSet("mute", flag.ExitOnError)
+	a.addCredentialFlags(fs)
+	undo := fs.Bool("undo", false, "Notify about the meeting and its series again")
+	fs.Parse(args)
+
+	meeting, mutes := a.nextMeetingToMute()
+	if *undo {
+		if err := mutes.Unmute(meeting); err != nil {
+			exitWithError("error unmuting meeting", err)
+		}
+		fmt.Printf("You will be notified about %q again.\n", meeting.Title)
+		return
+	}
+	if err := mutes.Suppress(meeting); err != nil {
+		exitWithError("error muting meeting", err)
+	}
+	if meeting.SeriesID != "" {
+		fmt.Printf("You won't be notified about %q or the rest of its series again.\n", meeting.Title)
+	} else {
+		fmt.Printf("You won't be notified about %q.\n", meeting.Title)
+	}
+}
+
+// nextMeetingToMute returns your next meeting and your mutes, exiting if either can't be found.
+func (a *app) nextMeetingToMute() (zoom.Meeting, *notifier.Mutes) {
+	path, err := notifier.DefaultMutesPath()
+	if err != nil {
+		exitWithError("error locating mutes", err)
+	}
+	meeting, ok, err := a.nextMeeting(context.Background())
+	if err != nil {
+		exitWithError("error fetching next meeting", err)
+	}
+	if !ok {
+		fmt.Println("No upcoming events found.")
+		os.Exit(1)
+	}
+	return meeting, notifier.NewMutes(path)
+}
+
+// runHistory lists the meetings you joined most recently.
+func runHistory(a *app, args []string) {
+	fs := flag.NewFlagSet("history", flag.ExitOnError)
+	n := fs.Int("n", 10, "How many meetings to list")
+	fs.Parse(args)
+
+	var entries []zoom.HistoryEntry
+	if a.history != nil {
+		var err error
+		if entries, err = a.history.Recent(*n); err != nil {
+			exitWithError("error reading meeting history", err)
+		}
+	}
+	if len(entries) == 0 {
+		fmt.Println("No meetings joined yet.")
+		return
+	}
+	for _, entry := range entries {
+		fmt.Printf("%s  %s  %s\n", zoom.InLocation(entry.JoinedAt).Format("Mon Jan 2 15:04"), entry.Title, entry.URL)
+	}
+}
+
+// runAgenda lists your upcoming meetings.
+func runAgenda(a *app, args []string) {
+	horizon := a.opts.Horizon
+	if horizon <= 0 {
+		horizon = defaultAgendaHorizon
+	}
+
+	fs := flag.NewFlagSet("agenda", flag.ExitOnError)
+	a.addCredentialFlags(fs)
+	fs.DurationVar(&horizon, "for", horizon, "How far ahead to list meetings")
+	asJSON := fs.Bool("json", false, "Print the meetings as a JSON array")
+	today := fs.Bool("today", false, "List all of today's meetings, with their durations and conflicts")
+	fs.Parse(args)
+
+	if fs.NArg() > 0 {
+		runAgendaMatching(a, strings.Join(fs.Args(), " "), *asJSON)
+		return
+	}
+	if *today {
+		runTodayAgenda(a, *asJSON)
+		return
+	}
+
+	a.opts.Horizon = horizon
+	a.opts.Paginate = true
+	if accounts := a.accountsSource(context.Background(), ""); accounts != nil {
+		now := time.Now()
+		meetings, err := accounts.UpcomingEvents(context.Background(), zoom.Window{Start: now, End: now.Add(horizon)})
+		if err != nil {
+			exitWithError("error fetching meetings", err)
+		}
+		writeAgenda(a, meetings, *asJSON, "in the next "+horizon.String())
+		return
+	}
+
+	events, err := zoom.NextEvents(a.calendarService(context.Background()), a.opts)
+	if err != nil {
+		exitWithError("error fetching meetings", err)
+	}
+
+	if *asJSON {
+		meetings := make([]zoom.Meeting, 0, len(events))
+		for _, event := range events {
+			meetings = append(meetings, zoom.MeetingFromEvent(event.Event, a.opts.Providers))
+		}
+		if err := zoom.WriteMeetingsJSON(os.Stdout, meetings); err != nil {
+			exitWithError("error writing meetings", err)
+		}
+		return
+	}
+
+	if len(events) == 0 {
+		fmt.Printf("No meetings in the next %s.\n", horizon)
+		return
+	}
+	for _, event := range events {
+		start, _ := zoom.MeetingStartTime(event.Event)
+		line := fmt.Sprintf("%s  %s", zoom.InLocation(start).Format("Mon 15:04"), event.Event.Summary)
+		if event.HasMeetingURL {
+			line += "  " + event.MeetingURL.String()
+		}
+		fmt.Println(line)
+	}
+}
+
+// runTodayAgenda lists all of today's meetings.
+func runTodayAgenda(a *app, asJSON bool) {
+	var meetings []zoom.Meeting
+	var err error
+	if len(a.settings.EnabledAccounts()) > 0 {
+		// Every meeting today is listed, however long ago it started.
+		a.opts.SkipInProgressAfter = 0
+		meetings, err = zoom.TodayAgendaFromSource(context.Background(), a.accountsSource(context.Background(), ""), time.Now())
+	} else {
+		meetings, err = zoom.TodayAgenda(a.calendarService(context.Background()), a.opts)
+	}
+	if err != nil {
+		exitWithError("error fetching meetings", err)
+	}
+	writeAgenda(a, meetings, asJSON, "today")
+}
+
+// runAgendaMatching lists the meetings in the span of time the query describes, such as
+// "tomorrow" or "next Monday".
+func runAgendaMatching(a *app, query string, asJSON bool) {
+	meetings, err := a.eventsMatching(query)
+	if err != nil {
+		exitWithError("error fetching meetings", err)
+	}
+	writeAgenda(a, meetings, asJSON, query)
+}
+
+// writeAgenda prints the meetings with their durations and conflicts, or as JSON. when
+// describes the span of time they are in, such as "today".
+func writeAgenda(a *app, meetings []zoom.Meeting, asJSON bool, when string) {
+	var err error
+	switch {
+	case asJSON:
+		err = zoom.WriteMeetingsJSON(os.Stdout, meetings)
+	case len(meetings) == 0:
+		fmt.Printf("No meetings %s.\n", when)
+	default:
+		err = zoom.WriteAgenda(os.Stdout, meetings, zoom.URLOptionsFromSettings(a.settings))
+	}
+	if err != nil {
+		exitWithError("error writing meetings", err)
+	}
+}
+
+// runFree prints when you next have a gap of the given length between meetings.
+func runFree(a *app, args []string) {
+	fs := flag.NewFlagSet("free", flag.ExitOnError)
+	a.addCredentialFlags(fs)
+	length := fs.Duration("for", 30*time.Minute, "How long a gap to look for")
+	within := fs.Duration("within", 8*time.Hour, "How far ahead to look")
+	fs.Parse(args)
+
+	var slot time.Time
+	var err error
+	if len(a.settings.EnabledAccounts()) > 0 {
+		// Meetings in progress make you busy however long ago they started.
+		a.opts.SkipInProgressAfter = 0
+		slot, err = zoom.NextFreeSlotFromSource(context.Background(), a.accountsSource(context.Background(), ""), *length, *within, time.Now())
+	} else {
+		slot, err = zoom.NextFreeSlotWithOptions(a.calendarService(context.Background()), *length, *within, a.opts)
+	}
+	if errors.Is(err, zoom.ErrNoFreeSlot) {
+		fmt.Printf("You're not free for %s in the next %s.\n", shortDuration(*length), shortDuration(*within))
+		os.Exit(1)
+	} else if err != nil {
+		exitWithError("error finding free time", err)
+	}
+
+	if !slot.After(time.Now()) {
+		fmt.Printf("You're free for %s now.\n", shortDuration(*length))
+		return
+	}
+	fmt.Printf("You're next free for %s at %s.\n", shortDuration(*length), zoom.Formatter.AbsoluteTime(zoom.InLocation(slot)))
+}
+
+// shortDuration formats a whole number of minutes without trailing zero units, e.g. "30m" or "1h".
+func shortDuration(d time.Duration) string {
+	s := strings.TrimSuffix(d.Round(time.Minute).String(), "0s")
+	if strings.HasSuffix(s, "h0m") {
+		s = strings.TrimSuffix(s, "0m")
+	}
+	return s
+}
+
+// runRoom prints whether a meeting room is in use, and its current and next bookings.
+func runRoom(a *app, args []string) {
+	fs := flag.NewFlagSet("room", flag.ExitOnError)
+	a.addCredentialFlags(fs)
+	roomID := fs.String("calendar", "", "The room's calendar ID, e.g. its resource email c_1234@resource.calendar.google.com")
+	asJSON := fs.Bool("json", false, "Print the room's status as JSON")
+	fs.Parse(args)
+
+	if *roomID == "" {
+		fmt.Println("usage: zoom room -calendar=c_1234@resource.calendar.google.com [-json]")
+		os.Exit(2)
+	}
+
+	var status zoom.RoomStatus
+	var err error
+	if len(a.settings.EnabledAccounts()) > 0 {
+		// The room's bookings are read by whichever of your accounts can see its calendar.
+		a.opts = zoom.RoomOptions(*roomID, a.opts)
+		status, err = zoom.RoomStatusFromSource(context.Background(), a.accountsSource(context.Background(), ""), time.Now(), zoom.DefaultRoomHorizon)
+	} else {
+		status, err = zoom.RoomWithOptions(a.calendarService(context.Background()), *roomID, a.opts)
+	}
+	if err != nil {
+		exitWithError("error fetching room bookings", err)
+	}
+
+	now := time.Now()
+	if *asJSON {
+		if err := zoom.WriteRoomStatusJSON(os.Stdout, status, now); err != nil {
+			exitWithError("error writing room status", err)
+		}
+		return
+	}
+
+	at := func(t time.Time) string {
+		return zoom.Formatter.AbsoluteTime(zoom.InLocation(t))
+	}
+	switch {
+	case status.Free() && status.Next == nil:
+		fmt.Println("Free, with nothing booked for the next day.")
+	case status.Free():
+		fmt.Printf("Free for %s, until %q at %s.\n", shortDuration(status.For(now)), status.Next.Title, at(status.Next.Start))
+	default:
+		fmt.Printf("In use by %q until %s.\n", status.Current.Title, at(status.Until))
+		if status.Next != nil {
+			fmt.Printf("Next: %q at %s.\n", status.Next.Title, at(status.Next.Start))
+		}
+	}
+}
+
+// runTUI shows today's meetings full screen until you quit, with keys to join, copy, and
+// snooze them.
+func runTUI(a *app, args []string) {
+	fs := flag.NewFlagSet("tui", flag.ExitOnError)
+	a.addCredentialFlags(fs)
+	refresh := fs.Duration("refresh", tui.DefaultRefresh, "How often to fetch your meetings again")
+	snoozeFor := fs.Duration("snooze", tui.DefaultSnooze, "How long the s key snoozes a meeting's notification")
+	fs.Parse(args)
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer cancel()
+
+	// Every meeting today is listed, however long ago it started. Only changed events are
+	// fetched on each refresh, but they are kept in memory, since the daemon's sync file
+	// covers a different window.
+	a.opts.SkipInProgressAfter = 0
+	var source zoom.CalendarSource
+	if accounts := a.accountsSource(ctx, ""); accounts != nil {
+		source = accounts
+	} else {
+		source = zoom.NewIncrementalCalendarSource(a.calendarService(ctx), a.opts, "")
+	}
+	u := &tui.UI{
+		Source:    source,
+		Refresh:   *refresh,
+		SnoozeFor: *snoozeFor,
+		Open: func(meeting zoom.Meeting) error {
+			link := meeting.URL(zoom.URLOptionsFromSettings(a.settings))
+			if link == nil {
+				return zoom.ErrNoMeetingURL
+			}
+			return a.openMeeting(meeting, link)
+		},
+	}
+	if path, err := notifier.DefaultMutesPath(); err == nil {
+		u.Mutes = notifier.NewMutes(path)
+	}
+	if err := u.Run(ctx); err != nil {
+		exitWithError("error running the terminal UI", err)
+	}
+}
+
+// runStatus prints your next meeting for a status bar.
+func runStatus(a *app, args []string) {
+	fs := flag.NewFlagSet("status", flag.ExitOnError)
+	a.addCredentialFlags(fs)
+	bar := fs.String("bar", "tmux", "The status bar to print for: waybar, polybar, xbar, or tmux")
+	cacheFor := fs.Duration("cache", time.Minute, "Reuse the next meeting fetched within this long")
+	fs.Parse(args)
+
+	var meeting *zoom.Meeting
+	if len(a.settings.EnabledAccounts()) > 0 {
+		m, ok, err := a.nextMeeting(context.Background())
+		if err != nil {
+			exitWithError("error fetching next meeting", err)
+		}
+		if ok {
+			meeting = &m
+		}
+	} else {
+		a.useEventStore()
+		a.useCache(*cacheFor)
+
+		event, err := zoom.NextEventWithOptions(a.calendarService(context.Background()), a.opts)
+		if err != nil {
+			exitWithError("error fetching next meeting", err)
+		}
+		if event != nil {
+			m := zoom.MeetingFromEvent(event, a.opts.Providers)
+			meeting = &m
+		}
+	}
+
+	statusbar.URLOptions = zoom.URLOptionsFromSettings(a.settings)
+	now := time.Now()
+	switch *bar {
+	case "waybar":
+		b, err := statusbar.Waybar(meeting, now)
+		if err != nil {
+			exitWithError("error rendering status", err)
+		}
+		fmt.Println(string(b))
+	case "polybar":
+		fmt.Println(statusbar.Polybar(meeting, now, "xdg-open"))
+	case "xbar":
+		fmt.Print(statusbar.Xbar(meeting, now))
+	case "tmux":
+		fmt.Println(statusbar.Tmux(meeting, now))
+	default:
+		fmt.Printf("unknown status bar %q\n", *bar)
+		os.Exit(2)
+	}
+}
+
+// runDaemonCommand notifies about meetings until interrupted.
+func runDaemonCommand(a *app, args []string) {
+	notifyBefore := a.settings.NotifyBefore
+	if notifyBefore <= 0 {
+		notifyBefore = notifier.DefaultLeadTime
+	}
+
+	fs := flag.NewFlagSet("daemon", flag.ExitOnError)
+	a.addCredentialFlags(fs)
+	fs.DurationVar(&notifyBefore, "notify-before", notifyBefore, "How long before each meeting to notify")
+	fs.BoolVar(&a.settings.UseReminders, "reminders", a.settings.UseReminders, "Notify when the reminders set for each meeting in Google Calendar go off, rather than -notify-before it")
+	autoJoin := fs.Bool("auto-join", false, "Open each meeting automatically shortly before it starts")
+	joinBefore := fs.Duration("join-before", notifier.DefaultJoinOffset, "How long before each meeting to open it, when running with -auto-join")
+	fs.BoolVar(&a.opts.SkipCancelledInstances, "skip-cancelled", false, "Skip occurrences of recurring meetings renamed to e.g. \"CANCELLED: Standup\"")
+	listen := fs.String("listen", "", "Also serve your meetings and metrics on this address, as zoom serve does, e.g. 127.0.0.1:8765")
+	fs.Parse(args)
+
+	// Notifications say how often recurring meetings repeat, e.g. "Standup (weekly)".
+	a.opts.DescribeSeries = true
+
+	joinOffset := time.Duration(0)
+	if *autoJoin {
+		joinOffset = *joinBefore
+	}
+	var source zoom.CalendarSource = a.incrementalSource()
+	if *listen != "" {
+		s := a.newServer(source)
+		// The metrics also count the daemon's own requests to your calendar.
+		source = s.Metrics.Instrument(source)
+		go func() {
+			if err := http.ListenAndServe(*listen, s); err != nil {
+				fmt.Printf("error serving meetings: %+v\n", err)
+			}
+		}()
+		fmt.Printf("Serving your meetings and metrics on http://%s/.\n", *listen)
+	}
+	runDaemon(source, notifyBefore, joinOffset, a.settings)
+}
+
+// runDaemon notifies about meetings until interrupted. If joinOffset is non-zero, it
+// also opens each meeting that long before it starts. It also sets your Slack status and
+// calls webhooks, if the settings configure them.
+func runDaemon(source zoom.CalendarSource, notifyBefore, joinOffset time.Duration, settings config.Settings) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt)
+	go func() {
+		<-signals
+		cancel()
+	}()
+
+	n := &notifier.Notifier{
+		Source:       source,
+		LeadTime:     notifyBefore,
+		UseReminders: settings.UseReminders,
+		PreferWebURL: !settings.PreferDeepLink,
+		OnError: func(err error) {
+			fmt.Printf("error checking for meetings: %+v\n", err)
+		},
+	}
+	if path, err := notifier.DefaultMutesPath(); err == nil {
+		// Meetings are snoozed and muted with zoom snooze and zoom mute.
+		n.Mutes = notifier.NewMutes(path)
+	}
+
+	if joinOffset > 0 {
+		a := &notifier.AutoJoiner{
+			Source: source,
+			Offset: joinOffset,
+			BeforeJoin: func(meeting zoom.Meeting) notifier.JoinDecision {
+				fmt.Printf("Joining %q...\n", meeting.Title)
+				return notifier.Join
+			},
+			OnError: func(err error) {
+				fmt.Printf("error joining meeting: %+v\n", err)
+			},
+		}
+		go a.Run(ctx)
+		fmt.Printf("Meetings will be opened %s before they start.\n", joinOffset)
+	}
+
+	if settings.SlackToken != "" {
+		u := &slack.StatusUpdater{
+			Source: source,
+			Client: slack.NewClient(settings.SlackToken),
+			OnError: func(err error) {
+				fmt.Printf("error updating Slack status: %+v\n", err)
+			},
+		}
+		go u.Run(ctx)
+		fmt.Println("Your Slack status will be set during each meeting.")
+	}
+
+	if len(settings.WebhookURLs) > 0 {
+		e := &webhook.Emitter{
+			Source: source,
+			Lead:   notifyBefore,
+			OnError: func(err error) {
+				fmt.Printf("error calling webhook: %+v\n", err)
+			},
+		}
+		for _, u := range settings.WebhookURLs {
+			e.Hooks = append(e.Hooks, webhook.Hook{URL: u, Body: settings.WebhookBody})
+		}
+		go e.Run(ctx)
+		fmt.Printf("Webhooks will be called %s before each meeting, and when it starts and ends.\n", notifyBefore)
+	}
+
+	if settings.UseReminders {
+		fmt.Println("Watching your calendar. You will be notified when each meeting's reminders go off.")
+	} else {
+		fmt.Printf("Watching your calendar. You will be notified %s before each meeting.\n", notifyBefore)
+	}
+	n.Run(ctx)
+}
+
+// runServe serves your meetings as JSON over HTTP until interrupted.
+func runServe(a *app, args []string) {
+	fs := flag.NewFlagSet("serve", flag.ExitOnError)
+	a.addCredentialFlags(fs)
+	addr := fs.String("addr", "127.0.0.1:8765", "Address to listen on")
+	horizon := fs.Duration("horizon", server.DefaultHorizon, "How far ahead to list meetings")
+	fs.Parse(args)
+
+	s := a.newServer(a.incrementalSource())
+	s.Horizon = *horizon
+
+	fmt.Printf("Serving your meetings on http://%s/next, /agenda, /healthz, and /metrics.\n", *addr)
+	fmt.Printf("Bind 'curl -X POST http://%s/join' to a hotkey to join your meeting.\n", *addr)
+	if err := http.ListenAndServe(*addr, s); err != nil {
+		fmt.Printf("error serving meetings: %+v\n", err)
+		os.Exit(1)
+	}
+}
+
+// runAuth manages your authorization to read your calendar.
+func runAuth(a *app, args []string) {
+	if len(args) == 0 || args[0] != "login" {
+		fmt.Println("usage: zoom auth login [-device] [-account=NAME] [-import=client_secrets.json]")
+		os.Exit(2)
+	}
+
+	fs := flag.NewFlagSet("auth login", flag.ExitOnError)
+	fs.StringVar(&a.importCredential, "import", "", "Full path to your downloaded Google OAuth2 client_secret JSON file")
+	device := fs.Bool("device", false, "Authorize by entering a code on another device, e.g. when there's no browser on this one")
+	account := fs.String("account", "", "Authorize one of the accounts in your settings, e.g. work, rather than your only account")
+	fs.Parse(args[1:])
+
+	authConfig := auth.Config{
+		OAuth:  a.oauthConfig(),
+		Store:  providerStore{a.provider},
+		Output: os.Stdout,
+	}
+	if *account != "" {
+		known := false
+		for _, name := range a.settings.Accounts {
+			known = known || name == *account
+		}
+		if !known {
+			fmt.Printf("There's no %q account in your settings. Add it to accounts first.\n", *account)
+			os.Exit(1)
+		}
+		authConfig.Store = auth.NewAccountStore(*account)
+	}
+	if *device {
+		authConfig.Flow = auth.DeviceCode
+	}
+
+	if _, err := auth.Login(context.Background(), authConfig); err != nil {
+		exitWithError("error authorizing", err)
+	}
+	fmt.Println("Stored credentials.")
+}
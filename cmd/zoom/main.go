@@ -1,148 +1,112 @@
 // Command zoom prints your next Google Calendar event and opens Zoom if the meeting is a zoom meeting.
 //
 // To install, run:
-//     go install github.com/benbalter/zoom-go/cmd/zoom
+//
+//	go install github.com/benbalter/zoom-go/cmd/zoom
 //
 // To use, run:
-//     zoom
+//
+//	zoom
 //
 // If you used the Ruby gem zoom_launcher, this project will gladly use the credentials you generated before.
 //
 // When setting up your credentials, you will run:
-//     zoom -import=$HOME/Downloads/google_credentials.json
 //
-// Then, you can run the zoom command without any issue.
+//	zoom auth login -import=$HOME/Downloads/google_credentials.json
+//
+// Then, you can run the zoom command without any issue. It has these subcommands:
+//
+//	zoom next          print your next meeting, and open it if it starts soon (the default)
+//	zoom join          open your next meeting now, or start it with -start if you host it
+//	zoom copy          copy your next meeting's link, or its dial-in number with -dial-in
+//	zoom rejoin        open the meeting you joined last again, even if it has ended
+//	zoom history       list the meetings you joined recently
+//	zoom agenda        list your meetings for the next day
+//	zoom free          print when you next have a gap between meetings, e.g. for a break
+//	zoom room          print whether a meeting room is in use, for a display outside it
+//	zoom status        print your next meeting for waybar, polybar, xbar, or tmux
+//	zoom tui           show today's meetings full screen, with a countdown and keys to join them
+//	zoom daemon        notify you before each meeting, and open it with -auto-join
+//	zoom snooze        hold off the daemon's notification about your next meeting
+//	zoom mute          stop notifications about your next meeting's series
+//	zoom serve         serve your meetings as JSON on localhost
+//	zoom auth login    authorize access to your calendar, or one of several with -account
+//
+// Run any of them with -h to see their flags.
 package main
 
 import (
-	"flag"
 	"fmt"
 	"os"
-	"time"
-
-	"github.com/pkg/errors"
-	"github.com/skratchdot/open-golang/open"
-
-	"github.com/benbalter/zoom-go"
-	"github.com/benbalter/zoom-go/config"
+	"strings"
 )
 
-func printSetupInstructions() {
-	fmt.Print(`In order to use Zoom Launcher, you need to create an OAuth app and authorize it to access your calendar.
-You can do it in four, not-so-easy steps:
-
-1. Create a new project
-	1. Go to https://console.developers.google.com
-	2. Switch to your work account if need be (top right)
-	3. Create a new project dropdown, top left next to your domain
-2. Grant the project Calendar API access
-	1. Click "Enable API"
-	2. Type "Calendar" in the search box
-	3. Click "Calendar API"
-	4. Click "Enable"
-3. Grab your credentials
-	1. Click "Credentials" on the left side
-	2. Create a new OAuth credential with type "other"
-	3. Download the credential to ~/.config/google/client_secrets.json (icon, right side)
-4. Run 'zoom -import=Downloads/client_secrets.json' and follow the instructions to authorize the app.
-`)
+// command is a subcommand of zoom.
+type command struct {
+	name    string
+	summary string
+	run     func(a *app, args []string)
 }
 
-func importGoogleClientConfig(provider config.Provider, filename string) error {
-	conf, err := config.ReadGoogleClientConfigFromFile(filename)
-	if err != nil {
-		return err
-	}
-
-	return provider.StoreGoogleClientConfig(conf)
+var commands = []command{
+	{"next", "print your next meeting, and open it if it starts soon", runNext},
+	{"join", "open your next meeting now", runJoin},
+	{"copy", "copy your next meeting's link to the clipboard", runCopy},
+	{"rejoin", "open the meeting you joined last again", runRejoin},
+	{"history", "list the meetings you joined recently", runHistory},
+	{"agenda", "list your upcoming meetings", runAgenda},
+	{"free", "print when you next have a gap between meetings", runFree},
+	{"room", "print whether a meeting room is in use", runRoom},
+	{"status", "print your next meeting for a status bar", runStatus},
+	{"tui", "show today's meetings full screen, with keys to join them", runTUI},
+	{"daemon", "notify you before each meeting", runDaemonCommand},
+	{"snooze", "hold off notifying you about your next meeting", runSnooze},
+	{"mute", "stop notifying you about your next meeting's series", runMute},
+	{"serve", "serve your meetings as JSON on localhost", runServe},
+	{"auth", "authorize access to your calendar (auth login)", runAuth},
 }
 
-func authorizeAccount(provider config.Provider) error {
-	authURL, err := zoom.GoogleCalendarAuthorizationURL(provider)
-	if err != nil {
-		return err
-	}
-
-	fmt.Println("Your browser is about to open. When it does, please authorize the application when prompted and paste the token it gives you below.")
-	time.Sleep(5 * time.Second)
-	open.Run(authURL)
-
-	fmt.Print("Authorization token: ")
-	var authCode string
-	if _, err := fmt.Scan(&authCode); err != nil {
-		return errors.WithStack(err)
+func printUsage() {
+	fmt.Println("usage: zoom <command> [flags]")
+	fmt.Println()
+	for _, c := range commands {
+		fmt.Printf("  %-8s %s\n", c.name, c.summary)
 	}
-	return zoom.HandleGoogleCalendarAuthorization(provider, authCode)
+	fmt.Println()
+	fmt.Println("Run 'zoom <command> -h' for the command's flags. With no command, zoom runs 'next'.")
 }
 
 func main() {
-	provider, err := config.NewFileProvider()
-	if err != nil {
-		fmt.Printf("unable to create file configuration provider: %+v\n", err)
-		os.Exit(1)
-	}
-
-	importCredential := flag.String("import", "", "Full path to your downloaded Google OAuth2 client_secret JSON file")
-	flag.Parse()
-
-	if importCredential != nil && *importCredential != "" {
-		fmt.Printf("Importing credentials from %q...\n", *importCredential)
-		if err := importGoogleClientConfig(provider, *importCredential); err != nil {
-			fmt.Printf("error importing credentials: %+v\n", err)
-		}
+	name, args := "next", os.Args[1:]
+	if len(args) > 0 && !strings.HasPrefix(args[0], "-") {
+		name, args = args[0], args[1:]
+	} else if i := indexOf(args, "-daemon"); i >= 0 {
+		// Earlier versions ran the daemon with the -daemon flag rather than a subcommand.
+		name, args = "daemon", append(args[:i:i], args[i+1:]...)
 	}
 
-	if !provider.GoogleClientConfigExists() {
-		printSetupInstructions()
-		os.Exit(1)
-	}
-
-	if !provider.GoogleTokenExists() {
-		if err := authorizeAccount(provider); err != nil {
-			fmt.Printf("error authorizing: %+v\n", err)
-			os.Exit(1)
-		}
-		fmt.Println("Stored credentials.")
-	}
-
-	calendar, err := zoom.NewGoogleCalendarService(provider)
-	if err != nil {
-		fmt.Printf("error creating google calendar client: %+v\n", err)
-		os.Exit(1)
-	}
-
-	meeting, err := zoom.NextEvent(calendar)
-	if err != nil {
-		fmt.Printf("error fetching next meeting: %+v\n", err)
-		os.Exit(1)
-	}
-
-	if meeting == nil {
-		fmt.Println("No upcoming events found.")
+	if name == "help" {
+		printUsage()
 		return
 	}
-
-	fmt.Println(zoom.MeetingSummary(meeting))
-
-	startTime, err := zoom.MeetingStartTime(meeting)
-	if startTime.Sub(time.Now()) < 0 {
-		fmt.Printf("It started %s.\n", zoom.HumanizedStartTime(meeting))
-	} else {
-		fmt.Printf("It starts %s.\n", zoom.HumanizedStartTime(meeting))
+	for _, c := range commands {
+		if c.name == name {
+			c.run(newApp(), args)
+			return
+		}
 	}
 
-	fmt.Printf("Calendar event URL: %s\n\n", meeting.HtmlLink)
-
-	url, ok := zoom.MeetingURLFromEvent(meeting)
-	if !ok {
-		fmt.Println("No Zoom URL found in the meeting.")
-		os.Exit(1)
-	}
+	fmt.Printf("unknown command %q\n\n", name)
+	printUsage()
+	os.Exit(2)
+}
 
-	if zoom.IsMeetingSoon(meeting) {
-		fmt.Printf("Opening %s...\n", url)
-		open.Run(url.String())
-	} else {
-		fmt.Printf("Zoom URL: %s\n", url)
+// indexOf returns the index of the first arg equal to flag, with one or two dashes, or -1.
+func indexOf(args []string, flag string) int {
+	for i, arg := range args {
+		if arg == flag || arg == "-"+flag {
+			return i
+		}
 	}
+	return -1
 }
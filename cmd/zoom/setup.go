@@ -0,0 +1,370 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"os"
+	"path/filepath"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	"golang.org/x/oauth2"
+	gcalendar "google.golang.org/api/calendar/v3"
+
+	"github.com/benbalter/zoom-go"
+	"github.com/benbalter/zoom-go/auth"
+	"github.com/benbalter/zoom-go/config"
+	"github.com/benbalter/zoom-go/server"
+	"github.com/benbalter/zoom-go/zoomapi"
+)
+
+func printSetupInstructions() {
+	fmt.Print(`In order to use Zoom Launcher, you need to create an OAuth app and authorize it to access your calendar.
+You can do it in four, not-so-easy steps:
+
+1. Create a new project
+	1. Go to https://console.developers.google.com
+	2. Switch to your work account if need be (top right)
+	3. Create a new project dropdown, top left next to your domain
+2. Grant the project Calendar API access
+	1. Click "Enable API"
+	2. Type "Calendar" in the search box
+	3. Click "Calendar API"
+	4. Click "Enable"
+3. Grab your credentials
+	1. Click "Credentials" on the left side
+	2. Create a new OAuth credential with type "Desktop app"
+	3. Download the credential to ~/.config/google/client_secrets.json (icon, right side)
+4. Run 'zoom auth login -import=Downloads/client_secrets.json' and follow the instructions to authorize the app.
+`)
+}
+
+// exitWithError prints the message and error, with advice if it's a calendar error you
+// can do something about, then exits unsuccessfully.
+func exitWithError(message string, err error) {
+	fmt.Printf("%s: %+v\n", message, err)
+	switch {
+	case errors.Is(err, zoom.ErrAuthExpired):
+		fmt.Println("Your authorization has expired. Run 'zoom auth login' to authorize access to your calendar again.")
+	case errors.Is(err, zoom.ErrCalendarUnavailable):
+		fmt.Println("Your calendar can't be reached right now. Check your connection and try again.")
+	}
+	os.Exit(1)
+}
+
+func importGoogleClientConfig(provider config.Provider, filename string) error {
+	conf, err := config.ReadGoogleClientConfigFromFile(filename)
+	if err != nil {
+		return err
+	}
+
+	return provider.StoreGoogleClientConfig(conf)
+}
+
+// providerStore keeps the OAuth token in the configuration provider, where earlier
+// versions of this command, and the zoom_launcher gem, kept it.
+type providerStore struct {
+	provider config.Provider
+}
+
+func (s providerStore) Token() (*oauth2.Token, error) {
+	if !s.provider.GoogleTokenExists() {
+		return nil, auth.ErrNoToken
+	}
+	return s.provider.GoogleToken()
+}
+
+func (s providerStore) StoreToken(token *oauth2.Token) error {
+	return s.provider.StoreGoogleToken(token)
+}
+
+// app holds what every command needs: your settings and how to reach your calendar.
+type app struct {
+	provider config.Provider
+	settings config.Settings
+	opts     zoom.Options
+	logger   *slog.Logger
+	history  *zoom.History
+	zoomAPI  *zoomapi.Client
+
+	importCredential string
+	serviceAccount   string
+	impersonate      string
+}
+
+// newApp loads your configuration and settings, exiting if they can't be read.
+func newApp() *app {
+	provider, err := config.NewFileProvider()
+	if err != nil {
+		exitWithError("unable to create file configuration provider", err)
+	}
+
+	settings, err := config.LoadSettings()
+	if err != nil {
+		exitWithError("error loading settings", err)
+	}
+	opts, err := zoom.OptionsFromSettings(settings)
+	if err != nil {
+		exitWithError("error loading settings", err)
+	}
+	if settings.SoonBefore > 0 {
+		zoom.SoonBefore = settings.SoonBefore
+	}
+	if settings.SoonAfter > 0 {
+		zoom.SoonAfter = settings.SoonAfter
+	}
+	if settings.Locale != "" {
+		locale, ok := zoom.LocaleNamed(settings.Locale)
+		if !ok {
+			fmt.Printf("error loading settings: unsupported locale %q\n", settings.Locale)
+			os.Exit(1)
+		}
+		zoom.Formatter = locale
+	}
+	if settings.Timezone != "" {
+		// The timezone was validated when the settings were loaded.
+		zoom.Location, _ = time.LoadLocation(settings.Timezone)
+	}
+
+	a := &app{provider: provider, settings: settings, opts: opts}
+	if path, err := zoom.DefaultPersonalRoomPath(); err == nil {
+		a.opts.PersonalRooms = zoom.NewPersonalRoomResolver(0, path)
+	}
+	if path, err := zoom.DefaultHistoryPath(); err == nil {
+		a.history = zoom.NewHistory(path)
+		// Meetings joined by the daemon are recorded too.
+		zoom.DefaultOpener.History = a.history
+	}
+	zoom.DefaultOpener.PreferWebURL = !settings.PreferDeepLink
+	if zoom.DefaultOpener.Rules, err = zoom.JoinRulesFromSettings(settings); err != nil {
+		exitWithError("error loading settings", err)
+	}
+	if settings.ZoomAccountID != "" && settings.ZoomClientID != "" && settings.ZoomClientSecret != "" {
+		a.zoomAPI = zoomapi.NewClient(settings.ZoomAccountID, settings.ZoomClientID, settings.ZoomClientSecret)
+		a.zoomAPI.Me = settings.ZoomEmail
+	}
+	if settings.Debug {
+		a.logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: slog.LevelDebug}))
+		a.opts.Logger = a.logger
+	}
+	return a
+}
+
+// addCredentialFlags adds the flags which choose how to authenticate to the flag set.
+func (a *app) addCredentialFlags(fs *flag.FlagSet) {
+	fs.StringVar(&a.importCredential, "import", "", "Full path to your downloaded Google OAuth2 client_secret JSON file")
+	fs.StringVar(&a.serviceAccount, "service-account", "", "Path to a Google service account JSON key to authenticate with instead of your own account")
+	fs.StringVar(&a.impersonate, "impersonate", "", "Email of the user or room whose calendar to read, when running with -service-account")
+}
+
+// oauthConfig returns your OAuth client config, importing it first if -import was given.
+// It exits with setup instructions if there is none.
+func (a *app) oauthConfig() *oauth2.Config {
+	if a.importCredential != "" {
+		fmt.Printf("Importing credentials from %q...\n", a.importCredential)
+		if err := importGoogleClientConfig(a.provider, a.importCredential); err != nil {
+			fmt.Printf("error importing credentials: %+v\n", err)
+		}
+	}
+
+	if !a.provider.GoogleClientConfigExists() {
+		printSetupInstructions()
+		os.Exit(1)
+	}
+
+	conf, err := a.provider.GoogleClientConfig()
+	if err != nil {
+		exitWithError("error reading google client config", err)
+	}
+	return conf
+}
+
+// calendarService returns a calendar service authorized as the service account, if one
+// was given, or else as you, asking you to authorize access first if needed.
+func (a *app) calendarService(ctx context.Context) *gcalendar.Service {
+	authConfig := auth.Config{}
+	if a.logger != nil {
+		authConfig.Logger = a.logger
+	}
+	if a.serviceAccount != "" {
+		conf, err := config.ReadGoogleServiceAccountConfigFromFile(a.serviceAccount, a.impersonate)
+		if err != nil {
+			exitWithError("error reading service account", err)
+		}
+		authConfig.ServiceAccount = conf
+	} else {
+		authConfig.OAuth = a.oauthConfig()
+		authConfig.Store = providerStore{a.provider}
+		authConfig.Output = os.Stdout
+	}
+
+	service, err := auth.NewService(ctx, authConfig)
+	if err != nil {
+		exitWithError("error creating google calendar client", err)
+	}
+	return service
+}
+
+// useEventStore makes the options fall back to the events from your last sync when your
+// calendar can't be reached.
+func (a *app) useEventStore() {
+	path, err := zoom.DefaultEventStorePath()
+	if err != nil {
+		return
+	}
+	a.opts.Store = zoom.NewEventStore(path)
+	a.opts.Store.OnStale = func(syncedAt time.Time, err error) {
+		fmt.Fprintf(os.Stderr, "Unable to reach your calendar, showing it as of %s.\n", humanize.Time(syncedAt))
+	}
+}
+
+// openMeeting opens the meeting at the URL, as your join rules say, and records it in
+// your history so you can rejoin it later.
+func (a *app) openMeeting(meeting zoom.Meeting, u *url.URL) error {
+	if err := zoom.Join(meeting, u); err != nil {
+		return err
+	}
+	a.recordJoin(meeting, u)
+	return nil
+}
+
+// openURL opens the URL, and records the meeting at the join URL in your history. They
+// differ for URLs which shouldn't be kept, such as start URLs, which carry a token to
+// start the meeting as its host.
+func (a *app) openURL(meeting zoom.Meeting, u, joinURL *url.URL) error {
+	if err := zoom.OpenURL(u); err != nil {
+		return err
+	}
+	a.recordJoin(meeting, joinURL)
+	return nil
+}
+
+// recordJoin records the meeting at the join URL in your history.
+func (a *app) recordJoin(meeting zoom.Meeting, joinURL *url.URL) {
+	if a.history != nil && joinURL != nil {
+		if err := a.history.Record(meeting, joinURL, time.Now()); err != nil {
+			fmt.Fprintf(os.Stderr, "Unable to record the meeting in your history: %v\n", err)
+		}
+	}
+}
+
+// incrementalSource returns a source which only lists the events changed since its last
+// refresh, keeping the events it has synced in your user cache directory. If you have
+// configured accounts, it merges the meetings of each enabled account.
+func (a *app) incrementalSource() zoom.CalendarSource {
+	// If there's no cache directory, the events are only kept in memory.
+	path, _ := zoom.DefaultIncrementalSyncPath()
+	if source := a.accountsSource(context.Background(), path); source != nil {
+		return source
+	}
+	return zoom.NewIncrementalCalendarSource(a.calendarService(context.Background()), a.opts, path)
+}
+
+// accountsSource returns a source which merges the meetings of your enabled accounts, or
+// nil if you haven't configured any. Each account's events are synced incrementally, and
+// kept next to syncPath, or only in memory if it is empty. Accounts which can't be
+// reached are reported, and their meetings left out.
+func (a *app) accountsSource(ctx context.Context, syncPath string) *zoom.AccountsSource {
+	names := a.settings.EnabledAccounts()
+	if len(names) == 0 {
+		return nil
+	}
+
+	source := &zoom.AccountsSource{
+		OnError: func(account string, err error) {
+			fmt.Fprintf(os.Stderr, "Unable to fetch the meetings of your %s account: %v\n", account, err)
+			if errors.Is(err, zoom.ErrAuthExpired) {
+				fmt.Fprintf(os.Stderr, "Run 'zoom auth login -account %s' to authorize access to its calendar again.\n", account)
+			}
+		},
+	}
+	for _, name := range names {
+		path := ""
+		if syncPath != "" {
+			path = filepath.Join(filepath.Dir(syncPath), "sync-"+name+".json")
+		}
+		service := a.accountService(ctx, name)
+		source.Accounts = append(source.Accounts, zoom.Account{
+			Name:   name,
+			Source: zoom.NewIncrementalCalendarSource(service, a.opts, path),
+		})
+	}
+	return source
+}
+
+// accountService returns a calendar service authorized as the named account, asking you
+// to authorize access first if needed.
+func (a *app) accountService(ctx context.Context, name string) *gcalendar.Service {
+	authConfig := auth.Config{
+		OAuth:  a.oauthConfig(),
+		Store:  auth.NewAccountStore(name),
+		Output: os.Stdout,
+	}
+	if a.logger != nil {
+		authConfig.Logger = a.logger
+	}
+	service, err := auth.NewService(ctx, authConfig)
+	if err != nil {
+		exitWithError(fmt.Sprintf("error creating google calendar client for your %s account", name), err)
+	}
+	return service
+}
+
+// nextMeeting returns your next meeting, preferring one with a join URL, from every
+// enabled account if you have configured accounts. ok is false if there is none.
+func (a *app) nextMeeting(ctx context.Context) (meeting zoom.Meeting, ok bool, err error) {
+	source := a.accountsSource(ctx, "")
+	if source == nil {
+		return zoom.NextMeetingContext(ctx, a.calendarService(ctx), a.opts)
+	}
+
+	window := zoom.Window{Start: time.Now()}
+	if a.opts.Horizon > 0 {
+		window.End = window.Start.Add(a.opts.Horizon)
+	}
+	next, err := zoom.NextMeetingFromSource(ctx, source, window)
+	if errors.Is(err, zoom.ErrNoUpcomingEvents) {
+		return zoom.Meeting{}, false, nil
+	} else if err != nil {
+		return zoom.Meeting{}, false, err
+	}
+	return *next, true, nil
+}
+
+// eventsMatching returns the meetings in the span of time the query describes, from every
+// enabled account if you have configured accounts. Meetings in progress are listed however
+// long ago they started.
+func (a *app) eventsMatching(query string) ([]zoom.Meeting, error) {
+	ctx := context.Background()
+	if len(a.settings.EnabledAccounts()) == 0 {
+		return zoom.EventsMatchingContext(ctx, a.calendarService(ctx), query, a.opts)
+	}
+	a.opts.SkipInProgressAfter = 0
+	return zoom.EventsMatchingFromSource(ctx, a.accountsSource(ctx, ""), query, time.Now())
+}
+
+// newServer returns a server for the source which opens your meetings on POST /join,
+// keeping its meetings fresh so joining doesn't wait for your calendar.
+func (a *app) newServer(source zoom.CalendarSource) *server.Server {
+	s := server.New(source)
+	s.Open = a.openMeeting
+	s.URLOptions = zoom.URLOptionsFromSettings(a.settings)
+	go s.Warm(context.Background())
+	return s
+}
+
+// useCache makes the options reuse the next event fetched within the TTL.
+func (a *app) useCache(ttl time.Duration) {
+	if ttl <= 0 {
+		return
+	}
+	path, err := zoom.DefaultCachePath()
+	if err != nil {
+		exitWithError("error locating cache", err)
+	}
+	a.opts.Cache = zoom.NewCache(ttl, path)
+}
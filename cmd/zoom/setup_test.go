@@ -0,0 +1,51 @@
+package main
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benbalter/zoom-go"
+)
+
+// withSettings points newApp at a config.yaml with the contents, and at empty home and
+// cache directories, restoring the DefaultOpener newApp configures when the test ends.
+func withSettings(t *testing.T, contents string) {
+	dir := t.TempDir()
+	t.Setenv("HOME", dir)
+	t.Setenv("XDG_CONFIG_HOME", filepath.Join(dir, "config"))
+	t.Setenv("XDG_CACHE_HOME", filepath.Join(dir, "cache"))
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "config", "zoom-go"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "config", "zoom-go", "config.yaml"), []byte(contents), 0600))
+
+	opener := *zoom.DefaultOpener
+	t.Cleanup(func() { *zoom.DefaultOpener = opener })
+}
+
+func TestNewApp_PreferDeepLink(t *testing.T) {
+	joinURL, _ := url.Parse("https://jithub.zoom.us/j/12345")
+	deepLink, _ := url.Parse("zoommtg://zoom.us/join?confno=12345")
+	meeting := zoom.Meeting{Title: "Standup", JoinURL: joinURL, DeepLink: deepLink}
+
+	for contents, want := range map[string]string{
+		"":                          "zoommtg://zoom.us/join?confno=12345",
+		"prefer_deep_link: true\n":  "zoommtg://zoom.us/join?confno=12345",
+		"prefer_deep_link: false\n": "https://jithub.zoom.us/j/12345",
+	} {
+		withSettings(t, contents)
+		newApp()
+
+		var opened []string
+		zoom.DefaultOpener.GOOS = "linux"
+		zoom.DefaultOpener.Run = func(name string, args ...string) error {
+			opened = append(opened, args...)
+			return nil
+		}
+		require.NoError(t, zoom.Open(meeting))
+		assert.Equal(t, []string{want}, opened, "config.yaml: %q", contents)
+	}
+}
@@ -0,0 +1,50 @@
+package zoom
+
+import (
+	"net/url"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// MeetingURLFromConferenceData returns a URL if the event's structured conference data
+// describes a Zoom meeting. Unlike MeetingURLFromEvent, the location and description are
+// not scanned.
+func MeetingURLFromConferenceData(event *calendar.Event) (*url.URL, bool) {
+	webURL, deepLink, ok := meetingURLsFromConferenceData(event)
+	if !ok {
+		return nil, false
+	}
+	return URLOptions{PreferDeepLink: true}.choose(webURL, deepLink), true
+}
+
+// meetingURLsFromConferenceData returns the Zoom URL from the event's video entry point
+// along with the zoommtg:// deep link, using the entry point's meeting code if the URL
+// does not contain a meeting ID. The deep link includes the meeting passcode, if one was found.
+func meetingURLsFromConferenceData(event *calendar.Event) (webURL, deepLink *url.URL, ok bool) {
+	entryPoint := videoEntryPoint(event)
+	if entryPoint == nil {
+		return nil, nil, false
+	}
+
+	webURL, deepLink, ok = meetingURLsFromText(entryPoint.Uri)
+	if !ok {
+		return nil, nil, false
+	}
+	if deepLink == nil {
+		deepLink = zoomDeepLink(webURL.Host, "join", entryPoint.MeetingCode)
+	}
+	return webURL, withPasscode(deepLink, meetingPasscode(event, webURL)), true
+}
+
+// videoEntryPoint returns the video entry point in the event's conference data, if any.
+func videoEntryPoint(event *calendar.Event) *calendar.EntryPoint {
+	if event == nil || event.ConferenceData == nil {
+		return nil
+	}
+	for _, entryPoint := range event.ConferenceData.EntryPoints {
+		if entryPoint != nil && entryPoint.EntryPointType == "video" {
+			return entryPoint
+		}
+	}
+	return nil
+}
@@ -0,0 +1,78 @@
+package zoom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestMeetingURLFromConferenceData(t *testing.T) {
+	testCases := []struct {
+		input    *calendar.Event
+		expected string
+	}{
+		{&calendar.Event{ConferenceData: &calendar.ConferenceData{
+			EntryPoints: []*calendar.EntryPoint{
+				{EntryPointType: "phone", Uri: "tel:+1-646-558-8656"},
+				{EntryPointType: "video", Uri: "https://jithub.zoom.us/j/12345"},
+			},
+		}}, "zoommtg://zoom.us/join?confno=12345"},
+		{&calendar.Event{ConferenceData: &calendar.ConferenceData{
+			EntryPoints: []*calendar.EntryPoint{
+				{EntryPointType: "video", Uri: "https://jithub.zoom.us/my/parkr", MeetingCode: "67890"},
+			},
+		}}, "zoommtg://zoom.us/join?confno=67890"},
+		{&calendar.Event{ConferenceData: &calendar.ConferenceData{
+			EntryPoints: []*calendar.EntryPoint{
+				{EntryPointType: "video", Uri: "https://jithub.zoom.us/my/parkr"},
+			},
+		}}, "https://jithub.zoom.us/my/parkr"},
+	}
+	for _, testCase := range testCases {
+		u, ok := MeetingURLFromConferenceData(testCase.input)
+		if assert.True(t, ok, "input: %+v", testCase.input) {
+			assert.Equal(t, testCase.expected, u.String())
+		}
+	}
+}
+
+func TestMeetingURLFromConferenceData_NoMatch(t *testing.T) {
+	for _, event := range []*calendar.Event{
+		nil,
+		{},
+		{Location: "https://jithub.zoom.us/j/12345"},
+		{ConferenceData: &calendar.ConferenceData{
+			EntryPoints: []*calendar.EntryPoint{
+				{EntryPointType: "video", Uri: "https://meet.google.com/abc-defg-hij"},
+			},
+		}},
+	} {
+		_, ok := MeetingURLFromConferenceData(event)
+		assert.False(t, ok, "input: %+v", event)
+	}
+}
+
+func TestMeetingURLFromEvent_PrefersConferenceData(t *testing.T) {
+	u, ok := MeetingURLFromEvent(&calendar.Event{
+		Location: "https://jithub.zoom.us/j/11111",
+		ConferenceData: &calendar.ConferenceData{
+			EntryPoints: []*calendar.EntryPoint{
+				{EntryPointType: "video", Uri: "https://jithub.zoom.us/j/22222"},
+			},
+		},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=22222", u.String())
+
+	u, ok = MeetingURLFromEvent(&calendar.Event{
+		Location: "https://jithub.zoom.us/j/11111",
+		ConferenceData: &calendar.ConferenceData{
+			EntryPoints: []*calendar.EntryPoint{
+				{EntryPointType: "video", Uri: "https://meet.google.com/abc-defg-hij"},
+			},
+		},
+	})
+	assert.True(t, ok)
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=11111", u.String())
+}
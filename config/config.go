@@ -2,11 +2,12 @@
 package config
 
 import (
+	"errors"
 	"io/ioutil"
 
-	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
 	"golang.org/x/oauth2/google"
+	"golang.org/x/oauth2/jwt"
 	calendar "google.golang.org/api/calendar/v3"
 )
 
@@ -42,13 +43,30 @@ type Provider interface {
 func ReadGoogleClientConfigFromFile(filepath string) (*oauth2.Config, error) {
 	b, err := ioutil.ReadFile(filepath)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, err
 	}
 
 	// If modifying these scopes, delete your previously saved client_secret.json.
 	conf, err := google.ConfigFromJSON(b, calendar.CalendarReadonlyScope)
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, err
 	}
 	return conf, nil
 }
+
+// ReadGoogleServiceAccountConfigFromFile reads a service account's JSON key file. If subject
+// is not empty, the service account impersonates that user or room resource, which requires
+// domain-wide delegation to be enabled for it in your Google Workspace admin console.
+func ReadGoogleServiceAccountConfigFromFile(filepath, subject string) (*jwt.Config, error) {
+	b, err := ioutil.ReadFile(filepath)
+	if err != nil {
+		return nil, err
+	}
+
+	conf, err := google.JWTConfigFromJSON(b, calendar.CalendarReadonlyScope)
+	if err != nil {
+		return nil, err
+	}
+	conf.Subject = subject
+	return conf, nil
+}
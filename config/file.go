@@ -6,7 +6,6 @@ import (
 	"os/user"
 	"path/filepath"
 
-	"github.com/pkg/errors"
 	"golang.org/x/oauth2"
 )
 
@@ -25,7 +24,7 @@ type FileProvider struct {
 func NewFileProvider() (*FileProvider, error) {
 	usr, err := user.Current()
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, err
 	}
 
 	return &FileProvider{
@@ -62,7 +61,7 @@ func (f *FileProvider) GoogleClientConfig() (*oauth2.Config, error) {
 		if os.IsNotExist(err) {
 			return nil, ErrNoGoogleClientConfig
 		}
-		return nil, errors.WithStack(err)
+		return nil, err
 	}
 
 	f.cachedGoogleClientConfig = conf
@@ -76,7 +75,7 @@ func (f *FileProvider) StoreGoogleClientConfig(conf *oauth2.Config) error {
 
 	fd, err := os.Create(filepath.Join(f.directory, googleClientConfigFilename))
 	if err != nil {
-		return errors.WithStack(err)
+		return err
 	}
 
 	return json.NewEncoder(fd).Encode(conf)
@@ -101,7 +100,7 @@ func (f *FileProvider) GoogleToken() (*oauth2.Token, error) {
 		if os.IsNotExist(err) {
 			return nil, ErrNoGoogleToken
 		}
-		return nil, errors.WithStack(err)
+		return nil, err
 	}
 
 	f.cachedGoogleToken = token
@@ -115,7 +114,7 @@ func (f *FileProvider) StoreGoogleToken(token *oauth2.Token) error {
 
 	fd, err := os.Create(filepath.Join(f.directory, googleTokenFilename))
 	if err != nil {
-		return errors.WithStack(err)
+		return err
 	}
 
 	return json.NewEncoder(fd).Encode(token)
@@ -0,0 +1,452 @@
+package config
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// envPrefix prefixes the environment variables which override settings, e.g. ZOOM_GO_HORIZON.
+const envPrefix = "ZOOM_GO_"
+
+// settingsFilenames are the names of the settings file, in order of preference.
+var settingsFilenames = []string{"config.yaml", "config.yml", "config.toml"}
+
+// Settings are your preferences for finding and opening meetings.
+type Settings struct {
+	// CalendarIDs are the calendars to search (calendar_ids). If empty, only your primary
+	// calendar is searched.
+	CalendarIDs []string
+
+	// AllCalendars searches every calendar you can read (all_calendars).
+	AllCalendars bool
+
+	// Horizon limits the search to meetings starting within this long (horizon).
+	Horizon time.Duration
+
+	// MaxResults is the number of events to list at a time (max_results).
+	MaxResults int64
+
+	// Concurrency is the number of calendars to list at once (concurrency). If zero, 4
+	// calendars are listed at once.
+	Concurrency int
+
+	// Providers are the names of the video-conferencing services to recognize (providers),
+	// e.g. "zoom" or "google meet".
+	Providers []string
+
+	// PreferDeepLink opens meetings in the provider's app rather than the browser
+	// (prefer_deep_link). It defaults to true.
+	PreferDeepLink bool
+
+	// JoinRules choose how to join the meetings of particular providers or domains
+	// (join_rules), such as "zoom.us app", "teams copy", or
+	// "meet.google.com browser google-chrome --profile-directory=Work". The first rule
+	// matching a meeting is used.
+	JoinRules []string
+
+	// NotifyBefore is how long before each meeting to notify (notify_before).
+	NotifyBefore time.Duration
+
+	// UseReminders makes zoom daemon notify about each meeting when the reminders set for
+	// it in Google Calendar go off, rather than NotifyBefore it starts (use_reminders).
+	UseReminders bool
+
+	// SoonBefore and SoonAfter are how long before and after a meeting starts it counts
+	// as soon, and is opened right away (soon_before and soon_after). If zero, the
+	// default of 5 minutes is used.
+	SoonBefore time.Duration
+	SoonAfter  time.Duration
+
+	// Locale is the language to show times in (locale), such as "de" or "fr_FR.UTF-8".
+	// If empty, times are shown in English.
+	Locale string
+
+	// SlackToken is a Slack user token with the users.profile:write scope (slack_token).
+	// If set, zoom daemon sets your Slack status during each meeting. It is best set with
+	// the ZOOM_GO_SLACK_TOKEN environment variable rather than in the settings file.
+	SlackToken string
+
+	// Accounts are the names of the Google accounts whose meetings are merged (accounts),
+	// such as "work" and "personal". Each is authorized with zoom auth -account NAME, and
+	// its meetings are labelled with its name. If empty, only the account authorized with
+	// zoom auth is used.
+	Accounts []string
+
+	// DisabledAccounts are accounts which are skipped for now, without forgetting their
+	// authorization (disabled_accounts).
+	DisabledAccounts []string
+
+	// ZoomAccountID, ZoomClientID, and ZoomClientSecret are the credentials of a Zoom
+	// Server-to-Server OAuth app with the meeting:read:admin scope (zoom_account_id,
+	// zoom_client_id, and zoom_client_secret). If set, zoom next shows details from Zoom,
+	// such as the meeting's host, and zoom join -start starts meetings you host. The
+	// secret is best set with the ZOOM_GO_ZOOM_CLIENT_SECRET environment variable.
+	ZoomAccountID    string
+	ZoomClientID     string
+	ZoomClientSecret string
+
+	// ZoomEmail is your email in Zoom (zoom_email), if it isn't the one you are invited to
+	// meetings with.
+	ZoomEmail string
+
+	// WebhookURLs are called by zoom daemon before each meeting starts, when it starts,
+	// and when it ends (webhook_urls).
+	WebhookURLs []string
+
+	// WebhookBody is a Go template for the webhook request bodies (webhook_body). If
+	// empty, a JSON description of the event and meeting is sent.
+	WebhookBody string
+
+	// Timezone is the IANA time zone to show times in (timezone), such as
+	// "Europe/Berlin". If empty, the local time zone is used.
+	Timezone string
+
+	// IncludeTitle, IncludeDomains, and IncludeColors, if set, skip events unless their
+	// title matches the regexp (include_title), they were organized by someone in one of
+	// the email domains (include_domains), and they have one of the colors, by name or ID
+	// (include_colors).
+	IncludeTitle   string
+	IncludeDomains []string
+	IncludeColors  []string
+
+	// ExcludeTitle, ExcludeDomains, and ExcludeColors skip events whose title matches the
+	// regexp (exclude_title), which were organized by someone in one of the email domains
+	// (exclude_domains), or which have one of the colors (exclude_colors).
+	ExcludeTitle   string
+	ExcludeDomains []string
+	ExcludeColors  []string
+
+	// WorkingHours are the days and hours you work (working_hours), such as
+	// "mon-fri 09:00-17:30". Events outside them are skipped. If empty, every event is considered.
+	WorkingHours []string
+
+	// Debug logs why each event was skipped or chosen, how you were authorized, and
+	// retried calendar requests to standard error (debug).
+	Debug bool
+}
+
+// DefaultSettings returns the settings used when nothing is configured.
+func DefaultSettings() Settings {
+	return Settings{PreferDeepLink: true}
+}
+
+// EnabledAccounts returns the Accounts which aren't DisabledAccounts.
+func (s Settings) EnabledAccounts() []string {
+	var enabled []string
+	for _, name := range s.Accounts {
+		disabled := false
+		for _, other := range s.DisabledAccounts {
+			disabled = disabled || other == name
+		}
+		if !disabled {
+			enabled = append(enabled, name)
+		}
+	}
+	return enabled
+}
+
+// SettingsDirectory returns $XDG_CONFIG_HOME/zoom-go, or ~/.config/zoom-go if XDG_CONFIG_HOME is unset.
+func SettingsDirectory() (string, error) {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "zoom-go"), nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(home, ".config", "zoom-go"), nil
+}
+
+// LoadSettings reads config.yaml or config.toml from the SettingsDirectory, then applies
+// overrides from ZOOM_GO_* environment variables, such as ZOOM_GO_CALENDAR_IDS. It returns
+// the default settings, with overrides, if there is no settings file.
+func LoadSettings() (Settings, error) {
+	settings := DefaultSettings()
+
+	dir, err := SettingsDirectory()
+	if err != nil {
+		return settings, err
+	}
+	for _, name := range settingsFilenames {
+		path := filepath.Join(dir, name)
+		if _, err := os.Stat(path); err != nil {
+			continue
+		}
+		if settings, err = ReadSettingsFromFile(path); err != nil {
+			return settings, err
+		}
+		break
+	}
+
+	return settings, settings.applyEnv(os.LookupEnv)
+}
+
+// ReadSettingsFromFile reads settings from a YAML or TOML file, depending on its extension.
+// Only flat files of keys with string, number, boolean, duration, and list values are supported.
+func ReadSettingsFromFile(path string) (Settings, error) {
+	fd, err := os.Open(path)
+	if err != nil {
+		return DefaultSettings(), err
+	}
+	defer fd.Close()
+
+	settings, err := ParseSettings(fd, filepath.Ext(path) == ".toml")
+	if err != nil {
+		return settings, fmt.Errorf("reading %s: %w", path, err)
+	}
+	return settings, nil
+}
+
+// ParseSettings parses settings in YAML, or in TOML if toml is true, on top of the defaults.
+func ParseSettings(r io.Reader, toml bool) (Settings, error) {
+	settings := DefaultSettings()
+	separator := ":"
+	if toml {
+		separator = "="
+	}
+
+	var listKey string
+	var listLine int
+	var list []string
+	flush := func() error {
+		if listKey == "" {
+			return nil
+		}
+		key, items := listKey, list
+		listKey, list = "", nil
+		if len(items) == 0 && !listKeys[key] {
+			// A key with nothing after it, which is only a list if items follow it.
+			if !knownKey(key) {
+				return fmt.Errorf("line %d: unknown setting %q", listLine, key)
+			}
+			return fmt.Errorf("line %d: %s has no value", listLine, key)
+		}
+		if err := settings.set(key, items); err != nil {
+			return fmt.Errorf("line %d: %w", listLine, err)
+		}
+		return nil
+	}
+
+	scanner := bufio.NewScanner(r)
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(stripComment(scanner.Text()))
+		if line == "" {
+			continue
+		}
+		if toml && strings.HasPrefix(line, "[") {
+			return settings, fmt.Errorf("line %d: sections such as %s aren't supported; settings are keys at the top level of the file", lineNumber, line)
+		}
+
+		// A YAML block list item, belonging to the key above it.
+		if !toml && strings.HasPrefix(line, "- ") && listKey != "" {
+			list = append(list, unquote(strings.TrimSpace(line[2:])))
+			continue
+		}
+		if err := flush(); err != nil {
+			return settings, err
+		}
+
+		i := strings.Index(line, separator)
+		if i < 0 {
+			return settings, fmt.Errorf("line %d: expected a key and value separated by %q", lineNumber, separator)
+		}
+		key := strings.TrimSpace(line[:i])
+		value := strings.TrimSpace(line[i+1:])
+
+		if value == "" && !toml {
+			listKey, listLine = key, lineNumber
+			continue
+		}
+		if err := settings.set(key, parseValue(value)); err != nil {
+			return settings, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+	}
+	if err := flush(); err != nil {
+		return settings, err
+	}
+	return settings, scanner.Err()
+}
+
+// applyEnv overrides settings with the ZOOM_GO_* environment variables. Lists are comma-separated.
+func (s *Settings) applyEnv(lookup func(string) (string, bool)) error {
+	for _, key := range settingKeys {
+		value, ok := lookup(envPrefix + strings.ToUpper(key))
+		if !ok {
+			continue
+		}
+
+		var parsed interface{} = value
+		if listKeys[key] {
+			parsed = splitList(value)
+		}
+		if err := s.set(key, parsed); err != nil {
+			return fmt.Errorf("%s%s: %w", envPrefix, strings.ToUpper(key), err)
+		}
+	}
+	return nil
+}
+
+// settingKeys are the keys which may appear in a settings file.
+var settingKeys = []string{"calendar_ids", "all_calendars", "horizon", "max_results", "concurrency", "providers", "prefer_deep_link", "join_rules", "notify_before", "use_reminders", "soon_before", "soon_after", "locale", "timezone", "slack_token", "accounts", "disabled_accounts", "zoom_account_id", "zoom_client_id", "zoom_client_secret", "zoom_email", "webhook_urls", "webhook_body", "include_title", "include_domains", "include_colors", "exclude_title", "exclude_domains", "exclude_colors", "working_hours", "debug"}
+
+// knownKey returns true if the key is one of the settingKeys.
+func knownKey(key string) bool {
+	for _, known := range settingKeys {
+		if key == known {
+			return true
+		}
+	}
+	return false
+}
+
+// listKeys are the settings whose values are lists. A single value is a list of one.
+var listKeys = map[string]bool{"calendar_ids": true, "providers": true, "webhook_urls": true, "accounts": true, "disabled_accounts": true, "include_domains": true, "include_colors": true, "exclude_domains": true, "exclude_colors": true, "working_hours": true, "join_rules": true}
+
+// set assigns a parsed value, either a string or a list of strings, to the setting with the key.
+func (s *Settings) set(key string, value interface{}) error {
+	text, isText := value.(string)
+	list, isList := value.([]string)
+	if isText && listKeys[key] {
+		list, isList = []string{text}, true
+	}
+
+	var err error
+	switch {
+	case key == "calendar_ids" && isList:
+		s.CalendarIDs = list
+	case key == "providers" && isList:
+		s.Providers = list
+	case key == "webhook_urls" && isList:
+		s.WebhookURLs = list
+	case key == "accounts" && isList:
+		err = validAccountNames(list)
+		s.Accounts = list
+	case key == "disabled_accounts" && isList:
+		s.DisabledAccounts = list
+	case key == "include_domains" && isList:
+		s.IncludeDomains = list
+	case key == "include_colors" && isList:
+		s.IncludeColors = list
+	case key == "exclude_domains" && isList:
+		s.ExcludeDomains = list
+	case key == "exclude_colors" && isList:
+		s.ExcludeColors = list
+	case key == "working_hours" && isList:
+		s.WorkingHours = list
+	case key == "join_rules" && isList:
+		s.JoinRules = list
+	case isList:
+		return fmt.Errorf("%s must not be a list", key)
+	case key == "all_calendars":
+		s.AllCalendars, err = strconv.ParseBool(text)
+	case key == "horizon":
+		s.Horizon, err = time.ParseDuration(text)
+	case key == "max_results":
+		s.MaxResults, err = strconv.ParseInt(text, 10, 64)
+	case key == "concurrency":
+		s.Concurrency, err = strconv.Atoi(text)
+	case key == "prefer_deep_link":
+		s.PreferDeepLink, err = strconv.ParseBool(text)
+	case key == "notify_before":
+		s.NotifyBefore, err = time.ParseDuration(text)
+	case key == "use_reminders":
+		s.UseReminders, err = strconv.ParseBool(text)
+	case key == "soon_before":
+		s.SoonBefore, err = time.ParseDuration(text)
+	case key == "soon_after":
+		s.SoonAfter, err = time.ParseDuration(text)
+	case key == "locale":
+		s.Locale = text
+	case key == "slack_token":
+		s.SlackToken = text
+	case key == "zoom_account_id":
+		s.ZoomAccountID = text
+	case key == "zoom_client_id":
+		s.ZoomClientID = text
+	case key == "zoom_client_secret":
+		s.ZoomClientSecret = text
+	case key == "zoom_email":
+		s.ZoomEmail = text
+	case key == "webhook_body":
+		s.WebhookBody = text
+	case key == "include_title":
+		s.IncludeTitle = text
+	case key == "exclude_title":
+		s.ExcludeTitle = text
+	case key == "debug":
+		s.Debug, err = strconv.ParseBool(text)
+	case key == "timezone":
+		_, err = time.LoadLocation(text)
+		s.Timezone = text
+	default:
+		return fmt.Errorf("unknown setting %q", key)
+	}
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return nil
+}
+
+// validAccountNames returns an error unless every name is letters, digits, dashes, and
+// underscores, since account names are used in file names.
+func validAccountNames(names []string) error {
+	for _, name := range names {
+		if name == "" || strings.TrimLeft(name, "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789-_") != "" {
+			return fmt.Errorf("%q must be only letters, digits, dashes, and underscores", name)
+		}
+	}
+	return nil
+}
+
+// parseValue parses an inline value: a quoted or bare string, or a [list, of, strings].
+func parseValue(value string) interface{} {
+	if strings.HasPrefix(value, "[") && strings.HasSuffix(value, "]") {
+		return splitList(value[1 : len(value)-1])
+	}
+	return unquote(value)
+}
+
+// splitList splits a comma-separated list, unquoting each item.
+func splitList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = unquote(strings.TrimSpace(item)); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// unquote removes matching single or double quotes around a value.
+func unquote(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	return value
+}
+
+// stripComment removes a # comment which is not inside quotes. As in YAML and TOML, a #
+// only starts a comment at the start of the line or after a space or tab, so values such
+// as https://example.com/#/hook are kept whole.
+func stripComment(line string) string {
+	var quote byte
+	for i := 0; i < len(line); i++ {
+		switch c := line[i]; {
+		case quote != 0:
+			if c == quote {
+				quote = 0
+			}
+		case c == '"' || c == '\'':
+			quote = c
+		case c == '#' && (i == 0 || line[i-1] == ' ' || line[i-1] == '\t'):
+			return line[:i]
+		}
+	}
+	return line
+}
@@ -0,0 +1,163 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseSettings_YAML(t *testing.T) {
+	settings, err := ParseSettings(strings.NewReader(`
+# Where to look for meetings.
+calendar_ids:
+  - parkr@jithub.com
+  - "team@jithub.com"
+horizon: 12h
+max_results: 25 # more than the default
+concurrency: 8
+providers: [zoom, "google meet"]
+prefer_deep_link: false
+notify_before: 2m
+use_reminders: true
+soon_before: 10m
+soon_after: 1m
+locale: de_DE.UTF-8
+timezone: Europe/Berlin
+slack_token: xoxp-1234
+accounts: [work, personal]
+disabled_accounts: [personal]
+zoom_account_id: abc
+zoom_client_id: def
+zoom_client_secret: ghi
+zoom_email: parkr@jithub.com
+webhook_urls: [http://light.local/flash]
+webhook_body: '{"event": "{{.Event}}"}'
+include_domains: [jithub.com]
+exclude_title: '(?i)\blunch\b'
+working_hours:
+  - mon-fri 09:00-17:30
+join_rules:
+  - zoom.us app
+  - meet.google.com browser open -na "Google Chrome" --args --profile-directory=Work
+debug: true
+`), false)
+	require.NoError(t, err)
+	assert.Equal(t, Settings{
+		CalendarIDs:      []string{"parkr@jithub.com", "team@jithub.com"},
+		Horizon:          12 * time.Hour,
+		MaxResults:       25,
+		Concurrency:      8,
+		Providers:        []string{"zoom", "google meet"},
+		NotifyBefore:     2 * time.Minute,
+		UseReminders:     true,
+		SoonBefore:       10 * time.Minute,
+		SoonAfter:        time.Minute,
+		Locale:           "de_DE.UTF-8",
+		Timezone:         "Europe/Berlin",
+		SlackToken:       "xoxp-1234",
+		Accounts:         []string{"work", "personal"},
+		DisabledAccounts: []string{"personal"},
+		ZoomAccountID:    "abc",
+		ZoomClientID:     "def",
+		ZoomClientSecret: "ghi",
+		ZoomEmail:        "parkr@jithub.com",
+		WebhookURLs:      []string{"http://light.local/flash"},
+		WebhookBody:      `{"event": "{{.Event}}"}`,
+		IncludeDomains:   []string{"jithub.com"},
+		ExcludeTitle:     `(?i)\blunch\b`,
+		WorkingHours:     []string{"mon-fri 09:00-17:30"},
+		JoinRules:        []string{"zoom.us app", `meet.google.com browser open -na "Google Chrome" --args --profile-directory=Work`},
+		Debug:            true,
+	}, settings)
+}
+
+func TestParseSettings_TOML(t *testing.T) {
+	settings, err := ParseSettings(strings.NewReader(`
+# Calendars
+all_calendars = true
+horizon = "1h30m"
+providers = ["zoom"]
+`), true)
+	require.NoError(t, err)
+	assert.Equal(t, Settings{
+		AllCalendars:   true,
+		Horizon:        90 * time.Minute,
+		Providers:      []string{"zoom"},
+		PreferDeepLink: true,
+	}, settings)
+}
+
+func TestParseSettings_Errors(t *testing.T) {
+	_, err := ParseSettings(strings.NewReader("horizon: soon\n"), false)
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "line 1: invalid horizon")
+
+	_, err = ParseSettings(strings.NewReader("timezone: Mars/Olympus_Mons\n"), false)
+	assert.Contains(t, err.Error(), "line 1: invalid timezone")
+
+	_, err = ParseSettings(strings.NewReader("accounts: [work, ../personal]\n"), false)
+	assert.EqualError(t, err, `line 1: invalid accounts: "../personal" must be only letters, digits, dashes, and underscores`)
+
+	_, err = ParseSettings(strings.NewReader("colour: blue\n"), false)
+	assert.EqualError(t, err, `line 1: unknown setting "colour"`)
+
+	_, err = ParseSettings(strings.NewReader("horizon 1h\n"), true)
+	assert.EqualError(t, err, `line 1: expected a key and value separated by "="`)
+
+	_, err = ParseSettings(strings.NewReader("horizon = \"1h\"\n[slack]\nwebhook_urls = \"https://hooks.slack.com/1\"\n"), true)
+	assert.EqualError(t, err, `line 2: sections such as [slack] aren't supported; settings are keys at the top level of the file`)
+
+	_, err = ParseSettings(strings.NewReader("locale:\nhorizon: 1h\n"), false)
+	assert.EqualError(t, err, `line 1: locale has no value`)
+
+	_, err = ParseSettings(strings.NewReader("horizon: 1h\ncolour:\n"), false)
+	assert.EqualError(t, err, `line 2: unknown setting "colour"`)
+
+	_, err = ParseSettings(strings.NewReader("horizon:\n  - 1h\n"), false)
+	assert.EqualError(t, err, `line 1: horizon must not be a list`)
+}
+
+func TestParseSettings_Hash(t *testing.T) {
+	settings, err := ParseSettings(strings.NewReader("webhook_urls: https://example.com/#/hook # the desk light\nwebhook_body: '{\"text\": \"# {{.Meeting.Title}}\"}'\n"), false)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/#/hook"}, settings.WebhookURLs, "a # only starts a comment after a space")
+	assert.Equal(t, `{"text": "# {{.Meeting.Title}}"}`, settings.WebhookBody)
+
+	settings, err = ParseSettings(strings.NewReader("webhook_urls = [\"https://example.com/#/hook\"]\t# the desk light\nlocale = de#comment\n"), true)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"https://example.com/#/hook"}, settings.WebhookURLs)
+	assert.Equal(t, "de#comment", settings.Locale)
+}
+
+func TestLoadSettings(t *testing.T) {
+	dir := t.TempDir()
+	t.Setenv("XDG_CONFIG_HOME", dir)
+	t.Setenv("ZOOM_GO_CALENDAR_IDS", "parkr@jithub.com, team@jithub.com")
+
+	settings, err := LoadSettings()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"parkr@jithub.com", "team@jithub.com"}, settings.CalendarIDs)
+	assert.True(t, settings.PreferDeepLink)
+
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "zoom-go"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "zoom-go", "config.toml"), []byte("horizon = \"8h\"\nnotify_before = \"10m\"\n"), 0600))
+	t.Setenv("ZOOM_GO_NOTIFY_BEFORE", "1m")
+	t.Setenv("ZOOM_GO_ZOOM_CLIENT_SECRET", "secret")
+
+	settings, err = LoadSettings()
+	require.NoError(t, err)
+	assert.Equal(t, 8*time.Hour, settings.Horizon)
+	assert.Equal(t, time.Minute, settings.NotifyBefore, "the environment overrides the file")
+	assert.Equal(t, "secret", settings.ZoomClientSecret)
+}
+
+func TestSettingsEnabledAccounts(t *testing.T) {
+	settings := Settings{Accounts: []string{"work", "personal", "side"}, DisabledAccounts: []string{"personal"}}
+	assert.Equal(t, []string{"work", "side"}, settings.EnabledAccounts())
+	assert.Empty(t, Settings{}.EnabledAccounts())
+}
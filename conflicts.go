@@ -0,0 +1,90 @@
+package zoom
+
+import (
+	"sort"
+	"strings"
+	"time"
+)
+
+// Conflicts returns the groups of meetings which overlap, such as when you are
+// double-booked. Each group is sorted by start time and contains every meeting which
+// overlaps another in the group. Meetings without both a start and an end never conflict.
+func Conflicts(meetings []Meeting) [][]Meeting {
+	sorted := append([]Meeting(nil), meetings...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	var conflicts [][]Meeting
+	for _, group := range conflictGroups(sorted) {
+		conflict := make([]Meeting, 0, len(group))
+		for _, i := range group {
+			conflict = append(conflict, sorted[i])
+		}
+		conflicts = append(conflicts, conflict)
+	}
+	return conflicts
+}
+
+// ConflictSummary describes the meetings which overlap the meeting, such as
+// "⚠ overlaps with 'Design review'". It is empty if none of the meetings overlap it.
+func ConflictSummary(meeting Meeting, meetings []Meeting) string {
+	var titles []string
+	for _, other := range meetings {
+		if other.ID == meeting.ID && other.Start.Equal(meeting.Start) {
+			continue
+		}
+		if meeting.overlaps(other) {
+			titles = append(titles, "'"+other.Title+"'")
+		}
+	}
+
+	switch len(titles) {
+	case 0:
+		return ""
+	case 1:
+		return "⚠ overlaps with " + titles[0]
+	}
+	return "⚠ overlaps with " + strings.Join(titles[:len(titles)-1], ", ") + " and " + titles[len(titles)-1]
+}
+
+// conflictGroups returns the indexes of each group of overlapping meetings. The meetings
+// must be sorted by start time.
+func conflictGroups(meetings []Meeting) [][]int {
+	var groups [][]int
+	var group []int
+	var groupEnd time.Time
+	for i, meeting := range meetings {
+		if !isScheduled(meeting) {
+			continue
+		}
+		if len(group) > 0 && meeting.Start.Before(groupEnd) {
+			group = append(group, i)
+		} else {
+			if len(group) > 1 {
+				groups = append(groups, group)
+			}
+			group = []int{i}
+			groupEnd = meeting.End
+		}
+		if meeting.End.After(groupEnd) {
+			groupEnd = meeting.End
+		}
+	}
+	if len(group) > 1 {
+		groups = append(groups, group)
+	}
+	return groups
+}
+
+// overlaps returns true if the meetings are both scheduled and share some time.
+func (m Meeting) overlaps(other Meeting) bool {
+	if !isScheduled(m) || !isScheduled(other) {
+		return false
+	}
+	return m.Start.Before(other.End) && other.Start.Before(m.End)
+}
+
+// isScheduled returns true if the meeting has both a start and an end. All-day events
+// aren't scheduled at a time of day, so they never conflict with meetings.
+func isScheduled(meeting Meeting) bool {
+	return !meeting.AllDay && !meeting.Start.IsZero() && !meeting.End.IsZero()
+}
@@ -0,0 +1,36 @@
+package zoom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConflicts(t *testing.T) {
+	at := func(hour, minute int) time.Time { return time.Date(2018, 10, 10, hour, minute, 0, 0, time.UTC) }
+	standup := Meeting{ID: "standup", Title: "Standup", Start: at(9, 0), End: at(9, 15)}
+	review := Meeting{ID: "review", Title: "Design review", Start: at(10, 0), End: at(12, 0)}
+	oneOnOne := Meeting{ID: "1:1", Title: "1:1", Start: at(10, 30), End: at(11, 0)}
+	lunch := Meeting{ID: "lunch", Title: "Lunch", Start: at(11, 30), End: at(12, 30)}
+	backToBack := Meeting{ID: "retro", Title: "Retro", Start: at(12, 30), End: at(13, 0)}
+	unscheduled := Meeting{ID: "offsite", Title: "Offsite", Start: at(10, 0)}
+
+	conflicts := Conflicts([]Meeting{lunch, backToBack, standup, unscheduled, oneOnOne, review})
+	assert.Equal(t, [][]Meeting{{review, oneOnOne, lunch}}, conflicts)
+
+	assert.Empty(t, Conflicts([]Meeting{standup, review}))
+	assert.Empty(t, Conflicts(nil))
+}
+
+func TestConflictSummary(t *testing.T) {
+	at := func(hour, minute int) time.Time { return time.Date(2018, 10, 10, hour, minute, 0, 0, time.UTC) }
+	review := Meeting{ID: "review", Title: "Design review", Start: at(10, 0), End: at(12, 0)}
+	oneOnOne := Meeting{ID: "1:1", Title: "1:1", Start: at(10, 30), End: at(11, 0)}
+	lunch := Meeting{ID: "lunch", Title: "Lunch", Start: at(11, 30), End: at(12, 30)}
+	meetings := []Meeting{review, oneOnOne, lunch}
+
+	assert.Equal(t, "⚠ overlaps with '1:1' and 'Lunch'", ConflictSummary(review, meetings))
+	assert.Equal(t, "⚠ overlaps with 'Design review'", ConflictSummary(lunch, meetings))
+	assert.Equal(t, "", ConflictSummary(Meeting{Title: "Retro", Start: at(12, 30), End: at(13, 0)}, meetings))
+}
@@ -0,0 +1,66 @@
+package zoom
+
+import (
+	"context"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// TimeUntilStart returns how long until the calendar event starts. It is negative if the
+// event has already started.
+func TimeUntilStart(event *calendar.Event) (time.Duration, error) {
+	startTime, err := MeetingStartTime(event)
+	if err != nil {
+		return 0, err
+	}
+	return time.Until(startTime), nil
+}
+
+// Countdown sends a description of when the event starts, such as "starts in 4m32s" or
+// "started 1m5s ago", right away and then on every tick, until the context is done. The
+// channel is closed when the context is done, or immediately if the event has no start time.
+func Countdown(ctx context.Context, event *calendar.Event, tick time.Duration) <-chan string {
+	ch := make(chan string)
+
+	startTime, err := MeetingStartTime(event)
+	if err != nil {
+		close(ch)
+		return ch
+	}
+
+	go func() {
+		defer close(ch)
+
+		ticker := time.NewTicker(tick)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case ch <- FormatCountdown(startTime.Sub(time.Now())):
+			case <-ctx.Done():
+				return
+			}
+
+			select {
+			case <-ticker.C:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+	return ch
+}
+
+// FormatCountdown describes how long until a meeting starts, given by until, such as
+// "starts in 4m32s", "starts now", or "started 1m5s ago".
+func FormatCountdown(until time.Duration) string {
+	until = until.Round(time.Second)
+	switch {
+	case until > 0:
+		return "starts in " + until.String()
+	case until < 0:
+		return "started " + (-until).String() + " ago"
+	}
+	return "starts now"
+}
@@ -0,0 +1,51 @@
+package zoom
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestTimeUntilStart(t *testing.T) {
+	event := &calendar.Event{Start: &calendar.EventDateTime{
+		DateTime: time.Now().Add(10*time.Minute + 30*time.Second).Format(googleCalendarDateTimeFormat),
+	}}
+
+	until, err := TimeUntilStart(event)
+	require.NoError(t, err)
+	assert.True(t, until > 10*time.Minute && until <= 10*time.Minute+30*time.Second, "until was %s", until)
+
+	_, err = TimeUntilStart(&calendar.Event{})
+	assert.Error(t, err)
+}
+
+func TestCountdown(t *testing.T) {
+	event := &calendar.Event{Start: &calendar.EventDateTime{
+		DateTime: time.Now().Add(time.Hour + 30*time.Second).Format(googleCalendarDateTimeFormat),
+	}}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := Countdown(ctx, event, time.Millisecond)
+
+	first := <-ch
+	assert.Regexp(t, `^starts in 1h0m\d+s$`, first)
+	assert.Regexp(t, `^starts in 1h0m\d+s$`, <-ch)
+
+	cancel()
+	for range ch {
+		// Drain until the channel is closed.
+	}
+
+	_, ok := <-Countdown(context.Background(), &calendar.Event{}, time.Millisecond)
+	assert.False(t, ok, "the channel is closed if the event has no start time")
+}
+
+func TestFormatCountdown(t *testing.T) {
+	assert.Equal(t, "starts in 4m32s", FormatCountdown(4*time.Minute+32*time.Second+100*time.Millisecond))
+	assert.Equal(t, "started 1m5s ago", FormatCountdown(-65*time.Second))
+	assert.Equal(t, "starts now", FormatCountdown(200*time.Millisecond))
+}
@@ -0,0 +1,117 @@
+package zoom
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// MeetingDetails bundles everything needed to display and join a meeting.
+type MeetingDetails struct {
+	// Event is the underlying calendar event.
+	Event *calendar.Event
+
+	// WebURL is the HTTPS meeting URL as it appears in the event.
+	WebURL *url.URL
+
+	// DeepLink is the native zoommtg:// URL, if the meeting ID could be determined.
+	DeepLink *url.URL
+
+	// Passcode is the meeting passcode, if one was found.
+	Passcode string
+
+	// DialIns are the telephone entry points listed for the meeting.
+	DialIns []DialIn
+
+	// AttendeeSummary is a short description of who is attending, e.g. "3 attendees, 2 accepted".
+	AttendeeSummary string
+
+	// HumanizedStart is the human-friendly start time, e.g. "5 minutes from now".
+	HumanizedStart string
+
+	// InProgress is true if the meeting has started but not yet ended.
+	InProgress bool
+}
+
+// DialIn is a telephone number which can be used to join a meeting.
+type DialIn struct {
+	// Number is the phone number, e.g. "+1 646-558-8656".
+	Number string
+
+	// Label is the description of the number provided by the calendar, if any.
+	Label string
+
+	// RegionCode is the two-letter country code the number is for, if known.
+	RegionCode string
+
+	// CountryCode is the number's country calling code, e.g. "1", if known.
+	CountryCode string
+
+	// AccessCode is the code to enter once connected, such as the meeting ID, if any.
+	AccessCode string
+
+	// Passcode is the numeric passcode to enter after the access code, if any.
+	Passcode string
+}
+
+// NextMeetingDetails fetches the next event and resolves all of its join information.
+// It returns nil with no error if there is no upcoming event.
+func NextMeetingDetails(service *calendar.Service) (*MeetingDetails, error) {
+	return NextMeetingDetailsContext(context.Background(), service, Options{})
+}
+
+// NextMeetingDetailsContext is like NextMeetingDetails, but selects the event using the
+// options and binds the calendar API call to the context.
+func NextMeetingDetailsContext(ctx context.Context, service *calendar.Service, opts Options) (*MeetingDetails, error) {
+	event, err := NextEventContext(ctx, service, opts)
+	if err != nil {
+		return nil, err
+	}
+	if event == nil {
+		return nil, nil
+	}
+	return NewMeetingDetails(event), nil
+}
+
+// NewMeetingDetails resolves the join information for the given event.
+func NewMeetingDetails(event *calendar.Event) *MeetingDetails {
+	details := &MeetingDetails{
+		Event:           event,
+		DialIns:         DialInsFromEvent(event),
+		AttendeeSummary: summarizeAttendees(attendeesFromEvent(event)),
+		HumanizedStart:  HumanizedStartTime(event),
+		InProgress:      isMeetingInProgress(event),
+	}
+
+	if webURL, deepLink, ok := meetingURLsFromEvent(event); ok {
+		details.WebURL = webURL
+		details.DeepLink = deepLink
+	}
+	details.Passcode = meetingPasscode(event, details.WebURL)
+
+	return details
+}
+
+// isMeetingInProgress returns true if the event has started and has not yet ended.
+func isMeetingInProgress(event *calendar.Event) bool {
+	startTime, err := MeetingStartTime(event)
+	if err != nil || time.Now().Before(startTime) {
+		return false
+	}
+	endTime, err := MeetingEndTime(event)
+	if err != nil {
+		return false
+	}
+	return time.Now().Before(endTime)
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, value := range values {
+		if value != "" {
+			return value
+		}
+	}
+	return ""
+}
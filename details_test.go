@@ -0,0 +1,76 @@
+package zoom
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestNextMeetingDetails(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testEventResponse)
+	})
+
+	details, err := NextMeetingDetails(service)
+	require.NoError(t, err)
+	require.NotNil(t, details)
+	assert.Equal(t, "I am a video call", details.Event.Summary)
+	assert.Equal(t, "https://jithub.zoom.us/j/12345", details.WebURL.String())
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=12345", details.DeepLink.String())
+	assert.False(t, details.InProgress)
+}
+
+func TestNextMeetingDetails_NoUpcomingEvents(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items":[]}`)
+	})
+
+	details, err := NextMeetingDetails(service)
+	require.NoError(t, err)
+	assert.Nil(t, details)
+}
+
+func TestNewMeetingDetails(t *testing.T) {
+	details := NewMeetingDetails(&calendar.Event{
+		Location: "https://jithub.zoom.us/j/12345?pwd=abc123",
+		Start: &calendar.EventDateTime{
+			DateTime: time.Now().Add(-10 * time.Minute).Format(googleCalendarDateTimeFormat),
+		},
+		End: &calendar.EventDateTime{
+			DateTime: time.Now().Add(20 * time.Minute).Format(googleCalendarDateTimeFormat),
+		},
+		Attendees: []*calendar.EventAttendee{
+			{Email: "parkr@jithub.com", ResponseStatus: "accepted"},
+			{Email: "kevin@jithub.com", ResponseStatus: "needsAction"},
+		},
+		ConferenceData: &calendar.ConferenceData{
+			EntryPoints: []*calendar.EntryPoint{
+				{EntryPointType: "video", Uri: "https://jithub.zoom.us/j/12345?pwd=abc123"},
+				{EntryPointType: "phone", Uri: "tel:+1-646-558-8656", RegionCode: "US", AccessCode: "12345"},
+			},
+		},
+	})
+
+	assert.Equal(t, "https://jithub.zoom.us/j/12345?pwd=abc123", details.WebURL.String())
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=12345&pwd=abc123", details.DeepLink.String())
+	assert.Equal(t, "abc123", details.Passcode)
+	assert.Equal(t, []DialIn{{Number: "+1-646-558-8656", RegionCode: "US", CountryCode: "1", AccessCode: "12345"}}, details.DialIns)
+	assert.Equal(t, "2 attendees, 1 accepted", details.AttendeeSummary)
+	assert.Equal(t, "10 minutes ago", details.HumanizedStart)
+	assert.True(t, details.InProgress)
+}
@@ -0,0 +1,191 @@
+package zoom
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+var (
+	// phoneNumberRegexp matches international phone numbers in meeting invitations, along
+	// with a region code before or after them, e.g. "(US) +1 555-123-4567" or
+	// "+1 646 558 8656 US (New York)", and Zoom's one-tap access and passcodes, e.g.
+	// "+16465588656,,12345678901#,,,,*123456#".
+	phoneNumberRegexp = regexp.MustCompile(`(?:\(([A-Z]{2})\)\s*)?(\+\d[\d .-]{5,18}\d)(?:,,(\d+)#)?(?:,+\*(\d+)#)?(?:[ \t]+([A-Z]{2})\b)?`)
+
+	// pinRegexp matches an access code written after a phone number, e.g. "PIN: 123 456 789#".
+	pinRegexp = regexp.MustCompile(`^\s*PIN:?\s*(\d[\d ]*\d)#?`)
+
+	// meetingIDRegexp matches the meeting ID written out in invitations, e.g. "Meeting ID: 123 4567 8901".
+	meetingIDRegexp = regexp.MustCompile(`(?i)\bmeeting ID\s*:\s*(\d[\d ]*\d)`)
+
+	// numericPasscodeRegexp matches a passcode which can be entered on a phone, e.g. "Passcode: 123456".
+	numericPasscodeRegexp = regexp.MustCompile(`(?i)\b(?:passcode|password)\s*:\s*(\d+)\b`)
+)
+
+// DialInsFromEvent returns the telephone numbers which can be used to join the event,
+// from its conference data and then its description, such as a Zoom invitation's "One
+// tap mobile" and "Dial by your location" numbers.
+func DialInsFromEvent(event *calendar.Event) []DialIn {
+	if event == nil {
+		return nil
+	}
+
+	dialIns := dialInsFromConferenceData(event)
+	seen := map[string]bool{}
+	for _, dialIn := range dialIns {
+		seen[digits(dialIn.Number)] = true
+	}
+	for _, dialIn := range dialInsFromDescription(event.Description) {
+		if !seen[digits(dialIn.Number)] {
+			seen[digits(dialIn.Number)] = true
+			dialIns = append(dialIns, dialIn)
+		}
+	}
+	return dialIns
+}
+
+// TelURL returns a tel: URL which dials the number, then pauses and enters the access
+// code and passcode, if any, e.g. "tel:+16465588656,,12345678901%23,,,,*123456%23".
+func (d DialIn) TelURL() *url.URL {
+	oneTap := d.OneTap()
+	if oneTap == "" {
+		return nil
+	}
+	return &url.URL{Scheme: "tel", Opaque: strings.ReplaceAll(oneTap, "#", "%23")}
+}
+
+// OneTap returns the number with the access code and passcode, if any, in the form a
+// phone dials in one go, e.g. "+16465588656,,12345678901#,,,,*123456#". It is empty if
+// the number has no digits.
+func (d DialIn) OneTap() string {
+	number := digits(d.Number)
+	if number == "" {
+		return ""
+	}
+
+	oneTap := "+" + number
+	if accessCode := digits(d.AccessCode); accessCode != "" {
+		oneTap += ",," + accessCode + "#"
+	}
+	if passcode := digits(d.Passcode); passcode != "" {
+		oneTap += ",,,,*" + passcode + "#"
+	}
+	return oneTap
+}
+
+// dialInsFromConferenceData returns the phone entry points in the event's conference data.
+func dialInsFromConferenceData(event *calendar.Event) []DialIn {
+	if event.ConferenceData == nil {
+		return nil
+	}
+
+	var dialIns []DialIn
+	for _, entryPoint := range event.ConferenceData.EntryPoints {
+		if entryPoint == nil || entryPoint.EntryPointType != "phone" {
+			continue
+		}
+		uriNumber := strings.TrimPrefix(entryPoint.Uri, "tel:")
+		dialIn := DialIn{
+			Number:      firstNonEmpty(entryPoint.Label, uriNumber),
+			Label:       entryPoint.Label,
+			RegionCode:  entryPoint.RegionCode,
+			CountryCode: countryCode(uriNumber),
+			AccessCode:  firstNonEmpty(entryPoint.AccessCode, entryPoint.Pin, entryPoint.Passcode),
+		}
+		if entryPoint.Passcode != dialIn.AccessCode {
+			dialIn.Passcode = entryPoint.Passcode
+		}
+		dialIns = append(dialIns, dialIn)
+	}
+	return dialIns
+}
+
+// dialInsFromDescription returns the phone numbers written out in the event's description.
+// Numbers without their own access code use the meeting ID and numeric passcode written
+// elsewhere in the description.
+func dialInsFromDescription(description string) []DialIn {
+	// Every phone number starts with a "+", and most descriptions have none.
+	if strings.IndexByte(description, '+') < 0 {
+		return nil
+	}
+	var meetingID, passcode string
+	if matches := meetingIDRegexp.FindStringSubmatch(description); matches != nil {
+		meetingID = digits(matches[1])
+	}
+	if matches := numericPasscodeRegexp.FindStringSubmatch(description); matches != nil {
+		passcode = matches[1]
+	}
+
+	var dialIns []DialIn
+	for _, match := range phoneNumberRegexp.FindAllStringSubmatchIndex(description, -1) {
+		group := func(i int) string {
+			if match[2*i] < 0 {
+				return ""
+			}
+			return description[match[2*i]:match[2*i+1]]
+		}
+
+		dialIn := DialIn{
+			Number:      strings.TrimSpace(group(2)),
+			RegionCode:  firstNonEmpty(group(1), group(5)),
+			CountryCode: countryCode(group(2)),
+			AccessCode:  group(3),
+			Passcode:    group(4),
+		}
+		if dialIn.AccessCode == "" {
+			if pin := pinRegexp.FindStringSubmatch(description[match[1]:]); pin != nil {
+				dialIn.AccessCode = digits(pin[1])
+			} else {
+				dialIn.AccessCode, dialIn.Passcode = meetingID, passcode
+			}
+		}
+		dialIns = append(dialIns, dialIn)
+	}
+	return dialIns
+}
+
+// digits returns only the digits in the string.
+func digits(s string) string {
+	return strings.Map(func(r rune) rune {
+		if r >= '0' && r <= '9' {
+			return r
+		}
+		return -1
+	}, s)
+}
+
+// twoDigitCallingCodes are the country calling codes with two digits. Calling codes are
+// prefix-free: "1" and "7" have one digit, these have two, and all others have three.
+var twoDigitCallingCodes = strings.Fields(`20 27 30 31 32 33 34 36 39 40 41 43 44 45 46 47 48 49
+	51 52 53 54 55 56 57 58 60 61 62 63 64 65 66 81 82 84 86 90 91 92 93 94 95 98`)
+
+// countryCode returns the country calling code of an international phone number, e.g.
+// "1" for "+1 646-558-8656" or "44" for "+44 20 3481 5240". It is empty if the number
+// doesn't start with "+".
+func countryCode(number string) string {
+	number = strings.TrimSpace(number)
+	if !strings.HasPrefix(number, "+") {
+		return ""
+	}
+	return callingCode(digits(number))
+}
+
+// callingCode returns the country calling code at the start of an international phone
+// number's digits.
+func callingCode(number string) string {
+	switch {
+	case len(number) < 4:
+		return ""
+	case number[0] == '1' || number[0] == '7':
+		return number[:1]
+	}
+	for _, code := range twoDigitCallingCodes {
+		if strings.HasPrefix(number, code) {
+			return code
+		}
+	}
+	return number[:3]
+}
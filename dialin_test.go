@@ -0,0 +1,88 @@
+package zoom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+const zoomInvitation = `Parker Moore is inviting you to a scheduled Zoom meeting.
+
+Join Zoom Meeting
+https://jithub.zoom.us/j/12345678901?pwd=abc123
+
+Meeting ID: 123 4567 8901
+Passcode: 654321
+One tap mobile
++16465588656,,12345678901#,,,,*654321# US (New York)
++16699009128,,12345678901#,,,,*654321# US (San Jose)
+
+Dial by your location
+        +1 646 558 8656 US (New York)
+        +44 203 481 5240 GB
+Meeting ID: 123 4567 8901
+Passcode: 654321`
+
+func TestDialInsFromEvent_ZoomInvitation(t *testing.T) {
+	dialIns := DialInsFromEvent(&calendar.Event{Description: zoomInvitation})
+
+	assert.Equal(t, []DialIn{
+		{Number: "+16465588656", RegionCode: "US", CountryCode: "1", AccessCode: "12345678901", Passcode: "654321"},
+		{Number: "+16699009128", RegionCode: "US", CountryCode: "1", AccessCode: "12345678901", Passcode: "654321"},
+		{Number: "+44 203 481 5240", RegionCode: "GB", CountryCode: "44", AccessCode: "12345678901", Passcode: "654321"},
+	}, dialIns)
+}
+
+func TestDialInsFromEvent_MeetInvitation(t *testing.T) {
+	dialIns := DialInsFromEvent(&calendar.Event{
+		Description: "Join by phone\n(US) +1 555-123-4567 PIN: 123 456 789#\n\nMore phone numbers: https://tel.meet/abc",
+	})
+
+	assert.Equal(t, []DialIn{
+		{Number: "+1 555-123-4567", RegionCode: "US", CountryCode: "1", AccessCode: "123456789"},
+	}, dialIns)
+}
+
+func TestDialInsFromEvent_ConferenceDataFirst(t *testing.T) {
+	dialIns := DialInsFromEvent(&calendar.Event{
+		Description: "+1 646 558 8656 US\n+49 69 7104 9922 DE",
+		ConferenceData: &calendar.ConferenceData{
+			EntryPoints: []*calendar.EntryPoint{
+				{EntryPointType: "video", Uri: "https://jithub.zoom.us/j/12345"},
+				{EntryPointType: "phone", Uri: "tel:+1-646-558-8656", Label: "+1 646-558-8656", RegionCode: "US", AccessCode: "12345", Passcode: "999"},
+			},
+		},
+	})
+
+	assert.Equal(t, []DialIn{
+		{Number: "+1 646-558-8656", Label: "+1 646-558-8656", RegionCode: "US", CountryCode: "1", AccessCode: "12345", Passcode: "999"},
+		{Number: "+49 69 7104 9922", RegionCode: "DE", CountryCode: "49"},
+	}, dialIns)
+
+	assert.Nil(t, DialInsFromEvent(nil))
+	assert.Empty(t, DialInsFromEvent(&calendar.Event{Description: "Call me at 555-1234."}))
+}
+
+func TestDialIn_TelURL(t *testing.T) {
+	dialIn := DialIn{Number: "+1 646-558-8656", AccessCode: "123 4567 8901", Passcode: "654321"}
+	assert.Equal(t, "tel:+16465588656,,12345678901%23,,,,*654321%23", dialIn.TelURL().String())
+
+	assert.Equal(t, "tel:+496971049922", DialIn{Number: "+49 69 7104 9922"}.TelURL().String())
+	assert.Nil(t, DialIn{}.TelURL())
+}
+
+func TestDialIn_OneTap(t *testing.T) {
+	dialIn := DialIn{Number: "+1 646-558-8656", AccessCode: "123 4567 8901", Passcode: "654321"}
+	assert.Equal(t, "+16465588656,,12345678901#,,,,*654321#", dialIn.OneTap())
+	assert.Equal(t, "+496971049922", DialIn{Number: "+49 69 7104 9922"}.OneTap())
+	assert.Equal(t, "", DialIn{}.OneTap())
+}
+
+func TestCountryCode(t *testing.T) {
+	assert.Equal(t, "1", countryCode("+1 646 558 8656"))
+	assert.Equal(t, "7", countryCode("+7 495 123-45-67"))
+	assert.Equal(t, "44", countryCode("+44 20 3481 5240"))
+	assert.Equal(t, "353", countryCode("+353 1 653 3895"))
+	assert.Equal(t, "", countryCode("646-558-8656"))
+}
@@ -0,0 +1,80 @@
+package zoom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"google.golang.org/api/googleapi"
+)
+
+var (
+	// ErrNoUpcomingEvents indicates that nothing is scheduled in the window searched.
+	ErrNoUpcomingEvents = errors.New("no upcoming events")
+
+	// ErrNoFreeSlot indicates that there is no gap long enough in the span searched.
+	ErrNoFreeSlot = errors.New("no free slot")
+
+	// ErrNoMeetingURL indicates that a meeting has no URL which can be used to join it.
+	ErrNoMeetingURL = errors.New("meeting does not have a join URL")
+
+	// ErrNoDialIn indicates that a meeting has no phone number which can be used to join it.
+	ErrNoDialIn = errors.New("meeting does not have a dial-in number")
+
+	// ErrNoClipboard indicates that no command to copy to the clipboard is installed, such
+	// as xclip or wl-copy on Linux.
+	ErrNoClipboard = errors.New("no clipboard command found")
+
+	// ErrAuthExpired is wrapped by calendar errors which mean your authorization has
+	// expired or was revoked, so you need to authorize access again.
+	ErrAuthExpired = errors.New("calendar authorization expired")
+
+	// ErrCalendarUnavailable is wrapped by calendar errors which mean the calendar
+	// couldn't be reached, or is rate limited or temporarily failing, so trying again
+	// later may succeed.
+	ErrCalendarUnavailable = errors.New("calendar unavailable")
+)
+
+// calendarError wraps an error from the Calendar API with ErrAuthExpired or
+// ErrCalendarUnavailable, if either describes it.
+func calendarError(err error) error {
+	switch {
+	case errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded):
+		return err
+	case isAuthExpired(err):
+		return &kindError{kind: ErrAuthExpired, err: err}
+	case isUnreachable(err):
+		return &kindError{kind: ErrCalendarUnavailable, err: err}
+	}
+	return err
+}
+
+// kindError is an error which is also one of the errors above, such as ErrAuthExpired,
+// according to errors.Is, while still wrapping the original error for errors.As.
+type kindError struct {
+	kind error
+	err  error
+}
+
+func (e *kindError) Error() string {
+	return e.kind.Error() + ": " + e.err.Error()
+}
+
+func (e *kindError) Unwrap() error {
+	return e.err
+}
+
+func (e *kindError) Is(target error) bool {
+	return target == e.kind
+}
+
+// isAuthExpired returns true if the error means the token was rejected, or couldn't be refreshed.
+func isAuthExpired(err error) bool {
+	var retrieveErr *oauth2.RetrieveError
+	if errors.As(err, &retrieveErr) {
+		return true
+	}
+	var apiErr *googleapi.Error
+	return errors.As(err, &apiErr) && apiErr.Code == http.StatusUnauthorized
+}
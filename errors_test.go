@@ -0,0 +1,65 @@
+package zoom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/oauth2"
+	"google.golang.org/api/googleapi"
+)
+
+func TestNextEventWithOptions_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	status := http.StatusUnauthorized
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+	})
+	opts := Options{Retry: RetryPolicy{Attempts: 1}}
+
+	_, err := NextEventWithOptions(service, opts)
+	assert.True(t, errors.Is(err, ErrAuthExpired))
+	assert.False(t, errors.Is(err, ErrCalendarUnavailable))
+	var apiErr *googleapi.Error
+	assert.True(t, errors.As(err, &apiErr), "the API's error is still available")
+
+	status = http.StatusServiceUnavailable
+	_, err = NextEventWithOptions(service, opts)
+	assert.True(t, errors.Is(err, ErrCalendarUnavailable))
+	assert.False(t, errors.Is(err, ErrAuthExpired))
+
+	status = http.StatusNotFound
+	_, err = NextEventWithOptions(service, opts)
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrCalendarUnavailable))
+	assert.False(t, errors.Is(err, ErrAuthExpired))
+}
+
+func TestCalendarError(t *testing.T) {
+	refreshErr := &url.Error{Op: "Get", URL: "https://www.googleapis.com/calendar/v3", Err: &oauth2.RetrieveError{
+		Response: &http.Response{Status: "400 Bad Request"},
+		Body:     []byte(`{"error": "invalid_grant"}`),
+	}}
+	assert.True(t, errors.Is(calendarError(refreshErr), ErrAuthExpired))
+	assert.False(t, isUnreachable(refreshErr), "expired tokens aren't answered from the store")
+
+	unreachable := calendarError(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("no such host")})
+	assert.True(t, errors.Is(unreachable, ErrCalendarUnavailable))
+	assert.False(t, errors.Is(unreachable, ErrAuthExpired))
+	assert.EqualError(t, unreachable, "calendar unavailable: dial tcp: no such host")
+	var opErr *net.OpError
+	assert.True(t, errors.As(unreachable, &opErr), "the original error is still available")
+	assert.False(t, errors.Is(calendarError(errors.New("unexpected end of JSON input")), ErrCalendarUnavailable))
+
+	canceled := fmt.Errorf("listing events: %w", context.Canceled)
+	assert.Equal(t, canceled, calendarError(canceled))
+}
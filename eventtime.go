@@ -0,0 +1,110 @@
+package zoom
+
+import (
+	"errors"
+	"sync"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// timeZones caches the locations of the IANA time zones events have been in, since
+// loading one reads the time zone database.
+var timeZones sync.Map
+
+// ParseEventDateTime returns the time of a calendar event's start or end, and whether it
+// is a date, as all-day events have, rather than a time. Times are in the date-time's
+// time zone, if it has one, which is also the zone of times without a UTC offset. Dates
+// are midnight at the start of the day, in the date-time's time zone if it has one, and
+// otherwise in Location.
+func ParseEventDateTime(dateTime *calendar.EventDateTime) (t time.Time, allDay bool, err error) {
+	if dateTime == nil {
+		return time.Time{}, false, errors.New("missing date-time")
+	}
+	zone, hasZone := eventTimeZone(dateTime.TimeZone)
+
+	switch {
+	case dateTime.DateTime != "":
+		t, err = time.Parse(googleCalendarDateTimeFormat, dateTime.DateTime)
+		if err != nil {
+			if !hasZone {
+				return time.Time{}, false, err
+			}
+			if t, err = time.ParseInLocation(localDateTimeFormat, dateTime.DateTime, zone); err != nil {
+				return time.Time{}, false, err
+			}
+		}
+		if hasZone {
+			t = t.In(zone)
+		}
+		return t, false, nil
+	case dateTime.Date != "":
+		t, err = time.ParseInLocation(googleCalendarDateFormat, dateTime.Date, zone)
+		return t, err == nil, err
+	}
+	return time.Time{}, false, errors.New("date-time has neither a date nor a time")
+}
+
+// parseEventDateTime is like ParseEventDateTime, for when whether it is a date doesn't matter.
+func parseEventDateTime(dateTime *calendar.EventDateTime) (time.Time, error) {
+	t, _, err := ParseEventDateTime(dateTime)
+	return t, err
+}
+
+// eventTimeZone returns the location of the IANA time zone, and true, or Location, or the
+// local time zone if that is nil, and false if the zone is empty or unknown.
+func eventTimeZone(name string) (*time.Location, bool) {
+	if name != "" {
+		if zone, ok := timeZones.Load(name); ok {
+			return zone.(*time.Location), true
+		}
+		if zone, err := time.LoadLocation(name); err == nil {
+			timeZones.Store(name, zone)
+			return zone, true
+		}
+	}
+	return displayLocation(nil), false
+}
+
+// displayLocation returns the location, or Location if it is nil, or the local time zone
+// if that is nil too.
+func displayLocation(loc *time.Location) *time.Location {
+	switch {
+	case loc != nil:
+		return loc
+	case Location != nil:
+		return Location
+	}
+	return time.Local
+}
+
+// MeetingStartTimeIn is like MeetingStartTime, but returns the start time in the location,
+// or in Location if it is nil, to show it. All-day events start at midnight on their first
+// day in the location, rather than at the same instant, since a day off is the same day
+// wherever you are.
+func MeetingStartTimeIn(event *calendar.Event, loc *time.Location) (time.Time, error) {
+	start, err := MeetingStartTime(event)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return timeIn(start, isAllDay(event), loc), nil
+}
+
+// MeetingEndTimeIn is like MeetingStartTimeIn, but returns the end time.
+func MeetingEndTimeIn(event *calendar.Event, loc *time.Location) (time.Time, error) {
+	end, err := MeetingEndTime(event)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return timeIn(end, event.End.DateTime == "", loc), nil
+}
+
+// timeIn returns the time in the location, or in Location if it is nil. Dates keep their
+// day, at midnight in the location.
+func timeIn(t time.Time, date bool, loc *time.Location) time.Time {
+	loc = displayLocation(loc)
+	if date {
+		return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, loc)
+	}
+	return t.In(loc)
+}
@@ -0,0 +1,109 @@
+package zoom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestParseEventDateTime(t *testing.T) {
+	defer func(location *time.Location) { Location = location }(Location)
+	Location = time.UTC
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+
+	start, allDay, err := ParseEventDateTime(&calendar.EventDateTime{DateTime: "2018-10-10T14:00:00-04:00"})
+	require.NoError(t, err)
+	assert.False(t, allDay)
+	assert.True(t, start.Equal(time.Date(2018, 10, 10, 18, 0, 0, 0, time.UTC)))
+
+	// The time is shown in the event's time zone.
+	start, _, err = ParseEventDateTime(&calendar.EventDateTime{DateTime: "2018-10-10T14:00:00-04:00", TimeZone: "Europe/Berlin"})
+	require.NoError(t, err)
+	assert.Equal(t, "2018-10-10T20:00:00+02:00", start.Format(time.RFC3339))
+
+	// Without a UTC offset, the time is in the event's time zone.
+	start, _, err = ParseEventDateTime(&calendar.EventDateTime{DateTime: "2018-10-10T14:00:00", TimeZone: "Europe/Berlin"})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2018, 10, 10, 14, 0, 0, 0, berlin), start)
+	_, _, err = ParseEventDateTime(&calendar.EventDateTime{DateTime: "2018-10-10T14:00:00"})
+	assert.Error(t, err, "the time zone is unknown")
+
+	start, allDay, err = ParseEventDateTime(&calendar.EventDateTime{Date: "2018-10-10"})
+	require.NoError(t, err)
+	assert.True(t, allDay)
+	assert.Equal(t, time.Date(2018, 10, 10, 0, 0, 0, 0, time.UTC), start, "dates are midnight in Location")
+
+	start, _, err = ParseEventDateTime(&calendar.EventDateTime{Date: "2018-10-10", TimeZone: "Europe/Berlin"})
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2018, 10, 10, 0, 0, 0, 0, berlin), start)
+
+	start, _, err = ParseEventDateTime(&calendar.EventDateTime{DateTime: "2018-10-10T14:00:00Z", TimeZone: "Mars/Olympus_Mons"})
+	require.NoError(t, err)
+	assert.Equal(t, time.UTC, start.Location(), "unknown time zones are ignored")
+
+	_, _, err = ParseEventDateTime(&calendar.EventDateTime{})
+	assert.EqualError(t, err, "date-time has neither a date nor a time")
+	_, _, err = ParseEventDateTime(nil)
+	assert.Error(t, err)
+}
+
+func TestMeetingStartTimeIn(t *testing.T) {
+	defer func(location *time.Location) { Location = location }(Location)
+	Location = time.UTC
+	tokyo, err := time.LoadLocation("Asia/Tokyo")
+	require.NoError(t, err)
+
+	event := &calendar.Event{
+		Start: &calendar.EventDateTime{DateTime: "2018-10-10T14:00:00-04:00", TimeZone: "America/New_York"},
+		End:   &calendar.EventDateTime{DateTime: "2018-10-10T15:00:00-04:00", TimeZone: "America/New_York"},
+	}
+	start, err := MeetingStartTimeIn(event, tokyo)
+	require.NoError(t, err)
+	assert.Equal(t, "2018-10-11T03:00:00+09:00", start.Format(time.RFC3339))
+	end, err := MeetingEndTimeIn(event, nil)
+	require.NoError(t, err)
+	assert.Equal(t, "2018-10-10T19:00:00Z", end.Format(time.RFC3339), "nil means Location")
+
+	// A day off is the same day wherever you are.
+	dayOff := &calendar.Event{
+		Start: &calendar.EventDateTime{Date: "2018-10-10", TimeZone: "America/New_York"},
+		End:   &calendar.EventDateTime{Date: "2018-10-11", TimeZone: "America/New_York"},
+	}
+	start, err = MeetingStartTimeIn(dayOff, tokyo)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2018, 10, 10, 0, 0, 0, 0, tokyo), start)
+	end, err = MeetingEndTimeIn(dayOff, tokyo)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2018, 10, 11, 0, 0, 0, 0, tokyo), end)
+
+	_, err = MeetingStartTimeIn(&calendar.Event{}, tokyo)
+	assert.EqualError(t, err, "event does not have a start datetime")
+}
+
+func TestMeetingFromEvent_AllDay(t *testing.T) {
+	defer func(location *time.Location) { Location = location }(Location)
+	Location = time.UTC
+
+	meeting := MeetingFromEvent(&calendar.Event{
+		Summary: "Offsite",
+		Start:   &calendar.EventDateTime{Date: "2018-10-10"},
+		End:     &calendar.EventDateTime{Date: "2018-10-12"},
+	}, nil)
+	assert.True(t, meeting.AllDay)
+	assert.Equal(t, time.Date(2018, 10, 10, 0, 0, 0, 0, time.UTC), meeting.Start)
+	assert.Equal(t, 48*time.Hour, meeting.Duration())
+	assert.Equal(t, "all day", meeting.TimeRange())
+
+	// All-day events never conflict with, or are back to back with, meetings.
+	standup := Meeting{Title: "Standup", Start: time.Date(2018, 10, 10, 9, 0, 0, 0, time.UTC), End: time.Date(2018, 10, 10, 9, 15, 0, 0, time.UTC)}
+	meetings := []Meeting{meeting, standup}
+	markConflicts(meetings)
+	assert.False(t, meetings[0].Conflicting)
+	assert.False(t, meetings[1].Conflicting)
+
+	assert.False(t, IsMeetingWithin(&calendar.Event{Start: &calendar.EventDateTime{Date: time.Now().Format("2006-01-02")}}, 24*time.Hour, 24*time.Hour))
+}
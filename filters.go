@@ -0,0 +1,111 @@
+package zoom
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// eventColors are the names Google Calendar shows for event color IDs.
+var eventColors = map[string]string{
+	"lavender": "1", "sage": "2", "grape": "3", "flamingo": "4", "banana": "5", "tangerine": "6",
+	"peacock": "7", "graphite": "8", "blueberry": "9", "basil": "10", "tomato": "11",
+}
+
+// Filter matches events by their title, organizer, and color. An event matches if it
+// matches every field which is set, so the zero Filter matches every event.
+type Filter struct {
+	// Summary matches the event's title.
+	Summary *regexp.Regexp
+
+	// OrganizerDomains match the domain of the organizer's email address, ignoring case,
+	// e.g. "jithub.com" matches "parkr@jithub.com" and "kevin@eng.jithub.com".
+	OrganizerDomains []string
+
+	// ColorIDs match the event's color, by ID, e.g. "11", or name, e.g. "tomato". Events
+	// in the calendar's default color have no color ID.
+	ColorIDs []string
+}
+
+// Matches returns true if the event matches every field of the filter which is set.
+func (f Filter) Matches(event *calendar.Event) bool {
+	if event == nil {
+		return false
+	}
+	if f.Summary != nil && !f.Summary.MatchString(event.Summary) {
+		return false
+	}
+	if len(f.OrganizerDomains) > 0 && !hasOrganizerDomain(event, f.OrganizerDomains) {
+		return false
+	}
+	if len(f.ColorIDs) > 0 && !hasColor(event, f.ColorIDs) {
+		return false
+	}
+	return true
+}
+
+// hasOrganizerDomain returns true if the event was organized, or else created, by someone
+// whose email address is in one of the domains or their subdomains.
+func hasOrganizerDomain(event *calendar.Event, domains []string) bool {
+	var email string
+	if event.Organizer != nil {
+		email = event.Organizer.Email
+	} else if event.Creator != nil {
+		email = event.Creator.Email
+	}
+	at := strings.LastIndex(email, "@")
+	if at < 0 {
+		return false
+	}
+
+	domain := strings.ToLower(email[at+1:])
+	for _, want := range domains {
+		want = strings.ToLower(strings.TrimPrefix(want, "@"))
+		if domain == want || strings.HasSuffix(domain, "."+want) {
+			return true
+		}
+	}
+	return false
+}
+
+// hasColor returns true if the event's color is one of the color IDs or names.
+func hasColor(event *calendar.Event, colors []string) bool {
+	for _, color := range colors {
+		if id, ok := eventColors[strings.ToLower(color)]; ok {
+			color = id
+		}
+		if event.ColorId != "" && event.ColorId == color {
+			return true
+		}
+	}
+	return false
+}
+
+// filterReason returns why the include and exclude filters skip the event, or "" if they don't.
+func filterReason(event *calendar.Event, include, exclude []Filter) string {
+	for _, filter := range exclude {
+		if filter.Matches(event) {
+			return "excluded by filter"
+		}
+	}
+	if len(include) == 0 {
+		return ""
+	}
+	for _, filter := range include {
+		if filter.Matches(event) {
+			return ""
+		}
+	}
+	return "not included by filter"
+}
+
+// compileFilterPattern compiles a regexp for Filter.Summary from a setting.
+func compileFilterPattern(key, pattern string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", key, err)
+	}
+	return re, nil
+}
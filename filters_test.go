@@ -0,0 +1,55 @@
+package zoom
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestFilterMatches(t *testing.T) {
+	lunch := &calendar.Event{Summary: "Lunch", ColorId: "11", Creator: &calendar.EventCreator{Email: "parkr@jithub.com"}}
+	standup := &calendar.Event{Summary: "Standup", Organizer: &calendar.EventOrganizer{Email: "kevin@ENG.jithub.com"}}
+	vendorCall := &calendar.Event{Summary: "Renewal call", Organizer: &calendar.EventOrganizer{Email: "sales@vendor.example"}}
+
+	testCases := []struct {
+		filter   Filter
+		event    *calendar.Event
+		expected bool
+	}{
+		{Filter{}, lunch, true},
+		{Filter{}, nil, false},
+		{Filter{Summary: regexp.MustCompile(`(?i)^lunch\b`)}, lunch, true},
+		{Filter{Summary: regexp.MustCompile(`(?i)^lunch\b`)}, standup, false},
+		{Filter{OrganizerDomains: []string{"jithub.com"}}, lunch, true},
+		{Filter{OrganizerDomains: []string{"@jithub.com"}}, standup, true},
+		{Filter{OrganizerDomains: []string{"jithub.com"}}, vendorCall, false},
+		{Filter{OrganizerDomains: []string{"hub.com"}}, lunch, false},
+		{Filter{ColorIDs: []string{"11"}}, lunch, true},
+		{Filter{ColorIDs: []string{"Tomato"}}, lunch, true},
+		{Filter{ColorIDs: []string{"basil"}}, lunch, false},
+		{Filter{ColorIDs: []string{""}}, standup, false},
+		{Filter{Summary: regexp.MustCompile(`Lunch`), OrganizerDomains: []string{"vendor.example"}}, lunch, false},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, testCase.filter.Matches(testCase.event), "filter: %+v, event: %+v", testCase.filter, testCase.event)
+	}
+}
+
+func TestOptionsAllows_IncludeExclude(t *testing.T) {
+	lunch := &calendar.Event{Summary: "Lunch", Organizer: &calendar.EventOrganizer{Email: "parkr@jithub.com"}}
+	standup := &calendar.Event{Summary: "Standup", Organizer: &calendar.EventOrganizer{Email: "kevin@jithub.com"}}
+	vendorCall := &calendar.Event{Summary: "Renewal call", Organizer: &calendar.EventOrganizer{Email: "sales@vendor.example"}}
+
+	opts := Options{
+		Include: []Filter{{OrganizerDomains: []string{"jithub.com"}}},
+		Exclude: []Filter{{Summary: regexp.MustCompile(`(?i)\blunch\b`)}},
+	}
+	assert.False(t, opts.allows(lunch))
+	assert.True(t, opts.allows(standup))
+	assert.False(t, opts.allows(vendorCall))
+
+	assert.Equal(t, "excluded by filter", opts.skipReason(lunch))
+	assert.Equal(t, "not included by filter", opts.skipReason(vendorCall))
+}
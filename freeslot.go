@@ -0,0 +1,135 @@
+package zoom
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// busyPeriod is a span of time in which a calendar is busy.
+type busyPeriod struct {
+	start, end time.Time
+}
+
+// NextFreeSlot returns when the next gap of at least the duration, in the span from now
+// until within from now, starts in your primary calendar. Gaps are found with the Calendar
+// API's free/busy query, so events marked as free don't count as busy. It returns
+// ErrNoFreeSlot if there is no such gap.
+func NextFreeSlot(service *calendar.Service, duration, within time.Duration) (time.Time, error) {
+	return NextFreeSlotWithOptions(service, duration, within, Options{})
+}
+
+// NextFreeSlotWithOptions is like NextFreeSlot, but looks for a gap in every calendar
+// selected by the options. Only the options' calendars and retry policy are used; events
+// skipped by the options, such as declined ones, still make you busy if the free/busy
+// query says so.
+func NextFreeSlotWithOptions(service *calendar.Service, duration, within time.Duration, opts Options) (time.Time, error) {
+	return NextFreeSlotContext(context.Background(), service, duration, within, opts)
+}
+
+// NextFreeSlotContext is like NextFreeSlotWithOptions, but the calendar API calls are bound
+// to the context.
+func NextFreeSlotContext(ctx context.Context, service *calendar.Service, duration, within time.Duration, opts Options) (time.Time, error) {
+	return nextFreeSlot(ctx, service, duration, within, opts, time.Now())
+}
+
+// NextFreeSlotFromSource is like NextFreeSlotContext, but looks for a gap between the
+// meetings the source lists, such as an AccountsSource, from now. Every meeting the source
+// lists makes you busy, except all-day events and those without an end time.
+func NextFreeSlotFromSource(ctx context.Context, source CalendarSource, duration, within time.Duration, now time.Time) (time.Time, error) {
+	end := now.Add(within)
+	meetings, err := source.UpcomingEvents(ctx, Window{Start: now, End: end})
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var busy []busyPeriod
+	for _, meeting := range meetings {
+		if meeting.AllDay || meeting.Start.IsZero() || meeting.End.IsZero() {
+			continue
+		}
+		busy = append(busy, busyPeriod{start: meeting.Start, end: meeting.End})
+	}
+	sort.Slice(busy, func(i, j int) bool {
+		return busy[i].start.Before(busy[j].start)
+	})
+	return firstGap(busy, now, end, duration)
+}
+
+func nextFreeSlot(ctx context.Context, service *calendar.Service, duration, within time.Duration, opts Options, now time.Time) (time.Time, error) {
+	end := now.Add(within)
+	busy, err := busyPeriods(ctx, service, Window{Start: now, End: end}, opts)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return firstGap(busy, now, end, duration)
+}
+
+// firstGap returns when the first gap of at least the duration between the busy periods,
+// which must be sorted by when they start, starts in the span from now until end.
+func firstGap(busy []busyPeriod, now, end time.Time, duration time.Duration) (time.Time, error) {
+	free := now
+	for _, period := range busy {
+		if period.start.Sub(free) >= duration {
+			return free, nil
+		}
+		if period.end.After(free) {
+			free = period.end
+		}
+	}
+	if end.Sub(free) >= duration {
+		return free, nil
+	}
+	return time.Time{}, ErrNoFreeSlot
+}
+
+// busyPeriods returns the spans of time in the window in which any of the calendars
+// selected by the options is busy, sorted by when they start.
+func busyPeriods(ctx context.Context, service *calendar.Service, window Window, opts Options) ([]busyPeriod, error) {
+	calendarIDs, err := calendarIDs(ctx, service, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	request := &calendar.FreeBusyRequest{
+		TimeMin: window.Start.Format(time.RFC3339),
+		TimeMax: window.End.Format(time.RFC3339),
+	}
+	for _, calendarID := range calendarIDs {
+		request.Items = append(request.Items, &calendar.FreeBusyRequestItem{Id: calendarID})
+	}
+
+	var response *calendar.FreeBusyResponse
+	err = opts.Retry.do(ctx, opts.logger(), func() (err error) {
+		response, err = service.Freebusy.Query(request).Context(ctx).Do()
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("querying free/busy: %w", calendarError(err))
+	}
+
+	var busy []busyPeriod
+	for _, calendarID := range calendarIDs {
+		freeBusy := response.Calendars[calendarID]
+		for _, e := range freeBusy.Errors {
+			if e != nil {
+				return nil, fmt.Errorf("querying free/busy in calendar %q: %s", calendarID, e.Reason)
+			}
+		}
+		for _, period := range freeBusy.Busy {
+			start, startErr := time.Parse(time.RFC3339, period.Start)
+			end, endErr := time.Parse(time.RFC3339, period.End)
+			if startErr != nil || endErr != nil {
+				return nil, fmt.Errorf("querying free/busy in calendar %q: invalid busy period %s to %s", calendarID, period.Start, period.End)
+			}
+			busy = append(busy, busyPeriod{start: start, end: end})
+		}
+	}
+	sort.Slice(busy, func(i, j int) bool {
+		return busy[i].start.Before(busy[j].start)
+	})
+	return busy, nil
+}
@@ -0,0 +1,100 @@
+package zoom
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestNextFreeSlot(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	var requests []calendar.FreeBusyRequest
+	mux.HandleFunc("/freeBusy", func(w http.ResponseWriter, r *http.Request) {
+		var request calendar.FreeBusyRequest
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&request))
+		requests = append(requests, request)
+		fmt.Fprint(w, `{"calendars": {
+			"parkr@jithub.com": {"busy": [
+				{"start": "2018-10-10T09:00:00Z", "end": "2018-10-10T10:00:00Z"},
+				{"start": "2018-10-10T11:00:00Z", "end": "2018-10-10T11:30:00Z"}
+			]},
+			"team@jithub.com": {"busy": [
+				{"start": "2018-10-10T09:30:00Z", "end": "2018-10-10T10:20:00Z"},
+				{"start": "2018-10-10T12:00:00Z", "end": "2018-10-10T17:00:00Z"}
+			]}
+		}}`)
+	})
+
+	opts := Options{CalendarIDs: []string{"parkr@jithub.com", "team@jithub.com"}}
+	now := time.Date(2018, 10, 10, 9, 15, 0, 0, time.UTC)
+
+	slot, err := nextFreeSlot(context.Background(), service, 30*time.Minute, 8*time.Hour, opts, now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2018, 10, 10, 10, 20, 0, 0, time.UTC), slot)
+	require.Len(t, requests, 1)
+	assert.Equal(t, "2018-10-10T09:15:00Z", requests[0].TimeMin)
+	assert.Equal(t, "2018-10-10T17:15:00Z", requests[0].TimeMax)
+	require.Len(t, requests[0].Items, 2)
+	assert.Equal(t, "team@jithub.com", requests[0].Items[1].Id)
+
+	slot, err = nextFreeSlot(context.Background(), service, time.Hour, 9*time.Hour, opts, now)
+	require.NoError(t, err)
+	assert.Equal(t, time.Date(2018, 10, 10, 17, 0, 0, 0, time.UTC), slot, "gaps after the last busy period count")
+
+	_, err = nextFreeSlot(context.Background(), service, time.Hour, 3*time.Hour, opts, now)
+	assert.Equal(t, ErrNoFreeSlot, err)
+
+	slot, err = nextFreeSlot(context.Background(), service, 30*time.Minute, time.Hour, opts, now.Add(-time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-time.Hour), slot, "you may be free right away")
+}
+
+func TestNextFreeSlot_CalendarError(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	mux.HandleFunc("/freeBusy", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"calendars": {"primary": {"errors": [{"domain": "global", "reason": "notFound"}]}}}`)
+	})
+
+	_, err := NextFreeSlot(service, 30*time.Minute, time.Hour)
+	assert.EqualError(t, err, `querying free/busy in calendar "primary": notFound`)
+}
+
+func TestNextFreeSlotFromSource(t *testing.T) {
+	at := func(hour, minute int) time.Time {
+		return time.Date(2018, 10, 10, hour, minute, 0, 0, time.UTC)
+	}
+	source := meetingsSource{
+		{Title: "Standup", Start: at(9, 0), End: at(10, 0), Account: "work"},
+		{Title: "School run", Start: at(9, 30), End: at(10, 20), Account: "personal"},
+		{Title: "Offsite", Start: at(0, 0), End: at(23, 59), AllDay: true},
+		{Title: "Reminder", Start: at(10, 25)},
+		{Title: "Lunch", Start: at(11, 0), End: at(11, 30)},
+	}
+	now := at(9, 15)
+
+	slot, err := NextFreeSlotFromSource(context.Background(), source, 30*time.Minute, 8*time.Hour, now)
+	require.NoError(t, err)
+	assert.Equal(t, at(10, 20), slot, "all-day events and those without an end don't make you busy")
+
+	slot, err = NextFreeSlotFromSource(context.Background(), source, time.Hour, 8*time.Hour, now)
+	require.NoError(t, err)
+	assert.Equal(t, at(11, 30), slot)
+
+	_, err = NextFreeSlotFromSource(context.Background(), source, time.Hour, 2*time.Hour, now)
+	assert.Equal(t, ErrNoFreeSlot, err)
+}
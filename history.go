@@ -0,0 +1,138 @@
+package zoom
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// History is a journal of the meetings you have joined, kept in a file with one JSON
+// entry per line, so a meeting can be joined again after it has started or even ended,
+// when NextEvent no longer finds it. Set Opener.History to record the meetings it opens.
+type History struct {
+	// Path is the file in which the journal is kept.
+	Path string
+
+	mu sync.Mutex
+}
+
+// HistoryEntry is a meeting which was joined.
+type HistoryEntry struct {
+	// JoinedAt is when the meeting was joined.
+	JoinedAt time.Time `json:"joinedAt"`
+
+	// ID identifies the meeting within its calendar, if it came from one.
+	ID string `json:"id,omitempty"`
+
+	// Title is the name of the meeting.
+	Title string `json:"title"`
+
+	// URL is the URL which was opened to join the meeting.
+	URL string `json:"url"`
+
+	// Start and End are when the meeting was scheduled, if known.
+	Start time.Time `json:"start,omitempty"`
+	End   time.Time `json:"end,omitempty"`
+}
+
+// NewHistory returns a History which keeps its journal in the file at path.
+func NewHistory(path string) *History {
+	return &History{Path: path}
+}
+
+// DefaultHistoryPath returns the file in your user cache directory in which the meetings
+// you have joined are recorded.
+func DefaultHistoryPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "zoom-go", "history.jsonl"), nil
+}
+
+// Record adds the meeting, joined at the URL now, to the journal.
+func (h *History) Record(meeting Meeting, u *url.URL, now time.Time) error {
+	entry := HistoryEntry{
+		JoinedAt: now,
+		ID:       meeting.ID,
+		Title:    meeting.Title,
+		URL:      urlString(u),
+		Start:    meeting.Start,
+		End:      meeting.End,
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if err := os.MkdirAll(filepath.Dir(h.Path), 0700); err != nil {
+		return fmt.Errorf("error recording joined meeting: %w", err)
+	}
+	f, err := os.OpenFile(h.Path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return fmt.Errorf("error recording joined meeting: %w", err)
+	}
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		f.Close()
+		return fmt.Errorf("error recording joined meeting: %w", err)
+	}
+	return f.Close()
+}
+
+// Recent returns up to n of the meetings most recently joined, most recent first. If n is
+// zero or less, every meeting in the journal is returned. Lines which can't be read, such
+// as one cut short by a crash, are skipped.
+func (h *History) Recent(n int) ([]HistoryEntry, error) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	f, err := os.Open(h.Path)
+	if os.IsNotExist(err) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("error reading meeting history: %w", err)
+	}
+	defer f.Close()
+
+	var entries []HistoryEntry
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		var entry HistoryEntry
+		if err := json.Unmarshal(scanner.Bytes(), &entry); err == nil && entry.URL != "" {
+			entries = append(entries, entry)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("error reading meeting history: %w", err)
+	}
+
+	for i, j := 0, len(entries)-1; i < j; i, j = i+1, j-1 {
+		entries[i], entries[j] = entries[j], entries[i]
+	}
+	if n > 0 && len(entries) > n {
+		entries = entries[:n]
+	}
+	return entries, nil
+}
+
+// LastJoined returns the meeting most recently joined, and false if none has been.
+func (h *History) LastJoined() (HistoryEntry, bool, error) {
+	entries, err := h.Recent(1)
+	if err != nil || len(entries) == 0 {
+		return HistoryEntry{}, false, err
+	}
+	return entries[0], true, nil
+}
+
+// JoinURL returns the parsed URL which was opened to join the meeting.
+func (e HistoryEntry) JoinURL() (*url.URL, error) {
+	return url.Parse(e.URL)
+}
@@ -0,0 +1,60 @@
+package zoom
+
+import (
+	"net/url"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistory(t *testing.T) {
+	history := NewHistory(filepath.Join(t.TempDir(), "zoom-go", "history.jsonl"))
+
+	_, ok, err := history.LastJoined()
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	standupURL, _ := url.Parse("zoommtg://zoom.us/join?confno=11111")
+	retroURL, _ := url.Parse("https://jithub.zoom.us/j/22222")
+	joinedAt := time.Date(2018, 10, 10, 17, 0, 0, 0, time.UTC)
+	start := joinedAt.Add(time.Minute)
+
+	require.NoError(t, history.Record(Meeting{ID: "standup", Title: "Standup", Start: start}, standupURL, joinedAt))
+	require.NoError(t, history.Record(Meeting{Title: "Retro"}, retroURL, joinedAt.Add(time.Hour)))
+
+	entry, ok, err := history.LastJoined()
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "Retro", entry.Title)
+	u, err := entry.JoinURL()
+	require.NoError(t, err)
+	assert.Equal(t, retroURL, u)
+
+	entries, err := history.Recent(0)
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "Retro", entries[0].Title)
+	assert.True(t, entries[1].JoinedAt.Equal(joinedAt))
+	assert.Equal(t, "standup", entries[1].ID)
+	assert.True(t, entries[1].Start.Equal(start))
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=11111", entries[1].URL)
+
+	entries, err = history.Recent(1)
+	require.NoError(t, err)
+	assert.Len(t, entries, 1)
+}
+
+func TestHistory_SkipsUnreadableLines(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "history.jsonl")
+	require.NoError(t, os.WriteFile(path, []byte(`{"title": "Standup", "url": "https://jithub.zoom.us/j/11111"}
+{"title": "Ret`), 0600))
+
+	entry, ok, err := NewHistory(path).LastJoined()
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "Standup", entry.Title)
+}
@@ -0,0 +1,333 @@
+// Package ical provides a zoom.CalendarSource which reads events from an iCalendar (.ics) file or feed.
+//
+// This lets you find your next meeting from any calendar system which can export or
+// publish an .ics feed, without needing Google OAuth credentials.
+//
+// Recurrence rules are not expanded; only the occurrences listed in the feed are used.
+package ical
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+
+	"github.com/benbalter/zoom-go"
+)
+
+const (
+	dateTimeFormat    = "20060102T150405"
+	utcDateTimeFormat = "20060102T150405Z"
+	dateFormat        = "20060102"
+)
+
+// Source reads meetings from an iCalendar file or URL.
+type Source struct {
+	// Providers are the video-conferencing services whose links make an event a meeting.
+	// If empty, only Zoom links are recognized.
+	Providers []zoom.Provider
+
+	open func(ctx context.Context) (io.ReadCloser, error)
+}
+
+// NewFileSource returns a Source which reads the .ics file at the path.
+func NewFileSource(path string) *Source {
+	return &Source{open: func(ctx context.Context) (io.ReadCloser, error) {
+		fd, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		return fd, nil
+	}}
+}
+
+// NewURLSource returns a Source which downloads the .ics feed at the URL using the client.
+// webcal:// URLs are fetched over HTTPS.
+func NewURLSource(client *http.Client, feedURL string) *Source {
+	if strings.HasPrefix(feedURL, "webcal://") {
+		feedURL = "https://" + strings.TrimPrefix(feedURL, "webcal://")
+	}
+
+	return &Source{open: func(ctx context.Context) (io.ReadCloser, error) {
+		req, err := http.NewRequest(http.MethodGet, feedURL, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := client.Do(req.WithContext(ctx))
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			resp.Body.Close()
+			return nil, fmt.Errorf("ical: got HTTP response code %d fetching %s", resp.StatusCode, feedURL)
+		}
+		return resp.Body, nil
+	}}
+}
+
+// UpcomingEvents returns the meetings in the feed which overlap the window, sorted by start time.
+// Cancelled events are skipped.
+func (s *Source) UpcomingEvents(ctx context.Context, window zoom.Window) ([]zoom.Meeting, error) {
+	r, err := s.open(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer r.Close()
+
+	events, err := Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	type timedEvent struct {
+		event      *calendar.Event
+		start, end time.Time
+	}
+
+	var inWindow []timedEvent
+	for _, event := range events {
+		if event.Status == "cancelled" {
+			continue
+		}
+		start, end, ok := eventBounds(event)
+		if !ok {
+			continue
+		}
+		if !end.After(window.Start) || (!window.End.IsZero() && !start.Before(window.End)) {
+			continue
+		}
+		inWindow = append(inWindow, timedEvent{event, start, end})
+	}
+
+	sort.SliceStable(inWindow, func(i, j int) bool {
+		return inWindow[i].start.Before(inWindow[j].start)
+	})
+
+	meetings := make([]zoom.Meeting, 0, len(inWindow))
+	for _, e := range inWindow {
+		meetings = append(meetings, zoom.MeetingFromEvent(e.event, s.Providers))
+	}
+	return meetings, nil
+}
+
+// Parse reads the VEVENTs in an iCalendar stream and converts them into Google Calendar
+// events, so they can be used with the rest of the zoom package.
+func Parse(r io.Reader) ([]*calendar.Event, error) {
+	lines, err := unfoldLines(r)
+	if err != nil {
+		return nil, err
+	}
+
+	var events []*calendar.Event
+	var current *calendar.Event
+	for _, line := range lines {
+		name, params, value := parseContentLine(line)
+
+		switch {
+		case name == "BEGIN" && value == "VEVENT":
+			current = &calendar.Event{}
+		case name == "END" && value == "VEVENT":
+			if current != nil {
+				events = append(events, current)
+			}
+			current = nil
+		case current != nil:
+			applyProperty(current, name, params, value)
+		}
+	}
+	return events, nil
+}
+
+// unfoldLines splits the stream into content lines, joining lines which were folded
+// by starting a continuation line with whitespace.
+func unfoldLines(r io.Reader) ([]string, error) {
+	var lines []string
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimRight(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		if line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return lines, nil
+}
+
+// parseContentLine splits a line like `DTSTART;TZID=America/New_York:20181010T170000`
+// into its name, parameters, and value.
+func parseContentLine(line string) (name string, params map[string]string, value string) {
+	params = map[string]string{}
+
+	colon := indexOutsideQuotes(line, ':')
+	if colon < 0 {
+		return strings.ToUpper(line), params, ""
+	}
+	value = line[colon+1:]
+
+	parts := splitOutsideQuotes(line[:colon], ';')
+	name = strings.ToUpper(parts[0])
+	for _, param := range parts[1:] {
+		if eq := strings.Index(param, "="); eq >= 0 {
+			params[strings.ToUpper(param[:eq])] = strings.Trim(param[eq+1:], `"`)
+		}
+	}
+	return name, params, value
+}
+
+func applyProperty(event *calendar.Event, name string, params map[string]string, value string) {
+	switch name {
+	case "UID":
+		event.Id = value
+		event.ICalUID = value
+	case "SUMMARY":
+		event.Summary = unescapeText(value)
+	case "DESCRIPTION":
+		event.Description = unescapeText(value)
+	case "LOCATION":
+		event.Location = unescapeText(value)
+	case "URL":
+		event.HtmlLink = value
+	case "STATUS":
+		event.Status = strings.ToLower(value)
+	case "DTSTART":
+		event.Start = parseDateTime(params, value)
+	case "DTEND":
+		event.End = parseDateTime(params, value)
+	case "ORGANIZER":
+		event.Organizer = &calendar.EventOrganizer{
+			DisplayName: params["CN"],
+			Email:       mailto(value),
+		}
+	case "ATTENDEE":
+		event.Attendees = append(event.Attendees, &calendar.EventAttendee{
+			DisplayName:    params["CN"],
+			Email:          mailto(value),
+			Optional:       params["ROLE"] == "OPT-PARTICIPANT",
+			ResponseStatus: responseStatus(params["PARTSTAT"]),
+		})
+	}
+}
+
+// parseDateTime converts a DATE or DATE-TIME value into a calendar date-time.
+func parseDateTime(params map[string]string, value string) *calendar.EventDateTime {
+	if params["VALUE"] == "DATE" || len(value) == len(dateFormat) {
+		t, err := time.Parse(dateFormat, value)
+		if err != nil {
+			return nil
+		}
+		return &calendar.EventDateTime{Date: t.Format("2006-01-02")}
+	}
+
+	if strings.HasSuffix(value, "Z") {
+		t, err := time.Parse(utcDateTimeFormat, value)
+		if err != nil {
+			return nil
+		}
+		return &calendar.EventDateTime{DateTime: t.Format(time.RFC3339)}
+	}
+
+	location := time.Local
+	if tzid := params["TZID"]; tzid != "" {
+		if loaded, err := time.LoadLocation(tzid); err == nil {
+			location = loaded
+		}
+	}
+	t, err := time.ParseInLocation(dateTimeFormat, value, location)
+	if err != nil {
+		return nil
+	}
+	return &calendar.EventDateTime{DateTime: t.Format(time.RFC3339), TimeZone: params["TZID"]}
+}
+
+// eventBounds returns the start and end of the event. Events without an end last until
+// the end of the day they start on if they are all-day events, and are instantaneous otherwise.
+func eventBounds(event *calendar.Event) (start, end time.Time, ok bool) {
+	start, allDay, ok := eventDateTime(event.Start)
+	if !ok {
+		return time.Time{}, time.Time{}, false
+	}
+
+	end, _, ok = eventDateTime(event.End)
+	if !ok {
+		end = start
+		if allDay {
+			end = start.AddDate(0, 0, 1)
+		}
+	}
+	return start, end, true
+}
+
+func eventDateTime(dateTime *calendar.EventDateTime) (t time.Time, allDay bool, ok bool) {
+	t, allDay, err := zoom.ParseEventDateTime(dateTime)
+	return t, allDay, err == nil
+}
+
+// responseStatus maps an iCalendar PARTSTAT onto the Google Calendar equivalent.
+func responseStatus(partstat string) string {
+	switch strings.ToUpper(partstat) {
+	case "ACCEPTED":
+		return "accepted"
+	case "DECLINED":
+		return "declined"
+	case "TENTATIVE":
+		return "tentative"
+	default:
+		return "needsAction"
+	}
+}
+
+func mailto(value string) string {
+	if len(value) > len("mailto:") && strings.EqualFold(value[:len("mailto:")], "mailto:") {
+		return value[len("mailto:"):]
+	}
+	return value
+}
+
+var textUnescaper = strings.NewReplacer(`\n`, "\n", `\N`, "\n", `\,`, ",", `\;`, ";", `\\`, `\`)
+
+func unescapeText(value string) string {
+	return textUnescaper.Replace(value)
+}
+
+func indexOutsideQuotes(s string, sep byte) int {
+	quoted := false
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '"':
+			quoted = !quoted
+		case sep:
+			if !quoted {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+func splitOutsideQuotes(s string, sep byte) []string {
+	var parts []string
+	for {
+		i := indexOutsideQuotes(s, sep)
+		if i < 0 {
+			return append(parts, s)
+		}
+		parts = append(parts, s[:i])
+		s = s[i+1:]
+	}
+}
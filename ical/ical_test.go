@@ -0,0 +1,128 @@
+package ical
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	calendar "google.golang.org/api/calendar/v3"
+
+	"github.com/benbalter/zoom-go"
+)
+
+var testFeed = strings.Replace(`BEGIN:VCALENDAR
+VERSION:2.0
+PRODID:-//Jithub//Calendar//EN
+BEGIN:VEVENT
+UID:in-person@jithub.com
+SUMMARY:I am an in-person meeting
+LOCATION:In a real place!
+DTSTART:20181011T000000Z
+DTEND:20181011T003000Z
+END:VEVENT
+BEGIN:VEVENT
+UID:video@jithub.com
+SUMMARY:I am a video call\, with a comma
+DESCRIPTION:Join Zoom Meeting\nhttps://jithub.zoom.us/j/12345?pwd=ab
+ c123
+DTSTART;TZID="America/Los_Angeles":20181010T173000
+DTEND;TZID="America/Los_Angeles":20181010T180000
+ORGANIZER;CN=Kevin Jithub:mailto:kevin@jithub.com
+ATTENDEE;CN="Moore, Parker";PARTSTAT=ACCEPTED:mailto:parkr@jithub.com
+ATTENDEE;ROLE=OPT-PARTICIPANT;PARTSTAT=NEEDS-ACTION:mailto:mona@jithub.com
+END:VEVENT
+BEGIN:VEVENT
+UID:cancelled@jithub.com
+SUMMARY:Cancelled sync
+LOCATION:https://jithub.zoom.us/j/99999
+STATUS:CANCELLED
+DTSTART:20181011T010000Z
+END:VEVENT
+BEGIN:VEVENT
+UID:holiday@jithub.com
+SUMMARY:Holiday
+DTSTART;VALUE=DATE:20181012
+END:VEVENT
+END:VCALENDAR
+`, "\n", "\r\n", -1)
+
+func TestParse(t *testing.T) {
+	events, err := Parse(strings.NewReader(testFeed))
+	require.NoError(t, err)
+	require.Len(t, events, 4)
+
+	video := events[1]
+	assert.Equal(t, "video@jithub.com", video.ICalUID)
+	assert.Equal(t, "I am a video call, with a comma", video.Summary)
+	assert.Equal(t, "Join Zoom Meeting\nhttps://jithub.zoom.us/j/12345?pwd=abc123", video.Description)
+	assert.Equal(t, &calendar.EventDateTime{DateTime: "2018-10-10T17:30:00-07:00", TimeZone: "America/Los_Angeles"}, video.Start)
+	assert.Equal(t, &calendar.EventOrganizer{DisplayName: "Kevin Jithub", Email: "kevin@jithub.com"}, video.Organizer)
+	assert.Equal(t, []*calendar.EventAttendee{
+		{DisplayName: "Moore, Parker", Email: "parkr@jithub.com", ResponseStatus: "accepted"},
+		{Email: "mona@jithub.com", ResponseStatus: "needsAction", Optional: true},
+	}, video.Attendees)
+
+	assert.Equal(t, "cancelled", events[2].Status)
+	assert.Equal(t, &calendar.EventDateTime{Date: "2018-10-12"}, events[3].Start)
+}
+
+func TestFileSource(t *testing.T) {
+	dir, err := ioutil.TempDir("", "zoom-ical")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	path := filepath.Join(dir, "calendar.ics")
+	require.NoError(t, ioutil.WriteFile(path, []byte(testFeed), 0600))
+
+	source := NewFileSource(path)
+	meetings, err := source.UpcomingEvents(context.Background(), zoom.Window{
+		Start: time.Date(2018, 10, 11, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2018, 10, 11, 2, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.Len(t, meetings, 2)
+	assert.Equal(t, "I am an in-person meeting", meetings[0].Title)
+	assert.Equal(t, "I am a video call, with a comma", meetings[1].Title)
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=12345&pwd=abc123", meetings[1].DeepLink.String())
+	assert.Equal(t, "abc123", meetings[1].Passcode)
+
+	next, err := zoom.NextMeetingFromSource(context.Background(), source, zoom.Window{
+		Start: time.Date(2018, 10, 11, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "I am a video call, with a comma", next.Title)
+}
+
+func TestURLSource(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/calendar.ics", r.URL.Path)
+		fmt.Fprint(w, testFeed)
+	}))
+	defer server.Close()
+
+	source := NewURLSource(server.Client(), server.URL+"/calendar.ics")
+	meetings, err := source.UpcomingEvents(context.Background(), zoom.Window{
+		Start: time.Date(2018, 10, 12, 0, 0, 0, 0, time.Local),
+	})
+	require.NoError(t, err)
+	require.Len(t, meetings, 1)
+	assert.Equal(t, "Holiday", meetings[0].Title)
+}
+
+func TestURLSource_Error(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	_, err := NewURLSource(server.Client(), server.URL).UpcomingEvents(context.Background(), zoom.Window{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "404")
+}
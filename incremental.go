@@ -0,0 +1,216 @@
+package zoom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+	"google.golang.org/api/googleapi"
+)
+
+// incrementalSyncAhead is how far past the end of a window a full sync lists events, so
+// that later windows are covered by the same sync token.
+const incrementalSyncAhead = 7 * 24 * time.Hour
+
+// incrementalSyncPageSize is the number of events requested at a time while syncing.
+const incrementalSyncPageSize = 250
+
+// incrementalSync keeps each calendar's events between refreshes, and uses the Calendar
+// API's sync tokens to list only the events which changed since the last refresh.
+type incrementalSync struct {
+	// path is a file in which the synced events are also stored, so later runs of a program
+	// can sync incrementally too. If empty, they are only kept in memory.
+	path string
+
+	mu        sync.Mutex
+	loaded    bool
+	calendars map[string]*syncedCalendar
+}
+
+// syncedCalendar is the events synced from a single calendar, as stored on disk.
+type syncedCalendar struct {
+	// SyncToken lists the events which changed since the last sync.
+	SyncToken string `json:"syncToken"`
+
+	// From and Until are the span of time whose events are synced.
+	From  time.Time `json:"from"`
+	Until time.Time `json:"until"`
+
+	// Events are the synced events, by ID.
+	Events map[string]*calendar.Event `json:"events"`
+}
+
+// NewIncrementalCalendarSource returns a GoogleCalendarSource which keeps the events it
+// lists, and on each later call only lists the events which have changed since, using
+// far less of your Calendar API quota than listing the window every time. It suits
+// sources which are refreshed often, such as a daemon's. The synced events are also
+// stored in the file at path, so separate runs of a program share them; if path is
+// empty, they are only kept in memory.
+//
+// A calendar is listed in full again when a window starts before, or ends more than a
+// week after, the windows it was first synced for, or when Google Calendar expires its
+// sync token. Windows without an end only include the events synced so far.
+func NewIncrementalCalendarSource(service *calendar.Service, opts Options, path string) *GoogleCalendarSource {
+	return &GoogleCalendarSource{service: service, opts: opts, incremental: &incrementalSync{path: path}}
+}
+
+// DefaultIncrementalSyncPath returns the file in your user cache directory in which
+// incrementally synced events are stored.
+func DefaultIncrementalSyncPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "zoom-go", "sync.json"), nil
+}
+
+// eventsInWindow syncs each selected calendar and returns the synced events in the window
+// allowed by the options, merged in order of start time.
+func (s *incrementalSync) eventsInWindow(ctx context.Context, service *calendar.Service, window Window, opts Options) ([]*calendar.Event, error) {
+	calendarIDs, err := calendarIDs(ctx, service, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.loaded {
+		s.loaded = true
+		if s.path != "" {
+			readJSONFile(s.path, &s.calendars)
+		}
+	}
+	if s.calendars == nil {
+		s.calendars = map[string]*syncedCalendar{}
+	}
+
+	// Each calendar is synced by its own goroutine, so look them all up first.
+	synced := make([]*syncedCalendar, len(calendarIDs))
+	for i, calendarID := range calendarIDs {
+		if s.calendars[calendarID] == nil {
+			s.calendars[calendarID] = &syncedCalendar{}
+		}
+		synced[i] = s.calendars[calendarID]
+	}
+
+	err = forEachCalendar(ctx, calendarIDs, opts, func(ctx context.Context, i int, calendarID string) error {
+		return synced[i].sync(ctx, service, calendarID, window, opts)
+	})
+	if s.path != "" {
+		// The events can always be listed again, so failing to store them isn't an error.
+		writeJSONFile(s.path, s.calendars)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	results := make([][]*calendar.Event, len(synced))
+	for i, c := range synced {
+		for _, event := range c.Events {
+			if inWindow(event, window) && opts.allows(event) {
+				results[i] = append(results[i], event)
+			}
+		}
+	}
+	return mergeEvents(results, 0), nil
+}
+
+// sync brings the calendar's events up to date, listing only the changed events if it
+// has a sync token which covers the window, and every event around the window otherwise.
+func (c *syncedCalendar) sync(ctx context.Context, service *calendar.Service, calendarID string, window Window, opts Options) error {
+	if c.SyncToken != "" && c.covers(window) {
+		err := c.list(ctx, service, calendarID, opts, func(call *calendar.EventsListCall) *calendar.EventsListCall {
+			return call.SyncToken(c.SyncToken)
+		})
+		var apiErr *googleapi.Error
+		if !errors.As(err, &apiErr) || apiErr.Code != http.StatusGone {
+			return err
+		}
+		// The sync token expired, so start again.
+		opts.logger().Debug("sync token expired", "calendar", calendarID)
+	}
+
+	end := window.End
+	if end.Before(window.Start) {
+		end = window.Start
+	}
+	*c = syncedCalendar{From: window.Start, Until: end.Add(incrementalSyncAhead), Events: map[string]*calendar.Event{}}
+	return c.list(ctx, service, calendarID, opts, func(call *calendar.EventsListCall) *calendar.EventsListCall {
+		return call.TimeMin(c.From.Format(time.RFC3339)).TimeMax(c.Until.Format(time.RFC3339))
+	})
+}
+
+// covers returns true if the calendar's events were synced for a span including the window.
+func (c *syncedCalendar) covers(window Window) bool {
+	return !window.Start.Before(c.From) && !window.End.After(c.Until)
+}
+
+// list applies every page of events returned by the call, as configured by configure, to
+// the synced events, and keeps the sync token for the next sync. Cancelled events are
+// removed. The events and sync token are left as they were if listing fails.
+func (c *syncedCalendar) list(ctx context.Context, service *calendar.Service, calendarID string, opts Options, configure func(*calendar.EventsListCall) *calendar.EventsListCall) error {
+	call := configure(service.Events.
+		List(calendarID).
+		SingleEvents(true).
+		MaxResults(incrementalSyncPageSize).
+		Context(ctx))
+
+	var changed []*calendar.Event
+	for {
+		var events *calendar.Events
+		err := opts.Retry.do(ctx, opts.logger(), func() (err error) {
+			events, err = call.Do()
+			return err
+		})
+		if err != nil {
+			return fmt.Errorf("syncing events in calendar %q: %w", calendarID, calendarError(err))
+		}
+
+		withDefaultReminders(events.Items, events.DefaultReminders)
+		changed = append(changed, events.Items...)
+		if events.NextPageToken == "" {
+			c.SyncToken = events.NextSyncToken
+			break
+		}
+		call = call.PageToken(events.NextPageToken)
+	}
+
+	opts.logger().Debug("synced calendar", "calendar", calendarID, "changed", len(changed))
+	if c.Events == nil {
+		c.Events = map[string]*calendar.Event{}
+	}
+	for _, event := range changed {
+		if event.Status == "cancelled" {
+			delete(c.Events, event.Id)
+		} else {
+			c.Events[event.Id] = event
+		}
+	}
+	return nil
+}
+
+// inWindow returns true if the event ends after the window starts and starts before it
+// ends. All-day events are in the windows which start before the end of their last day,
+// and events without a start time are in every window.
+func inWindow(event *calendar.Event, window Window) bool {
+	if event.Start != nil && event.Start.Date != "" && event.End != nil {
+		start, startErr := time.ParseInLocation("2006-01-02", event.Start.Date, window.Start.Location())
+		end, endErr := time.ParseInLocation("2006-01-02", event.End.Date, window.Start.Location())
+		if startErr != nil || endErr != nil {
+			return true
+		}
+		return end.After(window.Start) && (window.End.IsZero() || start.Before(window.End))
+	}
+	if hasEnded(event, window.Start) {
+		return false
+	}
+	start, err := MeetingStartTime(event)
+	return err != nil || window.End.IsZero() || start.Before(window.End)
+}
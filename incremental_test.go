@@ -0,0 +1,106 @@
+package zoom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIncrementalCalendarSource(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	var queries []string
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		queries = append(queries, query.Get("syncToken"))
+		switch query.Get("syncToken") {
+		case "":
+			assert.Equal(t, "2018-10-10T09:00:00-07:00", query.Get("timeMin"))
+			assert.Equal(t, "2018-10-18T09:00:00-07:00", query.Get("timeMax"))
+			if query.Get("pageToken") == "" {
+				fmt.Fprint(w, `{"nextPageToken": "page2", "items": [
+					{"id": "standup", "summary": "Standup", "location": "https://jithub.zoom.us/j/11111", "start": {"dateTime": "2018-10-10T10:00:00-07:00"}, "end": {"dateTime": "2018-10-10T10:15:00-07:00"}}
+				]}`)
+				return
+			}
+			fmt.Fprint(w, `{"nextSyncToken": "sync1", "items": [
+				{"id": "lunch", "summary": "Lunch", "start": {"dateTime": "2018-10-10T12:00:00-07:00"}, "end": {"dateTime": "2018-10-10T13:00:00-07:00"}},
+				{"id": "planning", "summary": "Planning", "start": {"dateTime": "2018-10-11T10:00:00-07:00"}, "end": {"dateTime": "2018-10-11T11:00:00-07:00"}}
+			]}`)
+		case "sync1":
+			assert.Empty(t, query.Get("timeMin"), "sync tokens can't be used with timeMin")
+			fmt.Fprint(w, `{"nextSyncToken": "sync2", "items": [
+				{"id": "lunch", "status": "cancelled"},
+				{"id": "retro", "summary": "Retro", "start": {"dateTime": "2018-10-10T15:00:00-07:00"}, "end": {"dateTime": "2018-10-10T16:00:00-07:00"}}
+			]}`)
+		case "sync2":
+			w.WriteHeader(http.StatusGone)
+			fmt.Fprint(w, `{"error": {"code": 410, "message": "Sync token is no longer valid, a full sync is required."}}`)
+		}
+	})
+
+	path := filepath.Join(t.TempDir(), "sync.json")
+	source := NewIncrementalCalendarSource(service, Options{}, path)
+	start := time.Date(2018, 10, 10, 9, 0, 0, 0, time.FixedZone("PDT", -7*60*60))
+	window := Window{Start: start, End: start.Add(24 * time.Hour)}
+	titles := func(meetings []Meeting) []string {
+		var titles []string
+		for _, meeting := range meetings {
+			titles = append(titles, meeting.Title)
+		}
+		return titles
+	}
+
+	meetings, err := source.UpcomingEvents(context.Background(), window)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Standup", "Lunch"}, titles(meetings))
+	assert.Equal(t, []string{"", ""}, queries)
+
+	meetings, err = source.UpcomingEvents(context.Background(), window)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Standup", "Retro"}, titles(meetings), "cancelled events are removed, and new ones added")
+	assert.Equal(t, []string{"", "", "sync1"}, queries)
+
+	// A later run picks up where this one left off.
+	queries = nil
+	source = NewIncrementalCalendarSource(service, Options{}, path)
+	meetings, err = source.UpcomingEvents(context.Background(), window)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"Standup", "Lunch"}, titles(meetings), "expired sync tokens start a full sync")
+	assert.Equal(t, []string{"sync2", "", ""}, queries)
+}
+
+func TestIncrementalCalendarSource_WindowOutsideSync(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	var timeMins []string
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		timeMins = append(timeMins, r.URL.Query().Get("timeMin"))
+		fmt.Fprint(w, `{"nextSyncToken": "sync", "items": []}`)
+	})
+
+	source := NewIncrementalCalendarSource(service, Options{}, "")
+	start := time.Date(2018, 10, 10, 9, 0, 0, 0, time.UTC)
+	for _, window := range []Window{
+		{Start: start, End: start.Add(time.Hour)},
+		{Start: start.Add(time.Hour), End: start.Add(2 * time.Hour)},
+		{Start: start.Add(-time.Hour), End: start},
+		{Start: start, End: start.Add(30 * 24 * time.Hour)},
+	} {
+		_, err := source.UpcomingEvents(context.Background(), window)
+		require.NoError(t, err)
+	}
+	assert.Equal(t, []string{"2018-10-10T09:00:00Z", "", "2018-10-10T08:00:00Z", "2018-10-10T09:00:00Z"}, timeMins)
+}
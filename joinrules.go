@@ -0,0 +1,156 @@
+package zoom
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// JoinAction is what a JoinRule does with a meeting it matches.
+type JoinAction string
+
+const (
+	// JoinApp opens the meeting in its provider's app, by its deep link if it has one.
+	JoinApp JoinAction = "app"
+
+	// JoinBrowser opens the meeting's web URL in the browser, or with JoinRule.Command.
+	JoinBrowser JoinAction = "browser"
+
+	// JoinCopy puts the meeting's web URL on the clipboard instead of opening it.
+	JoinCopy JoinAction = "copy"
+)
+
+// JoinRule chooses how to join the meetings it matches, such as opening Google Meet in a
+// particular Chrome profile, or copying Teams links rather than opening them.
+type JoinRule struct {
+	// Match is a provider's name, or the last word of it, such as "zoom", "teams", or
+	// "google-meet", or a domain such as "zoom.us", which also matches its subdomains.
+	Match string
+
+	// Action is what to do with the meetings the rule matches.
+	Action JoinAction
+
+	// Command is the program to open web URLs with, and its arguments, for JoinBrowser,
+	// e.g. ["google-chrome", "--profile-directory=Work"]. The URL is added as the last
+	// argument. If empty, the default browser is used.
+	Command []string
+}
+
+// ParseJoinRules parses rules such as "zoom.us app", "teams copy", or
+// "meet.google.com browser google-chrome --profile-directory=Work": what to match, the
+// action, and, for browser, the command to open the URL with. Arguments containing
+// spaces may be quoted, as in `browser open -na "Google Chrome" --args`.
+func ParseJoinRules(specs []string) ([]JoinRule, error) {
+	var rules []JoinRule
+	for _, spec := range specs {
+		fields, err := splitCommandLine(spec)
+		if err != nil {
+			return nil, fmt.Errorf("join rule %q: %w", spec, err)
+		}
+		if len(fields) < 2 {
+			return nil, fmt.Errorf("join rule %q should look like \"zoom.us app\"", spec)
+		}
+
+		rule := JoinRule{Match: strings.ToLower(fields[0]), Action: JoinAction(strings.ToLower(fields[1])), Command: fields[2:]}
+		switch rule.Action {
+		case JoinApp, JoinCopy:
+			if len(rule.Command) > 0 {
+				return nil, fmt.Errorf("join rule %q: only browser takes a command", spec)
+			}
+		case JoinBrowser:
+		default:
+			return nil, fmt.Errorf("join rule %q: unknown action %q, expected app, browser, or copy", spec, fields[1])
+		}
+		if len(rule.Command) == 0 {
+			rule.Command = nil
+		}
+		rules = append(rules, rule)
+	}
+	return rules, nil
+}
+
+// Matches reports whether the rule matches the meeting at the URL. The meeting's provider
+// is found from the URL if it isn't known, such as for meetings from your history.
+func (r JoinRule) Matches(meeting Meeting, u *url.URL) bool {
+	if strings.Contains(r.Match, ".") {
+		link := u
+		if meeting.JoinURL != nil {
+			link = meeting.JoinURL
+		}
+		if link == nil {
+			return false
+		}
+		host := strings.ToLower(link.Hostname())
+		return host == r.Match || strings.HasSuffix(host, "."+r.Match)
+	}
+
+	provider := meeting.Provider
+	if provider == "" && u != nil {
+		provider = providerOfURL(u)
+	}
+	if provider == "" {
+		return false
+	}
+	if normalizeProviderName(provider) == normalizeProviderName(r.Match) {
+		return true
+	}
+	words := strings.Fields(strings.ToLower(provider))
+	return len(words) > 0 && words[len(words)-1] == r.Match
+}
+
+// MatchJoinRule returns the first of the rules which matches the meeting at the URL.
+func MatchJoinRule(rules []JoinRule, meeting Meeting, u *url.URL) (JoinRule, bool) {
+	for _, rule := range rules {
+		if rule.Matches(meeting, u) {
+			return rule, true
+		}
+	}
+	return JoinRule{}, false
+}
+
+// providerOfURL returns the name of the provider of AllProviders the URL belongs to, or
+// "" if it belongs to none of them.
+func providerOfURL(u *url.URL) string {
+	if u.Scheme == "zoommtg" {
+		return ZoomProvider.Name()
+	}
+	if _, provider, ok := matchLink(u.String(), AllProviders); ok {
+		return provider.Name()
+	}
+	return ""
+}
+
+// splitCommandLine splits the text into fields at spaces, keeping spaces within single or
+// double quotes, which are removed.
+func splitCommandLine(text string) ([]string, error) {
+	var fields []string
+	var field strings.Builder
+	inField := false
+	var quote rune
+	for _, c := range text {
+		switch {
+		case quote != 0 && c == quote:
+			quote = 0
+		case quote != 0:
+			field.WriteRune(c)
+		case c == '"' || c == '\'':
+			quote, inField = c, true
+		case c == ' ' || c == '\t':
+			if inField {
+				fields = append(fields, field.String())
+				field.Reset()
+				inField = false
+			}
+		default:
+			field.WriteRune(c)
+			inField = true
+		}
+	}
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote", quote)
+	}
+	if inField {
+		fields = append(fields, field.String())
+	}
+	return fields, nil
+}
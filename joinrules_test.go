@@ -0,0 +1,56 @@
+package zoom
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseJoinRules(t *testing.T) {
+	rules, err := ParseJoinRules([]string{
+		"zoom.us app",
+		"Teams COPY",
+		`meet.google.com browser open -na "Google Chrome" --args --profile-directory=Work`,
+		"webex browser",
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []JoinRule{
+		{Match: "zoom.us", Action: JoinApp},
+		{Match: "teams", Action: JoinCopy},
+		{Match: "meet.google.com", Action: JoinBrowser, Command: []string{"open", "-na", "Google Chrome", "--args", "--profile-directory=Work"}},
+		{Match: "webex", Action: JoinBrowser},
+	}, rules)
+
+	_, err = ParseJoinRules([]string{"zoom.us launch"})
+	assert.EqualError(t, err, `join rule "zoom.us launch": unknown action "launch", expected app, browser, or copy`)
+	_, err = ParseJoinRules([]string{"teams copy xclip"})
+	assert.EqualError(t, err, `join rule "teams copy xclip": only browser takes a command`)
+	_, err = ParseJoinRules([]string{`meet browser "Google Chrome`})
+	assert.Error(t, err)
+}
+
+func TestJoinRule_Matches(t *testing.T) {
+	zoomURL, _ := url.Parse("https://jithub.zoom.us/j/12345")
+	teamsURL, _ := url.Parse("https://teams.microsoft.com/l/meetup-join/19%3ameeting_abc")
+	zoomMeeting := Meeting{Provider: "Zoom", JoinURL: zoomURL}
+
+	assert.True(t, JoinRule{Match: "zoom.us"}.Matches(zoomMeeting, zoomURL), "subdomains match")
+	assert.False(t, JoinRule{Match: "us.zoom.us"}.Matches(zoomMeeting, zoomURL))
+	assert.True(t, JoinRule{Match: "zoom"}.Matches(zoomMeeting, zoomURL))
+	assert.True(t, JoinRule{Match: "teams"}.Matches(Meeting{Provider: "Microsoft Teams"}, teamsURL), "the last word of the provider's name matches")
+	assert.True(t, JoinRule{Match: "google-meet"}.Matches(Meeting{Provider: "Google Meet"}, nil))
+	assert.False(t, JoinRule{Match: "meet"}.Matches(zoomMeeting, zoomURL))
+
+	// Meetings from your history only have a URL.
+	assert.True(t, JoinRule{Match: "teams"}.Matches(Meeting{}, teamsURL))
+	deepLink, _ := url.Parse("zoommtg://zoom.us/join?confno=12345")
+	assert.True(t, JoinRule{Match: "zoom"}.Matches(Meeting{}, deepLink))
+
+	rule, ok := MatchJoinRule([]JoinRule{{Match: "teams", Action: JoinCopy}, {Match: "zoom.us", Action: JoinApp}, {Match: "zoom", Action: JoinBrowser}}, zoomMeeting, zoomURL)
+	require.True(t, ok)
+	assert.Equal(t, JoinApp, rule.Action, "the first rule to match is used")
+	_, ok = MatchJoinRule(nil, zoomMeeting, zoomURL)
+	assert.False(t, ok)
+}
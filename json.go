@@ -0,0 +1,131 @@
+package zoom
+
+import (
+	"encoding/json"
+	"io"
+	"time"
+)
+
+// MeetingJSON is the JSON representation of a Meeting, for scripts and other programs.
+// Its field names are stable: fields may be added, but will not be renamed or removed.
+type MeetingJSON struct {
+	ID             string       `json:"id"`
+	Title          string       `json:"title"`
+	Start          string       `json:"start,omitempty"`
+	End            string       `json:"end,omitempty"`
+	HumanizedStart string       `json:"humanized_start,omitempty"`
+	InProgress     bool         `json:"in_progress"`
+	AllDay         bool         `json:"all_day,omitempty"`
+	JoinURL        string       `json:"join_url,omitempty"`
+	DeepLink       string       `json:"deep_link,omitempty"`
+	Passcode       string       `json:"passcode,omitempty"`
+	Provider       string       `json:"provider,omitempty"`
+	Organizer      *PersonJSON  `json:"organizer,omitempty"`
+	CalendarURL    string       `json:"calendar_url,omitempty"`
+	Attendees      int          `json:"attendees"`
+	Accepted       int          `json:"accepted"`
+	MyResponse     string       `json:"my_response,omitempty"`
+	DialIns        []DialInJSON `json:"dial_ins,omitempty"`
+	Account        string       `json:"account,omitempty"`
+}
+
+// DialInJSON is the JSON representation of a DialIn.
+type DialInJSON struct {
+	Number      string `json:"number"`
+	RegionCode  string `json:"region_code,omitempty"`
+	CountryCode string `json:"country_code,omitempty"`
+	AccessCode  string `json:"access_code,omitempty"`
+	Passcode    string `json:"passcode,omitempty"`
+	TelURL      string `json:"tel_url,omitempty"`
+}
+
+// PersonJSON is the JSON representation of a Person.
+type PersonJSON struct {
+	Name  string `json:"name,omitempty"`
+	Email string `json:"email,omitempty"`
+}
+
+// RoomStatusJSON is the JSON representation of a RoomStatus.
+type RoomStatusJSON struct {
+	Free    bool         `json:"free"`
+	Until   string       `json:"until,omitempty"`
+	Current *MeetingJSON `json:"current"`
+	Next    *MeetingJSON `json:"next"`
+}
+
+// NewMeetingJSON returns the JSON representation of the meeting as of now. Times are in RFC 3339 format.
+func NewMeetingJSON(meeting Meeting, now time.Time) MeetingJSON {
+	out := MeetingJSON{
+		ID:          meeting.ID,
+		Title:       meeting.Title,
+		JoinURL:     urlString(meeting.JoinURL),
+		DeepLink:    urlString(meeting.DeepLink),
+		Passcode:    meeting.Passcode,
+		Provider:    meeting.Provider,
+		CalendarURL: meeting.CalendarURL,
+		Attendees:   len(meeting.Attendees),
+		Accepted:    len(meeting.AttendeesWithResponse(ResponseAccepted)),
+		MyResponse:  meeting.MyResponse(),
+		Account:     meeting.Account,
+		AllDay:      meeting.AllDay,
+	}
+	if !meeting.Start.IsZero() {
+		out.Start = meeting.Start.Format(time.RFC3339)
+		out.HumanizedStart = Formatter.RelativeTime(meeting.Start, now)
+		out.InProgress = !meeting.Start.After(now) && (meeting.End.IsZero() || now.Before(meeting.End))
+	}
+	if !meeting.End.IsZero() {
+		out.End = meeting.End.Format(time.RFC3339)
+	}
+	for _, dialIn := range meeting.DialIns {
+		out.DialIns = append(out.DialIns, DialInJSON{
+			Number:      dialIn.Number,
+			RegionCode:  dialIn.RegionCode,
+			CountryCode: dialIn.CountryCode,
+			AccessCode:  dialIn.AccessCode,
+			Passcode:    dialIn.Passcode,
+			TelURL:      urlString(dialIn.TelURL()),
+		})
+	}
+	if meeting.Organizer != (Person{}) {
+		out.Organizer = &PersonJSON{Name: meeting.Organizer.Name, Email: meeting.Organizer.Email}
+	}
+	return out
+}
+
+// WriteMeetingJSON writes the meeting to w as a line of JSON. A nil meeting is written as null.
+func WriteMeetingJSON(w io.Writer, meeting *Meeting) error {
+	var out *MeetingJSON
+	if meeting != nil {
+		m := NewMeetingJSON(*meeting, time.Now())
+		out = &m
+	}
+	return json.NewEncoder(w).Encode(out)
+}
+
+// WriteMeetingsJSON writes the meetings to w as a line containing a JSON array.
+func WriteMeetingsJSON(w io.Writer, meetings []Meeting) error {
+	now := time.Now()
+	out := make([]MeetingJSON, 0, len(meetings))
+	for _, meeting := range meetings {
+		out = append(out, NewMeetingJSON(meeting, now))
+	}
+	return json.NewEncoder(w).Encode(out)
+}
+
+// WriteRoomStatusJSON writes the room's status as of now to w as a line of JSON.
+func WriteRoomStatusJSON(w io.Writer, status RoomStatus, now time.Time) error {
+	out := RoomStatusJSON{Free: status.Free()}
+	if !status.Until.IsZero() {
+		out.Until = status.Until.Format(time.RFC3339)
+	}
+	if status.Current != nil {
+		current := NewMeetingJSON(*status.Current, now)
+		out.Current = &current
+	}
+	if status.Next != nil {
+		next := NewMeetingJSON(*status.Next, now)
+		out.Next = &next
+	}
+	return json.NewEncoder(w).Encode(out)
+}
@@ -0,0 +1,78 @@
+package zoom
+
+import (
+	"bytes"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewMeetingJSON(t *testing.T) {
+	now := time.Date(2018, 10, 10, 9, 55, 0, 0, time.UTC)
+	joinURL, _ := url.Parse("https://jithub.zoom.us/j/12345")
+	deepLink, _ := url.Parse("zoommtg://zoom.us/join?confno=12345")
+
+	out := NewMeetingJSON(Meeting{
+		ID:        "standup",
+		Title:     "Standup",
+		Start:     time.Date(2018, 10, 10, 10, 0, 0, 0, time.UTC),
+		End:       time.Date(2018, 10, 10, 10, 15, 0, 0, time.UTC),
+		Organizer: Person{Name: "Parker Moore", Email: "parkr@jithub.com"},
+		JoinURL:   joinURL,
+		DeepLink:  deepLink,
+		Provider:  "Zoom",
+		Account:   "work",
+		DialIns:   []DialIn{{Number: "+1 646 558 8656", RegionCode: "US", CountryCode: "1", AccessCode: "12345"}},
+		Attendees: []Attendee{
+			{Person: Person{Email: "parkr@jithub.com"}, ResponseStatus: ResponseAccepted, Self: true},
+			{Person: Person{Email: "mona@jithub.com"}, ResponseStatus: ResponseNeedsAction},
+		},
+	}, now)
+
+	assert.Equal(t, MeetingJSON{
+		ID:             "standup",
+		Title:          "Standup",
+		Start:          "2018-10-10T10:00:00Z",
+		End:            "2018-10-10T10:15:00Z",
+		HumanizedStart: "5 minutes from now",
+		JoinURL:        "https://jithub.zoom.us/j/12345",
+		DeepLink:       "zoommtg://zoom.us/join?confno=12345",
+		Provider:       "Zoom",
+		Account:        "work",
+		Organizer:      &PersonJSON{Name: "Parker Moore", Email: "parkr@jithub.com"},
+		Attendees:      2,
+		Accepted:       1,
+		MyResponse:     "accepted",
+		DialIns: []DialInJSON{
+			{Number: "+1 646 558 8656", RegionCode: "US", CountryCode: "1", AccessCode: "12345", TelURL: "tel:+16465588656,,12345%23"},
+		},
+	}, out)
+
+	assert.True(t, NewMeetingJSON(Meeting{Start: now.Add(-time.Minute)}, now).InProgress)
+}
+
+func TestWriteMeetingJSON(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, WriteMeetingJSON(&buf, &Meeting{ID: "standup", Title: "Standup"}))
+	assert.Equal(t, `{"id":"standup","title":"Standup","in_progress":false,"attendees":0,"accepted":0}`+"\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteMeetingJSON(&buf, nil))
+	assert.Equal(t, "null\n", buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteMeetingsJSON(&buf, nil))
+	assert.Equal(t, "[]\n", buf.String())
+}
+
+func TestWriteRoomStatusJSON(t *testing.T) {
+	now := time.Date(2018, 10, 10, 10, 0, 0, 0, time.UTC)
+	next := Meeting{ID: "lunch", Title: "Lunch", Start: now.Add(2 * time.Hour)}
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteRoomStatusJSON(&buf, RoomStatus{Next: &next, Until: next.Start}, now))
+	assert.Equal(t, `{"free":true,"until":"2018-10-10T12:00:00Z","current":null,"next":{"id":"lunch","title":"Lunch","start":"2018-10-10T12:00:00Z","humanized_start":"2 hours from now","in_progress":false,"attendees":0,"accepted":0}}`+"\n", buf.String())
+}
@@ -0,0 +1,74 @@
+package zoom
+
+import (
+	"net/url"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// MeetingLink is one of the links an event can be joined by, such as its Zoom link or a
+// backup Google Meet link.
+type MeetingLink struct {
+	// Provider is the name of the service the link belongs to, e.g. "Zoom".
+	Provider string
+
+	// JoinURL is the link's web URL.
+	JoinURL *url.URL
+
+	// DeepLink opens the meeting in the provider's app, or is nil if there is no app or
+	// the meeting's ID isn't known. Zoom deep links include the passcode, if one was found.
+	DeepLink *url.URL
+
+	// Field is where in the event the link was found, e.g. FieldDescription.
+	Field string
+
+	// Confidence is how likely the link is the one to join by, from 0 to 100.
+	Confidence int
+}
+
+// URL returns the link's web URL or deep link, according to the options.
+func (l MeetingLink) URL(opts URLOptions) *url.URL {
+	return opts.choose(l.JoinURL, l.DeepLink)
+}
+
+// MeetingURLsFromEvent returns every link of any of the AllProviders the event can be
+// joined by, so you can offer a choice between them. The first is the best guess, the
+// link MeetingFromEvent joins by, and the rest are in order of confidence.
+func MeetingURLsFromEvent(event *calendar.Event) []MeetingLink {
+	return MeetingURLsFromEventWithProviders(event, AllProviders)
+}
+
+// MeetingURLsFromEventWithProviders is like MeetingURLsFromEvent, but only finds links of
+// the providers. If none are given, only Zoom links are found.
+func MeetingURLsFromEventWithProviders(event *calendar.Event, providers []Provider) []MeetingLink {
+	if event == nil {
+		return nil
+	}
+
+	var links []MeetingLink
+	best := MeetingFromEvent(event, providers)
+	for _, candidate := range MeetingURLCandidates(event, providers) {
+		link := MeetingLink{
+			Provider:   candidate.Provider.Name(),
+			JoinURL:    candidate.URL,
+			Field:      candidate.Field,
+			Confidence: candidate.Confidence,
+		}
+		switch {
+		case best.JoinURL != nil && candidate.URL.String() == best.JoinURL.String():
+			link.DeepLink = best.DeepLink
+			links = append([]MeetingLink{link}, links...)
+			best.JoinURL = nil
+			continue
+		case candidate.Provider == ZoomProvider:
+			_, deepLink, _ := meetingURLsFromText(candidate.URL.String())
+			link.DeepLink = withPasscode(deepLink, meetingPasscode(event, candidate.URL))
+		default:
+			if linker, ok := candidate.Provider.(DeepLinker); ok {
+				link.DeepLink = linker.DeepLink(candidate.URL)
+			}
+		}
+		links = append(links, link)
+	}
+	return links
+}
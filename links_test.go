@@ -0,0 +1,49 @@
+package zoom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestMeetingURLsFromEvent(t *testing.T) {
+	event := &calendar.Event{
+		HangoutLink: "https://meet.google.com/abc-defg-hij",
+		Description: "Main room: https://jithub.zoom.us/j/12345?pwd=secret\n" +
+			"Breakout: https://jithub.zoom.us/j/67890\n" +
+			"Backup (Teams): https://teams.microsoft.com/l/meetup-join/19%3ameeting_abc).",
+	}
+
+	links := MeetingURLsFromEvent(event)
+	require.Len(t, links, 4)
+
+	// Zoom is the best guess, as MeetingFromEvent prefers it, though the Meet link is
+	// more likely to be the event's own.
+	assert.Equal(t, MeetingLink{
+		Provider:   "Zoom",
+		JoinURL:    MeetingFromEvent(event, AllProviders).JoinURL,
+		DeepLink:   MeetingFromEvent(event, AllProviders).DeepLink,
+		Field:      FieldDescription,
+		Confidence: 60,
+	}, links[0])
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=12345&pwd=secret", links[0].URL(URLOptions{PreferDeepLink: true}).String())
+
+	assert.Equal(t, "Google Meet", links[1].Provider)
+	assert.Nil(t, links[1].DeepLink)
+	assert.Equal(t, "https://meet.google.com/abc-defg-hij", links[1].URL(URLOptions{PreferDeepLink: true}).String())
+
+	assert.Equal(t, "https://jithub.zoom.us/j/67890", links[2].JoinURL.String())
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=67890", links[2].DeepLink.String())
+
+	assert.Equal(t, "Microsoft Teams", links[3].Provider)
+	assert.Equal(t, "msteams://teams.microsoft.com/l/meetup-join/19%3ameeting_abc", links[3].DeepLink.String())
+
+	zoomLinks := MeetingURLsFromEventWithProviders(event, nil)
+	require.Len(t, zoomLinks, 2)
+	assert.Equal(t, "https://jithub.zoom.us/j/12345?pwd=secret", zoomLinks[0].JoinURL.String())
+
+	assert.Empty(t, MeetingURLsFromEvent(&calendar.Event{Location: "Boardroom"}))
+	assert.Nil(t, MeetingURLsFromEvent(nil))
+}
@@ -0,0 +1,22 @@
+package zoom
+
+// Logger receives debug messages explaining how the next meeting was chosen, such as why
+// an event was skipped, which provider's link was found, and when calendar requests are
+// retried. Each message is followed by alternating keys and values, so a *slog.Logger
+// can be used directly.
+type Logger interface {
+	Debug(msg string, args ...interface{})
+}
+
+// nopLogger discards every message.
+type nopLogger struct{}
+
+func (nopLogger) Debug(msg string, args ...interface{}) {}
+
+// logger returns the options' logger, which discards messages if none was set.
+func (o Options) logger() Logger {
+	if o.Logger == nil {
+		return nopLogger{}
+	}
+	return o.Logger
+}
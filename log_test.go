@@ -0,0 +1,52 @@
+package zoom
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// recordingLogger records each message with its keys and values, e.g. "skipping event reason=too long".
+type recordingLogger struct {
+	messages []string
+}
+
+func (l *recordingLogger) Debug(msg string, args ...interface{}) {
+	for i := 0; i+1 < len(args); i += 2 {
+		msg += fmt.Sprintf(" %v=%v", args[i], args[i+1])
+	}
+	l.messages = append(l.messages, msg)
+}
+
+func TestNextEventWithOptions_Logger(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testLongEventResponse)
+	})
+
+	logger := &recordingLogger{}
+	event, err := NextEventWithOptions(service, Options{Logger: logger, Providers: []Provider{GoogleMeetProvider}})
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, []string{
+		"no meeting link found id= summary=Conference hold",
+		"no meeting link found id= summary=Standup",
+		"no event has a meeting link, choosing the first id= summary=Conference hold",
+	}, logger.messages)
+
+	logger = &recordingLogger{}
+	_, err = NextEventWithOptions(service, Options{MaxMeetingDuration: 4 * time.Hour, Logger: logger})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"skipping event id= summary=Conference hold reason=too long",
+		"chose event with a meeting link id= summary=Standup provider=Zoom",
+	}, logger.messages)
+}
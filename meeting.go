@@ -0,0 +1,207 @@
+package zoom
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// Meeting is a scheduled meeting, independent of the calendar it came from.
+type Meeting struct {
+	// ID identifies the meeting within its calendar.
+	ID string
+
+	// Title is the name of the meeting.
+	Title string
+
+	// Start is when the meeting starts. It is the zero time if unknown.
+	Start time.Time
+
+	// End is when the meeting ends. It is the zero time if unknown.
+	End time.Time
+
+	// Organizer is the person who organized the meeting.
+	Organizer Person
+
+	// JoinURL is the web URL used to join the meeting, if one was found.
+	JoinURL *url.URL
+
+	// DeepLink is the URL which opens the meeting directly in the provider's native app, if
+	// known, e.g. zoommtg:// for Zoom or msteams:// for Microsoft Teams.
+	DeepLink *url.URL
+
+	// Passcode is the meeting passcode, if one was found.
+	Passcode string
+
+	// Provider is the name of the video-conferencing service, e.g. "Zoom".
+	Provider string
+
+	// DialIns are the telephone numbers which can be used to join the meeting.
+	DialIns []DialIn
+
+	// Attendees are the people invited to the meeting.
+	Attendees []Attendee
+
+	// CalendarURL links to the meeting in the calendar's web interface.
+	CalendarURL string
+
+	// SeriesID is the ID of the recurring series the meeting is an instance of, if any.
+	SeriesID string
+
+	// Series describes the recurring series the meeting is an instance of. It is only
+	// set by sources with Options.DescribeSeries.
+	Series *Series
+
+	// Conflicting is true if the meeting overlaps another meeting, as marked by TodayAgenda.
+	Conflicting bool
+
+	// Reminders are how long before the meeting starts its pop-up reminders are set in
+	// Google Calendar, earliest first. It is nil if they aren't known, and empty if the
+	// meeting has none.
+	Reminders []time.Duration
+
+	// AllDay is true if the event lasts all day, with dates rather than times. Start and
+	// End are then midnight at the start and end of its days.
+	AllDay bool
+
+	// Account is the name of the account the meeting came from, when the meetings of
+	// several accounts are merged by an AccountsSource.
+	Account string
+
+	// Zoom are the meeting's details from the Zoom API, if they were looked up, such as
+	// with the zoomapi package.
+	Zoom *ZoomDetails
+}
+
+// ZoomDetails are details of a Zoom meeting which the calendar event doesn't contain.
+type ZoomDetails struct {
+	// Topic is the meeting's name in Zoom, which may differ from the event's title.
+	Topic string
+
+	// HostEmail is the email of the meeting's host.
+	HostEmail string
+
+	// Duration is how long the meeting is scheduled to last in Zoom.
+	Duration time.Duration
+
+	// WaitingRoom is true if participants wait to be admitted by the host.
+	WaitingRoom bool
+
+	// StartURL starts the meeting as its host. It is only set if you are the host.
+	StartURL *url.URL
+}
+
+// Person is someone involved in a meeting.
+type Person struct {
+	Name  string
+	Email string
+}
+
+// Attendee is a person invited to a meeting.
+type Attendee struct {
+	Person
+
+	// ResponseStatus is one of ResponseNeedsAction, ResponseDeclined, ResponseTentative,
+	// or ResponseAccepted.
+	ResponseStatus string
+
+	// Optional is true if the attendee's presence is not required.
+	Optional bool
+
+	// Self is true if the attendee is the owner of the calendar.
+	Self bool
+}
+
+// MeetingFromEvent converts a Google Calendar event into a Meeting, using the first
+// provider which matches the event to find the join URL. If no providers are given,
+// only Zoom links are recognized.
+func MeetingFromEvent(event *calendar.Event, providers []Provider) Meeting {
+	if event == nil {
+		return Meeting{}
+	}
+
+	meeting := Meeting{
+		ID:          event.Id,
+		Title:       event.Summary,
+		CalendarURL: event.HtmlLink,
+		SeriesID:    event.RecurringEventId,
+	}
+
+	if startTime, err := MeetingStartTime(event); err == nil {
+		meeting.Start = startTime
+	}
+	if endTime, err := MeetingEndTime(event); err == nil {
+		meeting.End = endTime
+	}
+	meeting.AllDay = isAllDay(event)
+	meeting.Reminders, _ = EventReminders(event)
+
+	if event.Organizer != nil {
+		meeting.Organizer = Person{Name: event.Organizer.DisplayName, Email: event.Organizer.Email}
+	} else if event.Creator != nil {
+		meeting.Organizer = Person{Name: event.Creator.DisplayName, Email: event.Creator.Email}
+	}
+
+	meeting.Attendees = attendeesFromEvent(event)
+	meeting.DialIns = DialInsFromEvent(event)
+
+	if len(providers) == 0 {
+		providers = []Provider{ZoomProvider}
+	}
+	if joinURL, deepLink, provider, ok := conferenceURLsFromEvent(event, providers); ok {
+		meeting.Provider = provider.Name()
+		meeting.JoinURL = joinURL
+		meeting.DeepLink = deepLink
+		if provider == ZoomProvider {
+			meeting.Passcode = meetingPasscode(event, meeting.JoinURL)
+		}
+	}
+
+	return meeting
+}
+
+// ZoomMeetingID returns the meeting's numeric Zoom meeting ID, from its deep link or join
+// URL, and false if it isn't a Zoom meeting or its ID isn't known.
+func (m Meeting) ZoomMeetingID() (string, bool) {
+	if id, ok := ZoomMeetingID(m.DeepLink); ok {
+		return id, true
+	}
+	return ZoomMeetingID(m.JoinURL)
+}
+
+// URL returns the URL used to join the meeting, choosing between the web URL and the
+// deep link according to the options. It returns nil if the meeting has no join URL.
+// Use JoinURL and DeepLink to get both.
+func (m Meeting) URL(opts URLOptions) *url.URL {
+	return opts.choose(m.JoinURL, m.DeepLink)
+}
+
+// NextMeeting returns the next meeting in the calendars selected by the options, chosen
+// as NextEventWithOptions chooses the next event. ok is false if nothing is scheduled.
+func NextMeeting(service *calendar.Service, opts Options) (meeting Meeting, ok bool, err error) {
+	return NextMeetingContext(context.Background(), service, opts)
+}
+
+// NextMeetingContext is like NextMeeting, but the calendar API calls are bound to the context.
+func NextMeetingContext(ctx context.Context, service *calendar.Service, opts Options) (meeting Meeting, ok bool, err error) {
+	event, err := NextEventContext(ctx, service, opts)
+	if err != nil || event == nil {
+		return Meeting{}, false, err
+	}
+	meeting = MeetingFromEvent(event, opts.providers())
+	opts.resolvePersonalRoom(ctx, &meeting)
+	return meeting, true, nil
+}
+
+// NextMeetings returns the upcoming meetings in the calendars selected by the options,
+// sorted by start time.
+func NextMeetings(service *calendar.Service, opts Options) ([]Meeting, error) {
+	return NextMeetingsContext(context.Background(), service, opts)
+}
+
+// NextMeetingsContext is like NextMeetings, but the calendar API call is bound to the context.
+func NextMeetingsContext(ctx context.Context, service *calendar.Service, opts Options) ([]Meeting, error) {
+	return NewGoogleCalendarSource(service, opts).UpcomingEvents(ctx, opts.window(time.Now()))
+}
@@ -0,0 +1,157 @@
+package zoom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestMeetingFromEvent(t *testing.T) {
+	meeting := MeetingFromEvent(&calendar.Event{
+		Id:       "abc",
+		Summary:  "I am a video call",
+		HtmlLink: "lalala",
+		Location: "https://jithub.zoom.us/j/12345?pwd=secret",
+		Start:    &calendar.EventDateTime{DateTime: "2018-10-10T17:30:00-07:00"},
+		End:      &calendar.EventDateTime{DateTime: "2018-10-10T18:00:00-07:00"},
+		Creator:  &calendar.EventCreator{DisplayName: "Parker Moore", Email: "parkr@jithub.com"},
+		Organizer: &calendar.EventOrganizer{
+			DisplayName: "Kevin Jithub",
+			Email:       "kevin@jithub.com",
+		},
+		Attendees: []*calendar.EventAttendee{
+			{DisplayName: "Parker Moore", Email: "parkr@jithub.com", ResponseStatus: "accepted", Self: true},
+			{Email: "mona@jithub.com", ResponseStatus: "tentative", Optional: true},
+		},
+	}, nil)
+
+	assert.Equal(t, "abc", meeting.ID)
+	assert.Equal(t, "I am a video call", meeting.Title)
+	assert.Equal(t, "lalala", meeting.CalendarURL)
+	assert.True(t, meeting.Start.Equal(time.Date(2018, 10, 11, 0, 30, 0, 0, time.UTC)))
+	assert.Equal(t, 30*time.Minute, meeting.End.Sub(meeting.Start))
+	assert.Equal(t, Person{Name: "Kevin Jithub", Email: "kevin@jithub.com"}, meeting.Organizer)
+	assert.Equal(t, "Zoom", meeting.Provider)
+	assert.Equal(t, "https://jithub.zoom.us/j/12345?pwd=secret", meeting.JoinURL.String())
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=12345&pwd=secret", meeting.DeepLink.String())
+	assert.Equal(t, "secret", meeting.Passcode)
+	assert.Equal(t, []Attendee{
+		{Person: Person{Name: "Parker Moore", Email: "parkr@jithub.com"}, ResponseStatus: "accepted", Self: true},
+		{Person: Person{Email: "mona@jithub.com"}, ResponseStatus: "tentative", Optional: true},
+	}, meeting.Attendees)
+}
+
+func TestMeetingFromEvent_OtherProviders(t *testing.T) {
+	event := &calendar.Event{HangoutLink: "https://meet.google.com/abc-defg-hij"}
+
+	meeting := MeetingFromEvent(event, nil)
+	assert.Nil(t, meeting.JoinURL)
+	assert.Equal(t, "", meeting.Provider)
+
+	meeting = MeetingFromEvent(event, AllProviders)
+	assert.Equal(t, "Google Meet", meeting.Provider)
+	assert.Equal(t, "https://meet.google.com/abc-defg-hij", meeting.JoinURL.String())
+	assert.Nil(t, meeting.DeepLink)
+
+	meeting = MeetingFromEvent(&calendar.Event{
+		Description: "Join Microsoft Teams Meeting <https://teams.microsoft.com/l/meetup-join/19%3ameeting_abc%40thread.v2/0?context=%7b%7d>",
+	}, AllProviders)
+	assert.Equal(t, "Microsoft Teams", meeting.Provider)
+	assert.Equal(t, "https://teams.microsoft.com/l/meetup-join/19%3ameeting_abc%40thread.v2/0?context=%7b%7d", meeting.JoinURL.String())
+	assert.Equal(t, "msteams://teams.microsoft.com/l/meetup-join/19%3ameeting_abc%40thread.v2/0?context=%7b%7d", meeting.DeepLink.String())
+	assert.Equal(t, meeting.DeepLink, meeting.URL(URLOptions{PreferDeepLink: true}))
+	assert.Equal(t, meeting.JoinURL, meeting.URL(URLOptions{}))
+}
+
+func TestMeetingFromEvent_Nil(t *testing.T) {
+	assert.Equal(t, Meeting{}, MeetingFromEvent(nil, nil))
+}
+
+func TestNextMeetings(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testEventResponse)
+	})
+
+	meetings, err := NextMeetings(service, Options{})
+	require.NoError(t, err)
+	require.Len(t, meetings, 2)
+	assert.Equal(t, "I am an in-person meeting", meetings[0].Title)
+	assert.Nil(t, meetings[0].JoinURL)
+	assert.Equal(t, "I am a video call", meetings[1].Title)
+	assert.Equal(t, "https://jithub.zoom.us/j/12345", meetings[1].JoinURL.String())
+}
+
+func TestNextMeeting(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	response := testEventResponse
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, response)
+	})
+
+	meeting, ok, err := NextMeeting(service, Options{})
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "I am a video call", meeting.Title)
+
+	response = `{"items": []}`
+	meeting, ok, err = NextMeeting(service, Options{})
+	require.NoError(t, err)
+	assert.False(t, ok)
+	assert.Equal(t, Meeting{}, meeting)
+}
+
+func TestNextMeetingFromSource(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("timeMin") != "2018-10-10T00:00:00Z" {
+			fmt.Fprint(w, `{"items": []}`)
+			return
+		}
+		assert.Equal(t, "2018-10-11T00:00:00Z", r.URL.Query().Get("timeMax"))
+		fmt.Fprint(w, testEventResponse)
+	})
+
+	source := NewGoogleCalendarSource(service, Options{})
+	meeting, err := NextMeetingFromSource(context.Background(), source, Window{
+		Start: time.Date(2018, 10, 10, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2018, 10, 11, 0, 0, 0, 0, time.UTC),
+	})
+	require.NoError(t, err)
+	require.NotNil(t, meeting)
+	assert.Equal(t, "I am a video call", meeting.Title)
+
+	meeting, err = NextMeetingFromSource(context.Background(), source, Window{
+		Start: time.Date(2018, 10, 12, 0, 0, 0, 0, time.UTC),
+		End:   time.Date(2018, 10, 13, 0, 0, 0, 0, time.UTC),
+	})
+	assert.True(t, errors.Is(err, ErrNoUpcomingEvents))
+	assert.Nil(t, meeting)
+}
+
+func TestMeetingURL(t *testing.T) {
+	meeting := MeetingFromEvent(&calendar.Event{Location: "https://jithub.zoom.us/j/12345"}, nil)
+	assert.Equal(t, "https://jithub.zoom.us/j/12345", meeting.URL(URLOptions{}).String())
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=12345", meeting.URL(URLOptions{PreferDeepLink: true}).String())
+
+	assert.Nil(t, Meeting{}.URL(URLOptions{PreferDeepLink: true}))
+}
@@ -0,0 +1,186 @@
+// Package metrics records how often the calendar is read and when your meetings are, and
+// writes them in the Prometheus text exposition format.
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/benbalter/zoom-go"
+)
+
+// ContentType is the content type of the metrics written by Metrics.Write.
+const ContentType = "text/plain; version=0.0.4; charset=utf-8"
+
+// LatencyBuckets are the upper bounds, in seconds, of the calendar request latency histogram.
+var LatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// Metrics collects these metrics:
+//
+//	zoom_seconds_until_next_meeting           gauge, absent if there is no upcoming meeting
+//	zoom_meetings_today                       gauge
+//	zoom_calendar_requests_total              counter
+//	zoom_calendar_request_errors_total        counter
+//	zoom_calendar_request_duration_seconds    histogram
+//	zoom_cache_hits_total                     counter
+//	zoom_cache_misses_total                   counter
+//
+// The zero value is ready to use, and its methods may be called concurrently.
+type Metrics struct {
+	mu sync.Mutex
+
+	nextStart     time.Time
+	meetingsToday int
+
+	requests       uint64
+	requestErrors  uint64
+	latencyCounts  []uint64
+	latencySeconds float64
+
+	cacheHits   uint64
+	cacheMisses uint64
+}
+
+// ObserveMeetings records when the next of the meetings starts and how many of them start
+// today, as of now. The meetings must include all of today's, sorted by start time.
+func (m *Metrics) ObserveMeetings(now time.Time, meetings []zoom.Meeting) {
+	today := zoom.TodayWindow(now)
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.nextStart, m.meetingsToday = time.Time{}, 0
+	for _, meeting := range meetings {
+		if meeting.Start.IsZero() {
+			continue
+		}
+		if !meeting.Start.Before(today.Start) && meeting.Start.Before(today.End) {
+			m.meetingsToday++
+		}
+		if m.nextStart.IsZero() && meeting.Start.After(now) {
+			m.nextStart = meeting.Start
+		}
+	}
+}
+
+// ObserveRequest records a request to the calendar which took d and failed if err isn't nil.
+func (m *Metrics) ObserveRequest(d time.Duration, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.latencyCounts == nil {
+		m.latencyCounts = make([]uint64, len(LatencyBuckets))
+	}
+	m.requests++
+	if err != nil {
+		m.requestErrors++
+	}
+	m.latencySeconds += d.Seconds()
+	for i, bound := range LatencyBuckets {
+		if d.Seconds() <= bound {
+			m.latencyCounts[i]++
+		}
+	}
+}
+
+// CacheHit records that meetings were read from a cache rather than the calendar.
+func (m *Metrics) CacheHit() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cacheHits++
+}
+
+// CacheMiss records that meetings had to be read from the calendar.
+func (m *Metrics) CacheMiss() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.cacheMisses++
+}
+
+// Instrument returns a CalendarSource which records each request to the source.
+func (m *Metrics) Instrument(source zoom.CalendarSource) zoom.CalendarSource {
+	return instrumentedSource{source: source, metrics: m}
+}
+
+// instrumentedSource is a CalendarSource which records each request in its metrics.
+type instrumentedSource struct {
+	source  zoom.CalendarSource
+	metrics *Metrics
+}
+
+func (s instrumentedSource) UpcomingEvents(ctx context.Context, window zoom.Window) ([]zoom.Meeting, error) {
+	start := time.Now()
+	meetings, err := s.source.UpcomingEvents(ctx, window)
+	s.metrics.ObserveRequest(time.Since(start), err)
+	return meetings, err
+}
+
+// Write writes the metrics to w in the Prometheus text exposition format, as of now.
+func (m *Metrics) Write(w io.Writer, now time.Time) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e := &encoder{w: w}
+	e.header("zoom_seconds_until_next_meeting", "gauge", "Seconds until the next meeting starts.")
+	if !m.nextStart.IsZero() {
+		e.sample("zoom_seconds_until_next_meeting", "", m.nextStart.Sub(now).Seconds())
+	}
+	e.header("zoom_meetings_today", "gauge", "Number of meetings which start today.")
+	e.sample("zoom_meetings_today", "", float64(m.meetingsToday))
+
+	e.header("zoom_calendar_requests_total", "counter", "Requests to the calendar.")
+	e.sample("zoom_calendar_requests_total", "", float64(m.requests))
+	e.header("zoom_calendar_request_errors_total", "counter", "Requests to the calendar which failed.")
+	e.sample("zoom_calendar_request_errors_total", "", float64(m.requestErrors))
+
+	e.header("zoom_calendar_request_duration_seconds", "histogram", "How long requests to the calendar took.")
+	for i, bound := range LatencyBuckets {
+		var count uint64
+		if m.latencyCounts != nil {
+			count = m.latencyCounts[i]
+		}
+		e.sample("zoom_calendar_request_duration_seconds_bucket", `le="`+formatFloat(bound)+`"`, float64(count))
+	}
+	e.sample("zoom_calendar_request_duration_seconds_bucket", `le="+Inf"`, float64(m.requests))
+	e.sample("zoom_calendar_request_duration_seconds_sum", "", m.latencySeconds)
+	e.sample("zoom_calendar_request_duration_seconds_count", "", float64(m.requests))
+
+	e.header("zoom_cache_hits_total", "counter", "Reads of meetings which were served from the cache.")
+	e.sample("zoom_cache_hits_total", "", float64(m.cacheHits))
+	e.header("zoom_cache_misses_total", "counter", "Reads of meetings which had to request the calendar.")
+	e.sample("zoom_cache_misses_total", "", float64(m.cacheMisses))
+
+	return e.err
+}
+
+// encoder writes lines of the text exposition format, remembering the first error.
+type encoder struct {
+	w   io.Writer
+	err error
+}
+
+func (e *encoder) header(name, kind, help string) {
+	e.printf("# HELP %s %s\n# TYPE %s %s\n", name, help, name, kind)
+}
+
+func (e *encoder) sample(name, labels string, value float64) {
+	if labels != "" {
+		name += "{" + labels + "}"
+	}
+	e.printf("%s %s\n", name, formatFloat(value))
+}
+
+func (e *encoder) printf(format string, args ...interface{}) {
+	if e.err == nil {
+		_, e.err = fmt.Fprintf(e.w, format, args...)
+	}
+}
+
+// formatFloat formats the value as Prometheus expects, e.g. "0.25", "3", or "+Inf".
+func formatFloat(value float64) string {
+	return strconv.FormatFloat(value, 'g', -1, 64)
+}
@@ -0,0 +1,77 @@
+package metrics
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benbalter/zoom-go"
+)
+
+type fakeSource struct {
+	err error
+}
+
+func (s fakeSource) UpcomingEvents(ctx context.Context, window zoom.Window) ([]zoom.Meeting, error) {
+	return nil, s.err
+}
+
+func TestMetrics(t *testing.T) {
+	defer func(location *time.Location) { zoom.Location = location }(zoom.Location)
+	zoom.Location = time.UTC
+
+	now := time.Date(2018, 10, 11, 9, 0, 0, 0, time.UTC)
+	m := &Metrics{}
+	m.ObserveMeetings(now, []zoom.Meeting{
+		{Title: "Yesterday", Start: now.Add(-24 * time.Hour)},
+		{Title: "Breakfast", Start: now.Add(-time.Hour)},
+		{Title: "Standup", Start: now.Add(5 * time.Minute)},
+		{Title: "Lunch", Start: now.Add(3 * time.Hour)},
+		{Title: "Tomorrow", Start: now.Add(24 * time.Hour)},
+	})
+	m.ObserveRequest(200*time.Millisecond, nil)
+	m.ObserveRequest(3*time.Second, errors.New("calendar is unreachable"))
+	m.CacheHit()
+	m.CacheHit()
+	m.CacheMiss()
+
+	var out bytes.Buffer
+	require.NoError(t, m.Write(&out, now))
+	assert.Contains(t, out.String(), "# TYPE zoom_seconds_until_next_meeting gauge\nzoom_seconds_until_next_meeting 300\n")
+	assert.Contains(t, out.String(), "\nzoom_meetings_today 3\n")
+	assert.Contains(t, out.String(), "\nzoom_calendar_requests_total 2\n")
+	assert.Contains(t, out.String(), "\nzoom_calendar_request_errors_total 1\n")
+	assert.Contains(t, out.String(), "# TYPE zoom_calendar_request_duration_seconds histogram\n")
+	assert.Contains(t, out.String(), `zoom_calendar_request_duration_seconds_bucket{le="0.1"} 0`+"\n")
+	assert.Contains(t, out.String(), `zoom_calendar_request_duration_seconds_bucket{le="0.25"} 1`+"\n")
+	assert.Contains(t, out.String(), `zoom_calendar_request_duration_seconds_bucket{le="5"} 2`+"\n")
+	assert.Contains(t, out.String(), `zoom_calendar_request_duration_seconds_bucket{le="+Inf"} 2`+"\n")
+	assert.Contains(t, out.String(), "\nzoom_calendar_request_duration_seconds_sum 3.2\n")
+	assert.Contains(t, out.String(), "\nzoom_calendar_request_duration_seconds_count 2\n")
+	assert.Contains(t, out.String(), "\nzoom_cache_hits_total 2\n")
+	assert.Contains(t, out.String(), "\nzoom_cache_misses_total 1\n")
+}
+
+func TestMetrics_NoMeetings(t *testing.T) {
+	var out bytes.Buffer
+	require.NoError(t, (&Metrics{}).Write(&out, time.Now()))
+	assert.NotContains(t, out.String(), "\nzoom_seconds_until_next_meeting ")
+	assert.Contains(t, out.String(), "\nzoom_meetings_today 0\n")
+	assert.Contains(t, out.String(), `zoom_calendar_request_duration_seconds_bucket{le="0.05"} 0`+"\n")
+}
+
+func TestInstrument(t *testing.T) {
+	m := &Metrics{}
+	_, err := m.Instrument(fakeSource{}).UpcomingEvents(context.Background(), zoom.Window{})
+	require.NoError(t, err)
+	_, err = m.Instrument(fakeSource{err: errors.New("calendar is unreachable")}).UpcomingEvents(context.Background(), zoom.Window{})
+	assert.EqualError(t, err, "calendar is unreachable")
+
+	assert.Equal(t, uint64(2), m.requests)
+	assert.Equal(t, uint64(1), m.requestErrors)
+}
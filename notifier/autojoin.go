@@ -0,0 +1,168 @@
+package notifier
+
+import (
+	"context"
+	"time"
+
+	"github.com/benbalter/zoom-go"
+)
+
+const (
+	// DefaultJoinOffset is how long before a meeting starts to join when AutoJoiner.Offset is unset.
+	DefaultJoinOffset = time.Minute
+
+	// DefaultSnooze is how long a snoozed meeting waits when AutoJoiner.SnoozeDuration is unset.
+	DefaultSnooze = time.Minute
+
+	// defaultJoinInterval is how often the calendar is checked when AutoJoiner.Interval is unset.
+	// It is shorter than DefaultInterval so meetings are joined close to the intended time.
+	defaultJoinInterval = 15 * time.Second
+
+	// joinGracePeriod is how long after a meeting starts it will still be joined, if it has no end time.
+	joinGracePeriod = 10 * time.Minute
+)
+
+// JoinDecision tells an AutoJoiner what to do with a meeting which is about to be joined.
+type JoinDecision int
+
+const (
+	// Join opens the meeting now.
+	Join JoinDecision = iota
+
+	// Cancel skips the meeting entirely.
+	Cancel
+
+	// Snooze asks again after the AutoJoiner's SnoozeDuration.
+	Snooze
+)
+
+// AutoJoiner opens each meeting's join URL shortly before it starts.
+type AutoJoiner struct {
+	// Source is the calendar to watch.
+	Source zoom.CalendarSource
+
+	// Offset is how long before a meeting starts to open it. Zero means DefaultJoinOffset.
+	Offset time.Duration
+
+	// SnoozeDuration is how long to wait when BeforeJoin returns Snooze. Zero means DefaultSnooze.
+	SnoozeDuration time.Duration
+
+	// Interval is how often to check the calendar. Zero means every 15 seconds.
+	Interval time.Duration
+
+	// BeforeJoin is called before each meeting is opened, and decides whether to open it.
+	// If nil, every meeting with a join URL is opened.
+	BeforeJoin func(zoom.Meeting) JoinDecision
+
+	// Open opens a meeting. Nil means zoom.Open.
+	Open func(zoom.Meeting) error
+
+	// OnError is called when the calendar cannot be read or a meeting cannot be opened.
+	// The auto-joiner keeps running afterwards. If nil, errors are ignored.
+	OnError func(error)
+
+	handled map[string]time.Time
+	snoozed map[string]time.Time
+}
+
+// Run checks the calendar until the context is done, opening each meeting at most once.
+// It always returns the context's error.
+func (a *AutoJoiner) Run(ctx context.Context) error {
+	interval := a.Interval
+	if interval <= 0 {
+		interval = defaultJoinInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := a.check(ctx, time.Now()); err != nil && ctx.Err() == nil && a.OnError != nil {
+			a.OnError(err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// check opens every meeting which is due to be joined and has not already been handled.
+func (a *AutoJoiner) check(ctx context.Context, now time.Time) error {
+	offset := a.Offset
+	if offset <= 0 {
+		offset = DefaultJoinOffset
+	}
+	snooze := a.SnoozeDuration
+	if snooze <= 0 {
+		snooze = DefaultSnooze
+	}
+	openMeeting := a.Open
+	if openMeeting == nil {
+		openMeeting = zoom.Open
+	}
+	if a.handled == nil {
+		a.handled = map[string]time.Time{}
+		a.snoozed = map[string]time.Time{}
+	}
+
+	meetings, err := a.Source.UpcomingEvents(ctx, zoom.Window{Start: now, End: now.Add(offset)})
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for _, meeting := range meetings {
+		// Which of the meeting's URLs is opened is up to Open.
+		if (meeting.JoinURL == nil && meeting.DeepLink == nil) || !isDue(meeting, now, offset) {
+			continue
+		}
+
+		key := meeting.ID + " " + meeting.Start.String()
+		if _, ok := a.handled[key]; ok {
+			continue
+		}
+		if until, ok := a.snoozed[key]; ok && now.Before(until) {
+			continue
+		}
+
+		decision := Join
+		if a.BeforeJoin != nil {
+			decision = a.BeforeJoin(meeting)
+		}
+
+		switch decision {
+		case Snooze:
+			a.snoozed[key] = now.Add(snooze)
+			continue
+		case Join:
+			if err := openMeeting(meeting); err != nil && firstErr == nil {
+				firstErr = err
+			}
+		}
+		a.handled[key] = meeting.Start
+		delete(a.snoozed, key)
+	}
+
+	// Forget about meetings which are long past so the maps don't grow forever.
+	for key, start := range a.handled {
+		if start.Before(now.Add(-24 * time.Hour)) {
+			delete(a.handled, key)
+		}
+	}
+
+	return firstErr
+}
+
+// isDue returns true if the meeting starts within the offset of now and has not ended yet.
+func isDue(meeting zoom.Meeting, now time.Time, offset time.Duration) bool {
+	if meeting.Start.IsZero() || meeting.Start.Sub(now) > offset {
+		return false
+	}
+	if !meeting.End.IsZero() {
+		return now.Before(meeting.End)
+	}
+	return now.Sub(meeting.Start) < joinGracePeriod
+}
@@ -0,0 +1,76 @@
+package notifier
+
+import (
+	"context"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benbalter/zoom-go"
+)
+
+func TestAutoJoinerCheck(t *testing.T) {
+	now := time.Now()
+	standupURL, _ := url.Parse("zoommtg://zoom.us/join?confno=1")
+	planningURL, _ := url.Parse("zoommtg://zoom.us/join?confno=2")
+	retroURL, _ := url.Parse("zoommtg://zoom.us/join?confno=3")
+	endedURL, _ := url.Parse("zoommtg://zoom.us/join?confno=4")
+
+	source := &fakeSource{meetings: []zoom.Meeting{
+		{ID: "standup", Start: now.Add(30 * time.Second), DeepLink: standupURL},
+		{ID: "planning", Start: now.Add(10 * time.Minute), DeepLink: planningURL},
+		{ID: "retro", Start: now.Add(-time.Minute), End: now.Add(time.Hour), DeepLink: retroURL},
+		{ID: "ended", Start: now.Add(-time.Hour), End: now.Add(-time.Minute), DeepLink: endedURL},
+		{ID: "in-person", Start: now},
+	}}
+
+	var opened []string
+	decisions := map[string]JoinDecision{"retro": Snooze}
+	a := &AutoJoiner{
+		Source: source,
+		Open: func(meeting zoom.Meeting) error {
+			opened = append(opened, meeting.DeepLink.String())
+			return nil
+		},
+		BeforeJoin: func(meeting zoom.Meeting) JoinDecision {
+			return decisions[meeting.ID]
+		},
+	}
+
+	require.NoError(t, a.check(context.Background(), now))
+	assert.Equal(t, []string{"zoommtg://zoom.us/join?confno=1"}, opened)
+	assert.Equal(t, []zoom.Window{{Start: now, End: now.Add(DefaultJoinOffset)}}, source.windows)
+
+	// The snoozed meeting is not asked about again until the snooze is over.
+	decisions["retro"] = Join
+	require.NoError(t, a.check(context.Background(), now.Add(30*time.Second)))
+	assert.Equal(t, []string{"zoommtg://zoom.us/join?confno=1"}, opened)
+
+	require.NoError(t, a.check(context.Background(), now.Add(DefaultSnooze)))
+	assert.Equal(t, []string{"zoommtg://zoom.us/join?confno=1", "zoommtg://zoom.us/join?confno=3"}, opened)
+}
+
+func TestAutoJoinerCheck_Cancel(t *testing.T) {
+	now := time.Now()
+	u, _ := url.Parse("https://jithub.zoom.us/my/parkr")
+
+	asked := 0
+	a := &AutoJoiner{
+		Source: &fakeSource{meetings: []zoom.Meeting{{ID: "standup", Start: now, JoinURL: u}}},
+		Open: func(zoom.Meeting) error {
+			t.Fatal("cancelled meeting was opened")
+			return nil
+		},
+		BeforeJoin: func(zoom.Meeting) JoinDecision {
+			asked++
+			return Cancel
+		},
+	}
+
+	require.NoError(t, a.check(context.Background(), now))
+	require.NoError(t, a.check(context.Background(), now.Add(time.Minute)))
+	assert.Equal(t, 1, asked)
+}
@@ -0,0 +1,153 @@
+package notifier
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/benbalter/zoom-go"
+)
+
+// errNoMeetingID is returned when muting a meeting which can't be told apart from others.
+var errNoMeetingID = errors.New("the meeting has no ID")
+
+// Mutes are the meetings you don't want to be notified about: single meetings snoozed for
+// a while, and recurring series whose notifications are suppressed for good, such as a
+// standup you never join. They are kept in a file, so the daemon sees meetings muted with
+// other commands. Set Notifier.Mutes to use them.
+type Mutes struct {
+	// Path is the file in which the mutes are kept. If empty, they are only kept in memory.
+	Path string
+
+	mu    sync.Mutex
+	state mutesState
+}
+
+// mutesState is the content of a Mutes' file.
+type mutesState struct {
+	// Snoozed are the IDs of snoozed meetings, and when their snoozes end.
+	Snoozed map[string]time.Time `json:"snoozed,omitempty"`
+
+	// Suppressed are the IDs of the series, or of single meetings, never to notify about.
+	Suppressed map[string]bool `json:"suppressed,omitempty"`
+}
+
+// NewMutes returns Mutes kept in the file at path.
+func NewMutes(path string) *Mutes {
+	return &Mutes{Path: path}
+}
+
+// DefaultMutesPath returns the file in your user config directory, such as
+// $XDG_CONFIG_HOME/zoom-go on Linux, in which mutes are kept.
+func DefaultMutesPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "zoom-go", "mutes.json"), nil
+}
+
+// Snooze mutes the meeting until the time. Its notification is shown once the snooze
+// ends, if the meeting hasn't started more than the notifier's lead time before then.
+func (m *Mutes) Snooze(meeting zoom.Meeting, until time.Time) error {
+	if meeting.ID == "" {
+		return errNoMeetingID
+	}
+	return m.update(func(state *mutesState) {
+		if state.Snoozed == nil {
+			state.Snoozed = map[string]time.Time{}
+		}
+		state.Snoozed[meeting.ID] = until
+	})
+}
+
+// Suppress mutes every meeting in the meeting's recurring series, or only the meeting if
+// it isn't recurring.
+func (m *Mutes) Suppress(meeting zoom.Meeting) error {
+	if suppressionKey(meeting) == "" {
+		return errNoMeetingID
+	}
+	return m.update(func(state *mutesState) {
+		if state.Suppressed == nil {
+			state.Suppressed = map[string]bool{}
+		}
+		state.Suppressed[suppressionKey(meeting)] = true
+	})
+}
+
+// Unmute ends the meeting's snooze, and stops suppressing its series.
+func (m *Mutes) Unmute(meeting zoom.Meeting) error {
+	return m.update(func(state *mutesState) {
+		delete(state.Snoozed, meeting.ID)
+		delete(state.Suppressed, suppressionKey(meeting))
+	})
+}
+
+// Muted returns true if the meeting is snoozed at now, or its series is suppressed.
+func (m *Mutes) Muted(meeting zoom.Meeting, now time.Time) bool {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.load()
+	if key := suppressionKey(meeting); key != "" && m.state.Suppressed[key] {
+		return true
+	}
+	until, ok := m.state.Snoozed[meeting.ID]
+	return ok && meeting.ID != "" && now.Before(until)
+}
+
+// update changes the mutes, forgetting snoozes which have ended, and writes them to the file.
+func (m *Mutes) update(f func(*mutesState)) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.load()
+	f(&m.state)
+	now := time.Now()
+	for id, until := range m.state.Snoozed {
+		if !now.Before(until) {
+			delete(m.state.Snoozed, id)
+		}
+	}
+	if m.Path == "" {
+		return nil
+	}
+
+	b, err := json.Marshal(m.state)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(m.Path), 0700); err != nil {
+		return err
+	}
+	// Write a temporary file and rename it, so the daemon never reads a partial write.
+	tmp := m.Path + ".tmp"
+	if err := os.WriteFile(tmp, b, 0600); err != nil {
+		return err
+	}
+	return os.Rename(tmp, m.Path)
+}
+
+// load reads the mutes from the file, if there is one, since another process may have
+// changed them. A missing or unreadable file means nothing is muted.
+func (m *Mutes) load() {
+	if m.Path == "" {
+		return
+	}
+	m.state = mutesState{}
+	if b, err := os.ReadFile(m.Path); err == nil {
+		json.Unmarshal(b, &m.state)
+	}
+}
+
+// suppressionKey returns the ID suppressed for the meeting: its series' ID, or its own if
+// it isn't recurring.
+func suppressionKey(meeting zoom.Meeting) string {
+	if meeting.SeriesID != "" {
+		return meeting.SeriesID
+	}
+	return meeting.ID
+}
@@ -0,0 +1,65 @@
+package notifier
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benbalter/zoom-go"
+)
+
+func TestMutes(t *testing.T) {
+	now := time.Now()
+	path := filepath.Join(t.TempDir(), "zoom-go", "mutes.json")
+	mutes := NewMutes(path)
+
+	standup := zoom.Meeting{ID: "standup_20181010", SeriesID: "standup", Title: "Standup"}
+	nextStandup := zoom.Meeting{ID: "standup_20181011", SeriesID: "standup", Title: "Standup"}
+	planning := zoom.Meeting{ID: "planning", Title: "Planning"}
+
+	require.NoError(t, mutes.Snooze(planning, now.Add(10*time.Minute)))
+	assert.True(t, mutes.Muted(planning, now))
+	assert.False(t, mutes.Muted(planning, now.Add(10*time.Minute)), "snoozes end")
+
+	require.NoError(t, mutes.Suppress(standup))
+	assert.True(t, mutes.Muted(nextStandup, now), "suppressing a meeting suppresses its series")
+
+	// Mutes are shared through the file.
+	other := NewMutes(path)
+	assert.True(t, other.Muted(planning, now))
+	assert.True(t, other.Muted(standup, now))
+	require.NoError(t, other.Unmute(nextStandup))
+	assert.False(t, mutes.Muted(standup, now))
+
+	assert.EqualError(t, mutes.Snooze(zoom.Meeting{}, now.Add(time.Minute)), "the meeting has no ID")
+	assert.EqualError(t, mutes.Suppress(zoom.Meeting{}), "the meeting has no ID")
+	assert.False(t, mutes.Muted(zoom.Meeting{}, now))
+}
+
+func TestNotifierCheck_Mutes(t *testing.T) {
+	now := time.Now()
+	source := &fakeSource{meetings: []zoom.Meeting{
+		{ID: "standup_1", SeriesID: "standup", Title: "Standup", Start: now.Add(time.Minute)},
+		{ID: "planning", Title: "Planning", Start: now.Add(2 * time.Minute)},
+	}}
+
+	mutes := &Mutes{}
+	require.NoError(t, mutes.Suppress(source.meetings[0]))
+	require.NoError(t, mutes.Snooze(source.meetings[1], now.Add(time.Minute)))
+
+	var titles []string
+	n := &Notifier{Source: source, Mutes: mutes, Notify: func(notification Notification) error {
+		titles = append(titles, notification.Title)
+		return nil
+	}}
+
+	require.NoError(t, n.check(context.Background(), now))
+	assert.Empty(t, titles)
+
+	require.NoError(t, n.check(context.Background(), now.Add(time.Minute)))
+	assert.Equal(t, []string{"Planning"}, titles, "snoozed meetings are notified about when the snooze ends")
+}
@@ -0,0 +1,214 @@
+// Package notifier watches a calendar and displays desktop notifications shortly before each meeting starts.
+package notifier
+
+import (
+	"context"
+	"time"
+
+	"github.com/benbalter/zoom-go"
+)
+
+const (
+	// DefaultLeadTime is how long before a meeting starts to notify when Notifier.LeadTime is unset.
+	DefaultLeadTime = 5 * time.Minute
+
+	// DefaultInterval is how often the calendar is checked when Notifier.Interval is unset.
+	DefaultInterval = time.Minute
+
+	// DefaultReminderHorizon is how far ahead meetings are looked for when
+	// Notifier.UseReminders is set and Notifier.ReminderHorizon is unset.
+	DefaultReminderHorizon = 24 * time.Hour
+)
+
+// Notifier notifies you about upcoming meetings.
+type Notifier struct {
+	// Source is the calendar to watch.
+	Source zoom.CalendarSource
+
+	// LeadTime is how long before a meeting starts to notify. Zero means DefaultLeadTime.
+	LeadTime time.Duration
+
+	// UseReminders notifies about each meeting when the pop-up reminders set for it in
+	// Google Calendar go off, rather than LeadTime before it starts. Meetings whose
+	// reminders aren't known are still notified about LeadTime before they start, and
+	// meetings without reminders aren't notified about. A reminder which went off while
+	// the notifier wasn't running is notified about if it is less than LeadTime late.
+	UseReminders bool
+
+	// ReminderHorizon is how long before a meeting its reminders can go off, when
+	// UseReminders is set. Earlier reminders go off this long before it. Zero means
+	// DefaultReminderHorizon.
+	ReminderHorizon time.Duration
+
+	// PreferWebURL links notifications to meetings' web URLs even when they have a deep
+	// link, as zoom.Opener's does, e.g. when config.Settings.PreferDeepLink is false.
+	PreferWebURL bool
+
+	// Interval is how often to check the calendar. Zero means DefaultInterval.
+	Interval time.Duration
+
+	// Notify displays a notification. Nil means the package-level Notify.
+	Notify func(Notification) error
+
+	// Mutes, if set, are the meetings not to notify about. A snoozed meeting is notified
+	// about when its snooze ends, if it is still within the lead time.
+	Mutes *Mutes
+
+	// OnError is called when the calendar cannot be read or a notification cannot be
+	// displayed. The notifier keeps running afterwards. If nil, errors are ignored.
+	OnError func(error)
+
+	notified map[string]time.Time
+}
+
+// Run checks the calendar until the context is done, notifying about each meeting once.
+// It always returns the context's error.
+func (n *Notifier) Run(ctx context.Context) error {
+	interval := n.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := n.check(ctx, time.Now()); err != nil && ctx.Err() == nil && n.OnError != nil {
+			n.OnError(err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// check notifies about every meeting starting within the lead time of now, or which
+// started less than the lead time ago, which has not already been notified about. With
+// UseReminders, it notifies about each reminder which has gone off instead.
+func (n *Notifier) check(ctx context.Context, now time.Time) error {
+	leadTime := n.LeadTime
+	if leadTime <= 0 {
+		leadTime = DefaultLeadTime
+	}
+	notify := n.Notify
+	if notify == nil {
+		notify = Notify
+	}
+	if n.notified == nil {
+		n.notified = map[string]time.Time{}
+	}
+
+	horizon := leadTime
+	if n.UseReminders && n.reminderHorizon() > horizon {
+		horizon = n.reminderHorizon()
+	}
+	meetings, err := n.Source.UpcomingEvents(ctx, zoom.Window{Start: now, End: now.Add(horizon)})
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	for _, meeting := range meetings {
+		due, ok := n.due(meeting, now, leadTime)
+		if !ok {
+			continue
+		}
+
+		key := meeting.ID + " " + meeting.Start.String() + " " + due.String()
+		if _, ok := n.notified[key]; ok {
+			continue
+		}
+		if n.Mutes != nil && n.Mutes.Muted(meeting, now) {
+			continue
+		}
+
+		notification := NotificationForMeetingWithOptions(meeting, zoom.URLOptions{PreferDeepLink: !n.PreferWebURL})
+		if conflict := zoom.ConflictSummary(meeting, meetings); conflict != "" {
+			notification.Message += " " + conflict + "."
+		}
+		if err := notify(notification); err != nil && firstErr == nil {
+			firstErr = err
+		}
+		n.notified[key] = meeting.Start
+	}
+
+	// Forget about meetings which are long past so the map doesn't grow forever.
+	for key, start := range n.notified {
+		if start.Before(now.Add(-24 * time.Hour)) {
+			delete(n.notified, key)
+		}
+	}
+
+	return firstErr
+}
+
+// due returns when the latest notification about the meeting which is due at now became
+// due, and false if none is.
+func (n *Notifier) due(meeting zoom.Meeting, now time.Time, leadTime time.Duration) (time.Time, bool) {
+	if meeting.Start.IsZero() {
+		return time.Time{}, false
+	}
+	if !n.UseReminders || meeting.Reminders == nil {
+		if meeting.Start.Before(now.Add(-leadTime)) || meeting.Start.After(now.Add(leadTime)) {
+			return time.Time{}, false
+		}
+		return meeting.Start.Add(-leadTime), true
+	}
+
+	var due time.Time
+	for _, at := range meeting.ReminderTimes() {
+		if earliest := meeting.Start.Add(-n.reminderHorizon()); at.Before(earliest) {
+			at = earliest
+		}
+		if !at.After(now) {
+			due = at
+		}
+	}
+	if due.IsZero() || now.Sub(due) > leadTime {
+		return time.Time{}, false
+	}
+	return due, true
+}
+
+func (n *Notifier) reminderHorizon() time.Duration {
+	if n.ReminderHorizon > 0 {
+		return n.ReminderHorizon
+	}
+	return DefaultReminderHorizon
+}
+
+// NotificationForMeeting returns the notification to display before the meeting starts,
+// linked to its deep link if it has one. When it starts is described by zoom.Formatter,
+// in your locale.
+func NotificationForMeeting(meeting zoom.Meeting) Notification {
+	return NotificationForMeetingWithOptions(meeting, zoom.URLOptions{PreferDeepLink: true})
+}
+
+// NotificationForMeetingWithOptions is like NotificationForMeeting, but links the
+// notification to the meeting's URL chosen by the options.
+func NotificationForMeetingWithOptions(meeting zoom.Meeting, opts zoom.URLOptions) Notification {
+	title := meeting.Title
+	if title == "" {
+		title = "Upcoming meeting"
+	}
+	if meeting.Series != nil {
+		title += " (" + meeting.Series.Description() + ")"
+	}
+
+	verb := "Starts"
+	if meeting.Start.Before(time.Now()) {
+		verb = "Started"
+	}
+
+	notification := Notification{
+		Title:   title,
+		Message: verb + " " + zoom.Formatter.RelativeTime(meeting.Start, time.Now()) + ".",
+	}
+	if u := meeting.URL(opts); u != nil {
+		notification.URL = u.String()
+	}
+	return notification
+}
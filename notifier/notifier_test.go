@@ -0,0 +1,153 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benbalter/zoom-go"
+)
+
+type fakeSource struct {
+	meetings []zoom.Meeting
+	err      error
+	windows  []zoom.Window
+}
+
+func (s *fakeSource) UpcomingEvents(ctx context.Context, window zoom.Window) ([]zoom.Meeting, error) {
+	s.windows = append(s.windows, window)
+	return s.meetings, s.err
+}
+
+func TestNotifierCheck(t *testing.T) {
+	now := time.Now()
+	joinURL, _ := url.Parse("https://jithub.zoom.us/j/12345")
+	deepLink, _ := url.Parse("zoommtg://zoom.us/join?confno=12345")
+
+	source := &fakeSource{meetings: []zoom.Meeting{
+		{ID: "soon", Title: "Standup", Start: now.Add(3 * time.Minute), End: now.Add(18 * time.Minute), JoinURL: joinURL, DeepLink: deepLink},
+		{ID: "later", Title: "Planning", Start: now.Add(30 * time.Minute)},
+		{ID: "started", Title: "Retro", Series: &zoom.Series{Frequency: "weekly", Interval: 2}, Start: now.Add(-2 * time.Minute), End: now.Add(5 * time.Minute)},
+		{ID: "long-ago", Title: "All hands", Start: now.Add(-20 * time.Minute)},
+	}}
+
+	var notifications []Notification
+	n := &Notifier{
+		Source: source,
+		Notify: func(notification Notification) error {
+			notifications = append(notifications, notification)
+			return nil
+		},
+	}
+
+	require.NoError(t, n.check(context.Background(), now))
+	require.Len(t, notifications, 2)
+	assert.Equal(t, "Standup", notifications[0].Title)
+	assert.Equal(t, "Starts 2 minutes from now. ⚠ overlaps with 'Retro'.", notifications[0].Message)
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=12345", notifications[0].URL)
+	assert.Equal(t, "Retro (every 2 weeks)", notifications[1].Title)
+	assert.Equal(t, "Started 2 minutes ago. ⚠ overlaps with 'Standup'.", notifications[1].Message)
+	assert.Equal(t, "", notifications[1].URL)
+
+	assert.Equal(t, []zoom.Window{{Start: now, End: now.Add(DefaultLeadTime)}}, source.windows)
+
+	// Meetings are only notified about once.
+	require.NoError(t, n.check(context.Background(), now.Add(time.Minute)))
+	assert.Len(t, notifications, 2)
+}
+
+func TestNotifierCheck_Errors(t *testing.T) {
+	source := &fakeSource{err: errors.New("calendar unavailable")}
+	n := &Notifier{Source: source, Notify: func(Notification) error { return nil }}
+	assert.EqualError(t, n.check(context.Background(), time.Now()), "calendar unavailable")
+
+	source = &fakeSource{meetings: []zoom.Meeting{{ID: "soon", Start: time.Now().Add(time.Minute)}}}
+	n = &Notifier{Source: source, Notify: func(Notification) error { return errors.New("no notification daemon") }}
+	assert.EqualError(t, n.check(context.Background(), time.Now()), "no notification daemon")
+}
+
+func TestNotifierRun(t *testing.T) {
+	source := &fakeSource{err: errors.New("calendar unavailable")}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	n := &Notifier{
+		Source:   source,
+		Interval: time.Millisecond,
+		OnError: func(err error) {
+			assert.EqualError(t, err, "calendar unavailable")
+			cancel()
+		},
+	}
+	assert.Equal(t, context.Canceled, n.Run(ctx))
+}
+
+func TestMessageWithURL(t *testing.T) {
+	assert.Equal(t, "Starts soon.", messageWithURL(Notification{Message: "Starts soon."}))
+	assert.Equal(t, "https://jithub.zoom.us/j/1", messageWithURL(Notification{URL: "https://jithub.zoom.us/j/1"}))
+	assert.Equal(t, "Starts soon.\nhttps://jithub.zoom.us/j/1", messageWithURL(Notification{Message: "Starts soon.", URL: "https://jithub.zoom.us/j/1"}))
+}
+
+func TestNotifierCheck_Reminders(t *testing.T) {
+	now := time.Now()
+	source := &fakeSource{meetings: []zoom.Meeting{
+		{ID: "review", Title: "Design review", Start: now.Add(9 * time.Minute), Reminders: []time.Duration{30 * time.Minute, 10 * time.Minute}},
+		{ID: "offsite", Title: "Offsite", Start: now.Add(3 * time.Hour), Reminders: []time.Duration{48 * time.Hour}},
+		{ID: "unknown", Title: "Standup", Start: now.Add(3 * time.Minute)},
+		{ID: "none", Title: "Focus", Start: now.Add(time.Minute), Reminders: []time.Duration{}},
+		{ID: "later", Title: "Planning", Start: now.Add(20 * time.Minute), Reminders: []time.Duration{10 * time.Minute}},
+	}}
+
+	var notified []string
+	n := &Notifier{
+		Source:          source,
+		UseReminders:    true,
+		ReminderHorizon: 3 * time.Hour,
+		Notify: func(notification Notification) error {
+			notified = append(notified, notification.Title)
+			return nil
+		},
+	}
+
+	require.NoError(t, n.check(context.Background(), now))
+	assert.Equal(t, []string{"Design review", "Offsite", "Standup"}, notified, "reminders earlier than the horizon go off at the horizon")
+	assert.Equal(t, zoom.Window{Start: now, End: now.Add(3 * time.Hour)}, source.windows[0])
+
+	require.NoError(t, n.check(context.Background(), now.Add(5*time.Minute)))
+	assert.Equal(t, []string{"Design review", "Offsite", "Standup"}, notified, "each reminder goes off once")
+
+	require.NoError(t, n.check(context.Background(), now.Add(10*time.Minute)))
+	assert.Equal(t, []string{"Design review", "Offsite", "Standup", "Planning"}, notified)
+}
+
+func TestNotificationForMeeting_Locale(t *testing.T) {
+	defer func(formatter zoom.TimeFormatter) { zoom.Formatter = formatter }(zoom.Formatter)
+	zoom.Formatter = zoom.German
+
+	notification := NotificationForMeeting(zoom.Meeting{Title: "Standup", Start: time.Now().Add(10*time.Minute + 30*time.Second)})
+	assert.Contains(t, notification.Message, "10 Minuten", "the time is described in your locale")
+}
+
+func TestNotifierCheck_PreferWebURL(t *testing.T) {
+	now := time.Now()
+	joinURL, _ := url.Parse("https://jithub.zoom.us/j/12345")
+	deepLink, _ := url.Parse("zoommtg://zoom.us/join?confno=12345")
+
+	var notifications []Notification
+	n := &Notifier{
+		Source:       &fakeSource{meetings: []zoom.Meeting{{ID: "soon", Title: "Standup", Start: now.Add(3 * time.Minute), JoinURL: joinURL, DeepLink: deepLink}}},
+		PreferWebURL: true,
+		Notify: func(notification Notification) error {
+			notifications = append(notifications, notification)
+			return nil
+		},
+	}
+
+	require.NoError(t, n.check(context.Background(), now))
+	require.Len(t, notifications, 1)
+	assert.Equal(t, "https://jithub.zoom.us/j/12345", notifications[0].URL)
+}
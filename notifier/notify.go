@@ -0,0 +1,36 @@
+package notifier
+
+import "fmt"
+
+// Notification is a desktop notification.
+type Notification struct {
+	// Title is the headline of the notification.
+	Title string
+
+	// Message is the body of the notification.
+	Message string
+
+	// URL is opened when the notification is clicked, on platforms which support it.
+	// On other platforms it is appended to the message.
+	URL string
+}
+
+// Notify displays the notification on the desktop.
+func Notify(n Notification) error {
+	if err := notify(n).Run(); err != nil {
+		return fmt.Errorf("unable to display notification: %w", err)
+	}
+	return nil
+}
+
+// messageWithURL returns the message followed by the URL, for platforms which cannot open
+// the URL when the notification is clicked.
+func messageWithURL(n Notification) string {
+	if n.URL == "" {
+		return n.Message
+	}
+	if n.Message == "" {
+		return n.URL
+	}
+	return n.Message + "\n" + n.URL
+}
@@ -0,0 +1,27 @@
+package notifier
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// notify uses terminal-notifier if it is installed, since it can open the URL when the
+// notification is clicked, and falls back to AppleScript otherwise.
+func notify(n Notification) *exec.Cmd {
+	if path, err := exec.LookPath("terminal-notifier"); err == nil {
+		args := []string{"-title", n.Title, "-message", n.Message}
+		if n.URL != "" {
+			args = append(args, "-open", n.URL)
+		}
+		return exec.Command(path, args...)
+	}
+
+	script := "display notification " + appleScriptString(messageWithURL(n)) + " with title " + appleScriptString(n.Title)
+	return exec.Command("osascript", "-e", script)
+}
+
+var appleScriptEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+func appleScriptString(s string) string {
+	return `"` + appleScriptEscaper.Replace(s) + `"`
+}
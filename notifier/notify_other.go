@@ -0,0 +1,13 @@
+//go:build !windows && !darwin
+// +build !windows,!darwin
+
+package notifier
+
+import (
+	"os/exec"
+)
+
+// notify uses notify-send, which is available on most Linux desktops.
+func notify(n Notification) *exec.Cmd {
+	return exec.Command("notify-send", "--app-name=zoom", n.Title, messageWithURL(n))
+}
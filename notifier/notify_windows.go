@@ -0,0 +1,24 @@
+package notifier
+
+import (
+	"os/exec"
+	"strings"
+)
+
+// notify shows a balloon tip from the notification area using PowerShell.
+func notify(n Notification) *exec.Cmd {
+	script := strings.Join([]string{
+		"[void][System.Reflection.Assembly]::LoadWithPartialName('System.Windows.Forms')",
+		"$n = New-Object System.Windows.Forms.NotifyIcon",
+		"$n.Icon = [System.Drawing.SystemIcons]::Information",
+		"$n.BalloonTipTitle = " + powerShellString(n.Title),
+		"$n.BalloonTipText = " + powerShellString(messageWithURL(n)),
+		"$n.Visible = $true",
+		"$n.ShowBalloonTip(10000)",
+	}, "; ")
+	return exec.Command("powershell", "-NoProfile", "-NonInteractive", "-Command", script)
+}
+
+func powerShellString(s string) string {
+	return "'" + strings.Replace(s, "'", "''", -1) + "'"
+}
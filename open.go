@@ -0,0 +1,152 @@
+package zoom
+
+import (
+	"fmt"
+	"net/url"
+	"os/exec"
+	"runtime"
+	"time"
+)
+
+// Opener opens URLs with the operating system's default handler, which launches the
+// provider's native app for deep links like zoommtg:// and msteams://, and the browser
+// for HTTPS URLs.
+type Opener struct {
+	// PreferWebURL opens meetings' web URLs even when they have a deep link, e.g. when
+	// config.Settings.PreferDeepLink is false.
+	PreferWebURL bool
+
+	// History, if set, records each meeting opened by Open.
+	History *History
+
+	// Rules choose how to join the meetings they match, such as in a particular browser,
+	// overriding PreferWebURL. The first rule matching a meeting is used.
+	Rules []JoinRule
+
+	// Clipboard is where JoinCopy rules put links. Nil means DefaultClipboard.
+	Clipboard *Clipboard
+
+	// GOOS is the operating system to open URLs for. It defaults to runtime.GOOS.
+	GOOS string
+
+	// Run runs a command to completion. It defaults to running it with os/exec.
+	Run func(name string, args ...string) error
+}
+
+// DefaultOpener is the Opener used by Open and OpenURL.
+var DefaultOpener = &Opener{}
+
+// Open opens the meeting, preferring its native deep link over its web URL.
+func Open(meeting Meeting) error {
+	return DefaultOpener.Open(meeting)
+}
+
+// Join opens the meeting at the URL as the DefaultOpener's Rules say.
+func Join(meeting Meeting, u *url.URL) error {
+	return DefaultOpener.Join(meeting, u)
+}
+
+// OpenURL opens the URL with the operating system's default handler.
+func OpenURL(u *url.URL) error {
+	return DefaultOpener.OpenURL(u)
+}
+
+// Open opens the meeting, preferring its native deep link over its web URL unless
+// PreferWebURL is set or one of the Rules says otherwise, and records it in the History.
+// It returns ErrNoMeetingURL if the meeting has neither.
+func (o *Opener) Open(meeting Meeting) error {
+	u := meeting.URL(URLOptions{PreferDeepLink: !o.PreferWebURL})
+	if u == nil {
+		return ErrNoMeetingURL
+	}
+	if err := o.Join(meeting, u); err != nil {
+		return err
+	}
+	if o.History != nil {
+		// The meeting was opened, so failing to record it isn't an error.
+		o.History.Record(meeting, u, time.Now())
+	}
+	return nil
+}
+
+// Join opens the meeting at the URL, which should be one of its own, as the first of the
+// Rules matching it says: in the provider's app, in the browser or with the rule's
+// command, or by copying its web URL to the clipboard. If no rule matches, the URL is
+// opened with the operating system's default handler.
+func (o *Opener) Join(meeting Meeting, u *url.URL) error {
+	rule, ok := MatchJoinRule(o.Rules, meeting, u)
+	if !ok {
+		return o.OpenURL(u)
+	}
+
+	// Only the meeting's own URLs are swapped for one another, so that e.g. a start URL
+	// isn't swapped for the join URL.
+	own := sameURL(u, meeting.JoinURL) || sameURL(u, meeting.DeepLink)
+	switch rule.Action {
+	case JoinApp:
+		if own && meeting.DeepLink != nil {
+			u = meeting.DeepLink
+		}
+		return o.OpenURL(u)
+	case JoinCopy:
+		clipboard := o.Clipboard
+		if clipboard == nil {
+			clipboard = DefaultClipboard
+		}
+		if !own {
+			return clipboard.Copy(u.String())
+		}
+		return clipboard.CopyURL(meeting)
+	}
+
+	if own && meeting.JoinURL != nil {
+		u = meeting.JoinURL
+	}
+	if len(rule.Command) == 0 {
+		return o.OpenURL(u)
+	}
+	run := o.Run
+	if run == nil {
+		run = runCommand
+	}
+	args := append(append([]string{}, rule.Command[1:]...), u.String())
+	if err := run(rule.Command[0], args...); err != nil {
+		return fmt.Errorf("unable to open %s with %s: %w", u, rule.Command[0], err)
+	}
+	return nil
+}
+
+// sameURL reports whether the URLs are both set and the same.
+func sameURL(a, b *url.URL) bool {
+	return a != nil && b != nil && a.String() == b.String()
+}
+
+// OpenURL opens the URL with the operating system's default handler.
+func (o *Opener) OpenURL(u *url.URL) error {
+	goos := o.GOOS
+	if goos == "" {
+		goos = runtime.GOOS
+	}
+	run := o.Run
+	if run == nil {
+		run = runCommand
+	}
+
+	var err error
+	switch goos {
+	case "darwin":
+		err = run("open", u.String())
+	case "windows":
+		err = run("rundll32", "url.dll,FileProtocolHandler", u.String())
+	default:
+		err = run("xdg-open", u.String())
+	}
+	if err != nil {
+		return fmt.Errorf("unable to open %s: %w", u, err)
+	}
+	return nil
+}
+
+func runCommand(name string, args ...string) error {
+	return exec.Command(name, args...).Run()
+}
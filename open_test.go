@@ -0,0 +1,125 @@
+package zoom
+
+import (
+	"errors"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpenerOpen(t *testing.T) {
+	joinURL, _ := url.Parse("https://jithub.zoom.us/j/12345")
+	deepLink, _ := url.Parse("zoommtg://zoom.us/join?confno=12345")
+
+	testCases := []struct {
+		goos     string
+		meeting  Meeting
+		expected []string
+	}{
+		{"darwin", Meeting{JoinURL: joinURL, DeepLink: deepLink}, []string{"open", "zoommtg://zoom.us/join?confno=12345"}},
+		{"linux", Meeting{JoinURL: joinURL}, []string{"xdg-open", "https://jithub.zoom.us/j/12345"}},
+		{"freebsd", Meeting{JoinURL: joinURL}, []string{"xdg-open", "https://jithub.zoom.us/j/12345"}},
+		{"windows", Meeting{JoinURL: joinURL, DeepLink: deepLink}, []string{"rundll32", "url.dll,FileProtocolHandler", "zoommtg://zoom.us/join?confno=12345"}},
+	}
+	for _, testCase := range testCases {
+		var ran []string
+		opener := &Opener{GOOS: testCase.goos, Run: func(name string, args ...string) error {
+			ran = append([]string{name}, args...)
+			return nil
+		}}
+		require.NoError(t, opener.Open(testCase.meeting))
+		assert.Equal(t, testCase.expected, ran, "GOOS: %s", testCase.goos)
+	}
+}
+
+func TestOpenerOpen_PreferWebURL(t *testing.T) {
+	joinURL, _ := url.Parse("https://teams.microsoft.com/l/meetup-join/19%3ameeting_abc")
+	deepLink, _ := url.Parse("msteams://teams.microsoft.com/l/meetup-join/19%3ameeting_abc")
+
+	var ran []string
+	opener := &Opener{GOOS: "darwin", PreferWebURL: true, Run: func(name string, args ...string) error {
+		ran = append([]string{name}, args...)
+		return nil
+	}}
+	require.NoError(t, opener.Open(Meeting{JoinURL: joinURL, DeepLink: deepLink}))
+	assert.Equal(t, []string{"open", "https://teams.microsoft.com/l/meetup-join/19%3ameeting_abc"}, ran)
+}
+
+func TestOpenerOpen_History(t *testing.T) {
+	joinURL, _ := url.Parse("https://jithub.zoom.us/j/12345")
+	history := NewHistory(filepath.Join(t.TempDir(), "history.jsonl"))
+	opener := &Opener{History: history, Run: func(string, ...string) error { return nil }}
+
+	require.NoError(t, opener.Open(Meeting{Title: "Standup", JoinURL: joinURL}))
+	entry, ok, err := history.LastJoined()
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "Standup", entry.Title)
+	assert.Equal(t, "https://jithub.zoom.us/j/12345", entry.URL)
+
+	opener.Run = func(string, ...string) error { return errors.New("exit status 1") }
+	require.Error(t, opener.Open(Meeting{Title: "Retro", JoinURL: joinURL}))
+	entry, _, _ = history.LastJoined()
+	assert.Equal(t, "Standup", entry.Title, "meetings which couldn't be opened aren't recorded")
+}
+
+func TestOpenerOpen_Errors(t *testing.T) {
+	opener := &Opener{Run: func(string, ...string) error {
+		return errors.New("exit status 1")
+	}}
+
+	assert.Equal(t, ErrNoMeetingURL, opener.Open(Meeting{}))
+
+	joinURL, _ := url.Parse("https://jithub.zoom.us/j/12345")
+	assert.EqualError(t, opener.Open(Meeting{JoinURL: joinURL}), "unable to open https://jithub.zoom.us/j/12345: exit status 1")
+}
+
+func TestOpenerJoin_Rules(t *testing.T) {
+	joinURL, _ := url.Parse("https://jithub.zoom.us/j/12345")
+	deepLink, _ := url.Parse("zoommtg://zoom.us/join?confno=12345")
+	meetURL, _ := url.Parse("https://meet.google.com/abc-defg-hij")
+	teamsURL, _ := url.Parse("https://teams.microsoft.com/l/meetup-join/19%3ameeting_abc")
+	teamsDeepLink, _ := url.Parse("msteams://teams.microsoft.com/l/meetup-join/19%3ameeting_abc")
+
+	var ran []string
+	var copied string
+	opener := &Opener{
+		GOOS: "linux",
+		Run: func(name string, args ...string) error {
+			ran = append([]string{name}, args...)
+			return nil
+		},
+		Clipboard: &Clipboard{GOOS: "darwin", Run: func(input, name string, args ...string) error {
+			copied = input
+			return nil
+		}},
+		Rules: []JoinRule{
+			{Match: "zoom.us", Action: JoinApp},
+			{Match: "meet.google.com", Action: JoinBrowser, Command: []string{"google-chrome", "--profile-directory=Work"}},
+			{Match: "teams", Action: JoinCopy},
+		},
+	}
+
+	zoomMeeting := Meeting{Provider: "Zoom", JoinURL: joinURL, DeepLink: deepLink}
+	require.NoError(t, opener.Join(zoomMeeting, joinURL))
+	assert.Equal(t, []string{"xdg-open", "zoommtg://zoom.us/join?confno=12345"}, ran)
+
+	require.NoError(t, opener.Join(Meeting{Provider: "Google Meet", JoinURL: meetURL}, meetURL))
+	assert.Equal(t, []string{"google-chrome", "--profile-directory=Work", "https://meet.google.com/abc-defg-hij"}, ran)
+
+	ran = nil
+	require.NoError(t, opener.Open(Meeting{Provider: "Microsoft Teams", JoinURL: teamsURL, DeepLink: teamsDeepLink}))
+	assert.Nil(t, ran, "teams links are copied rather than opened")
+	assert.Equal(t, "https://teams.microsoft.com/l/meetup-join/19%3ameeting_abc", copied)
+
+	startURL, _ := url.Parse("https://jithub.zoom.us/s/12345?zak=secret")
+	require.NoError(t, opener.Join(zoomMeeting, startURL))
+	assert.Equal(t, []string{"xdg-open", "https://jithub.zoom.us/s/12345?zak=secret"}, ran, "only the meeting's own URLs are swapped")
+
+	webex, _ := url.Parse("https://jithub.webex.com/meet/parkr")
+	require.NoError(t, opener.Join(Meeting{Provider: "Webex", JoinURL: webex}, webex))
+	assert.Equal(t, []string{"xdg-open", "https://jithub.webex.com/meet/parkr"}, ran, "meetings no rule matches are opened as usual")
+}
@@ -0,0 +1,258 @@
+package zoom
+
+import (
+	"context"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// defaultMaxResults is the number of events listed when Options.MaxResults is unset.
+const defaultMaxResults = 10
+
+// defaultMaxPages is the number of pages listed per calendar when Options.MaxPages is unset.
+const defaultMaxPages = 10
+
+// defaultConcurrency is the number of calendars listed at once when Options.Concurrency is unset.
+const defaultConcurrency = 4
+
+// defaultPaginateHorizon bounds the search when Options.Paginate is set without a Horizon.
+const defaultPaginateHorizon = 7 * 24 * time.Hour
+
+// Options controls which events are considered when selecting the next meeting.
+// The zero value considers the next 10 events in your primary calendar, skipping
+// declined, cancelled, and all-day events.
+type Options struct {
+	// CalendarIDs are the calendars to search. If empty, only the primary calendar is searched.
+	CalendarIDs []string
+
+	// AllCalendars searches every calendar you can read, ignoring CalendarIDs.
+	AllCalendars bool
+
+	// MaxResults is the maximum number of events to list. Zero means 10.
+	// When paginating, it is the number of events requested at a time instead.
+	MaxResults int64
+
+	// Horizon limits the search to events starting within this long from now, and
+	// lists every event up to it, a page at a time. Zero means no limit, or one week
+	// when Paginate is set.
+	Horizon time.Duration
+
+	// Paginate keeps listing events, MaxResults at a time, until the horizon is reached.
+	// NextEvent stops as soon as it finds an event with a meeting link.
+	Paginate bool
+
+	// MaxPages caps the number of pages listed from each calendar when paginating, so a
+	// busy calendar can't be fetched without end. Zero means 10.
+	MaxPages int
+
+	// Concurrency is the number of calendars whose events are listed at once. Zero means 4.
+	Concurrency int
+
+	// Cache, if set, remembers the next event so NextEvent doesn't call the API every time.
+	Cache *Cache
+
+	// Store, if set, keeps the events from each successful sync on disk, and is used to
+	// select the next event when the calendar can't be reached.
+	Store *EventStore
+
+	// Retry controls how calendar API calls which are rate limited or fail temporarily are retried.
+	Retry RetryPolicy
+
+	// MaxMeetingDuration skips events which last longer than this, such as all-day holds
+	// which happen to include a Zoom URL. Zero means no limit.
+	MaxMeetingDuration time.Duration
+
+	// SkipInProgressAfter skips events which started more than this long ago, so that
+	// running late into one meeting selects the next one instead. Events which have
+	// already ended are always skipped. Zero means in-progress events are never skipped.
+	SkipInProgressAfter time.Duration
+
+	// IncludeDeclined considers events you have declined, which are skipped by default.
+	IncludeDeclined bool
+
+	// IncludeCancelled considers cancelled events, which are skipped by default.
+	IncludeCancelled bool
+
+	// IncludeAllDay considers all-day events, which are skipped by default.
+	IncludeAllDay bool
+
+	// IncludeFocusTime considers focus time and out of office events, which are skipped
+	// by default as they aren't meetings. See IsFocusTime and IsOutOfOffice.
+	IncludeFocusTime bool
+
+	// Include, if set, skips events which don't match any of the filters, e.g. to only
+	// consider meetings organized by your team's domain.
+	Include []Filter
+
+	// Exclude skips events which match any of the filters, e.g. "Lunch" holds.
+	Exclude []Filter
+
+	// WorkingHours, if set, skips events which don't overlap your working hours, such as
+	// an early morning gym block.
+	WorkingHours WorkingHours
+
+	// SkipCancelledInstances skips instances of a recurring series whose title says they
+	// were cancelled, e.g. "CANCELLED: Standup", as organizers often cancel a single
+	// occurrence by renaming it.
+	SkipCancelledInstances bool
+
+	// DescribeSeries sets the Series of each recurring meeting listed by a
+	// GoogleCalendarSource, which takes extra API calls the first time each series is seen.
+	DescribeSeries bool
+
+	// PersonalRooms, if set, resolves Zoom Personal Meeting Room links such as
+	// https://zoom.us/my/alice to deep links, in the meetings listed by
+	// GoogleCalendarSource and NextMeeting.
+	PersonalRooms *PersonalRoomResolver
+
+	// Providers are the video-conferencing services whose links make an event a meeting.
+	// If empty, only Zoom links are recognized.
+	Providers []Provider
+
+	// Logger, if set, is told why events were skipped or chosen, and when calls are retried.
+	Logger Logger
+}
+
+// maxResults returns the number of events to list, defaulting to 10.
+func (o Options) maxResults() int64 {
+	if o.MaxResults <= 0 {
+		return defaultMaxResults
+	}
+	return o.MaxResults
+}
+
+// maxPages returns the number of pages to list from each calendar, defaulting to 10.
+func (o Options) maxPages() int {
+	if o.MaxPages <= 0 {
+		return defaultMaxPages
+	}
+	return o.MaxPages
+}
+
+// concurrency returns the number of calendars to list at once, defaulting to 4.
+func (o Options) concurrency() int {
+	if o.Concurrency <= 0 {
+		return defaultConcurrency
+	}
+	return o.Concurrency
+}
+
+// paginates returns true if every page of events in the window should be listed,
+// rather than just the first MaxResults events.
+func (o Options) paginates(window Window) bool {
+	return o.Paginate || !window.End.IsZero()
+}
+
+// window returns the span of time to search, starting now and extending to the horizon.
+func (o Options) window(now time.Time) Window {
+	window := Window{Start: now}
+	switch {
+	case o.Horizon > 0:
+		window.End = now.Add(o.Horizon)
+	case o.Paginate:
+		window.End = now.Add(defaultPaginateHorizon)
+	}
+	return window
+}
+
+// resolvePersonalRoom sets the meeting's deep link from its personal meeting room link,
+// if the options resolve them. Failing to resolve one isn't an error, since the meeting
+// can still be joined in a browser.
+func (o Options) resolvePersonalRoom(ctx context.Context, meeting *Meeting) {
+	if o.PersonalRooms == nil {
+		return
+	}
+	if err := o.PersonalRooms.ResolveMeeting(ctx, meeting); err != nil {
+		o.logger().Debug("couldn't resolve personal meeting room", "url", meeting.JoinURL, "error", err)
+	}
+}
+
+// providers returns the providers to match against, defaulting to Zoom.
+func (o Options) providers() []Provider {
+	if len(o.Providers) == 0 {
+		return []Provider{ZoomProvider}
+	}
+	return o.Providers
+}
+
+// Allows returns true if the options consider the event, for sources which convert events
+// from other calendars, such as outlook.Source, and so skip the same events.
+func (o Options) Allows(event *calendar.Event) bool {
+	return o.allows(event)
+}
+
+// allows returns true if the event should be considered as a candidate meeting, and
+// logs why it isn't otherwise.
+func (o Options) allows(event *calendar.Event) bool {
+	reason := o.skipReason(event)
+	if reason != "" {
+		o.logger().Debug("skipping event", "id", event.Id, "summary", event.Summary, "reason", reason)
+	}
+	return reason == ""
+}
+
+// skipReason returns why the options exclude the event, or "" if they don't.
+func (o Options) skipReason(event *calendar.Event) string {
+	if !o.IncludeDeclined && isDeclined(event) {
+		return "declined"
+	}
+	if !o.IncludeCancelled && event.Status == "cancelled" {
+		return "cancelled"
+	}
+	if !o.IncludeAllDay && isAllDay(event) {
+		return "all day"
+	}
+	if !o.IncludeFocusTime && IsFocusTime(event) {
+		return "focus time"
+	}
+	if !o.IncludeFocusTime && IsOutOfOffice(event) {
+		return "out of office"
+	}
+	if reason := filterReason(event, o.Include, o.Exclude); reason != "" {
+		return reason
+	}
+	if o.WorkingHours != nil && isOutsideWorkingHours(event, o.WorkingHours) {
+		return "outside working hours"
+	}
+	if o.SkipCancelledInstances && IsRecurring(event) && LooksCancelled(event) {
+		return "cancelled instance"
+	}
+	if o.SkipInProgressAfter > 0 && isStale(event, time.Now(), o.SkipInProgressAfter) {
+		return "in progress"
+	}
+	if o.MaxMeetingDuration > 0 {
+		if duration, err := MeetingDuration(event); err == nil && duration > o.MaxMeetingDuration {
+			return "too long"
+		}
+	}
+	return ""
+}
+
+// isDeclined returns true if you have declined the event.
+func isDeclined(event *calendar.Event) bool {
+	for _, attendee := range event.Attendees {
+		if attendee != nil && attendee.Self && attendee.ResponseStatus == "declined" {
+			return true
+		}
+	}
+	return false
+}
+
+// isAllDay returns true if the event has a start date but no start time.
+func isAllDay(event *calendar.Event) bool {
+	return event.Start != nil && event.Start.DateTime == "" && event.Start.Date != ""
+}
+
+// isStale returns true if the event has ended, or started more than the given duration before now.
+func isStale(event *calendar.Event, now time.Time, startedBefore time.Duration) bool {
+	startTime, err := MeetingStartTime(event)
+	if err != nil {
+		return false
+	}
+	if endTime, err := MeetingEndTime(event); err == nil && !endTime.After(now) {
+		return true
+	}
+	// All-day events aren't meetings you are late for.
+	return !isAllDay(event) && now.Sub(startTime) > startedBefore
+}
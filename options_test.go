@@ -0,0 +1,145 @@
+package zoom
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+var testLongEventResponse = `{
+	"items": [
+		{
+			"summary": "Conference hold",
+			"location": "https://jithub.zoom.us/j/11111",
+			"start": {"dateTime": "2018-10-10T09:00:00-07:00"},
+			"end": {"dateTime": "2018-10-10T17:00:00-07:00"}
+		},
+		{
+			"summary": "Standup",
+			"location": "https://jithub.zoom.us/j/22222",
+			"start": {"dateTime": "2018-10-10T10:00:00-07:00"},
+			"end": {"dateTime": "2018-10-10T10:15:00-07:00"}
+		}
+	]
+}`
+
+func TestNextEventWithOptions_MaxMeetingDuration(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testLongEventResponse)
+	})
+
+	event, err := NextEventWithOptions(service, Options{})
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, "Conference hold", event.Summary)
+
+	event, err = NextEventWithOptions(service, Options{MaxMeetingDuration: 4 * time.Hour})
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, "Standup", event.Summary)
+
+	event, err = NextEventWithOptions(service, Options{MaxMeetingDuration: 5 * time.Minute})
+	require.NoError(t, err)
+	assert.Nil(t, event)
+}
+
+func TestOptionsAllows_MaxMeetingDuration(t *testing.T) {
+	opts := Options{MaxMeetingDuration: time.Hour}
+
+	testCases := []struct {
+		input    *calendar.Event
+		expected bool
+	}{
+		{&calendar.Event{}, true},
+		{&calendar.Event{
+			Start: &calendar.EventDateTime{DateTime: "2018-10-10T09:00:00-07:00"},
+		}, true},
+		{&calendar.Event{
+			Start: &calendar.EventDateTime{DateTime: "2018-10-10T09:00:00-07:00"},
+			End:   &calendar.EventDateTime{DateTime: "2018-10-10T10:00:00-07:00"},
+		}, true},
+		{&calendar.Event{
+			Start: &calendar.EventDateTime{DateTime: "2018-10-10T09:00:00-07:00"},
+			End:   &calendar.EventDateTime{DateTime: "2018-10-10T10:01:00-07:00"},
+		}, false},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, opts.allows(testCase.input), "input: %+v", testCase.input)
+	}
+}
+
+func TestOptionsAllows_Filters(t *testing.T) {
+	declined := &calendar.Event{Attendees: []*calendar.EventAttendee{
+		{Email: "kevin@jithub.com", ResponseStatus: "accepted"},
+		{Email: "parkr@jithub.com", ResponseStatus: "declined", Self: true},
+	}}
+	declinedBySomeoneElse := &calendar.Event{Attendees: []*calendar.EventAttendee{
+		{Email: "kevin@jithub.com", ResponseStatus: "declined"},
+		{Email: "parkr@jithub.com", ResponseStatus: "accepted", Self: true},
+	}}
+	cancelled := &calendar.Event{Status: "cancelled"}
+	allDay := &calendar.Event{Start: &calendar.EventDateTime{Date: "2018-10-10"}}
+	timed := &calendar.Event{Status: "confirmed", Start: &calendar.EventDateTime{DateTime: "2018-10-10T09:00:00-07:00"}}
+	cancelledInstance := &calendar.Event{Summary: "CANCELLED: Standup", RecurringEventId: "standup"}
+	cancelledOneOff := &calendar.Event{Summary: "CANCELLED: Interview"}
+
+	testCases := []struct {
+		opts     Options
+		input    *calendar.Event
+		expected bool
+	}{
+		{Options{}, declined, false},
+		{Options{IncludeDeclined: true}, declined, true},
+		{Options{}, declinedBySomeoneElse, true},
+		{Options{}, cancelled, false},
+		{Options{IncludeCancelled: true}, cancelled, true},
+		{Options{}, allDay, false},
+		{Options{IncludeAllDay: true}, allDay, true},
+		{Options{}, timed, true},
+		{Options{}, cancelledInstance, true},
+		{Options{SkipCancelledInstances: true}, cancelledInstance, false},
+		{Options{SkipCancelledInstances: true}, cancelledOneOff, true},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, testCase.opts.allows(testCase.input), "opts: %+v, input: %+v", testCase.opts, testCase.input)
+	}
+}
+
+func TestOptionsAllows_SkipInProgressAfter(t *testing.T) {
+	at := func(offset time.Duration) *calendar.EventDateTime {
+		return &calendar.EventDateTime{DateTime: time.Now().Add(offset).Format(googleCalendarDateTimeFormat)}
+	}
+
+	testCases := []struct {
+		opts     Options
+		input    *calendar.Event
+		expected bool
+	}{
+		{Options{}, &calendar.Event{Start: at(-40 * time.Minute), End: at(20 * time.Minute)}, true},
+		{Options{SkipInProgressAfter: 10 * time.Minute}, &calendar.Event{Start: at(-40 * time.Minute), End: at(20 * time.Minute)}, false},
+		{Options{SkipInProgressAfter: 10 * time.Minute}, &calendar.Event{Start: at(-5 * time.Minute), End: at(55 * time.Minute)}, true},
+		{Options{SkipInProgressAfter: 10 * time.Minute}, &calendar.Event{Start: at(-5 * time.Minute), End: at(-time.Minute)}, false},
+		{Options{SkipInProgressAfter: 10 * time.Minute}, &calendar.Event{Start: at(-40 * time.Minute)}, false},
+		{Options{SkipInProgressAfter: 10 * time.Minute}, &calendar.Event{Start: at(5 * time.Minute), End: at(35 * time.Minute)}, true},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, testCase.opts.allows(testCase.input), "opts: %+v, input: %+v", testCase.opts, testCase.input)
+	}
+}
+
+func TestOptionsWindow(t *testing.T) {
+	now := time.Now()
+	assert.Equal(t, Window{Start: now}, Options{}.window(now))
+	assert.Equal(t, Window{Start: now, End: now.Add(24 * time.Hour)}, Options{Horizon: 24 * time.Hour}.window(now))
+	assert.Equal(t, Window{Start: now, End: now.Add(defaultPaginateHorizon)}, Options{Paginate: true}.window(now))
+}
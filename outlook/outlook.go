@@ -0,0 +1,258 @@
+// Package outlook provides a zoom.CalendarSource backed by Microsoft Graph, for Office 365 and Outlook calendars.
+package outlook
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+
+	"github.com/benbalter/zoom-go"
+)
+
+// DefaultBaseURL is the Microsoft Graph API endpoint.
+const DefaultBaseURL = "https://graph.microsoft.com/v1.0"
+
+// graphDateTimeFormat is the format of the dateTime field in Graph's dateTimeTimeZone resource.
+const graphDateTimeFormat = "2006-01-02T15:04:05.9999999"
+
+// defaultWindowLength is how far ahead to look when the window has no end, since
+// Graph's calendarView requires one.
+const defaultWindowLength = 7 * 24 * time.Hour
+
+// Source reads meetings from the signed-in user's default Outlook calendar.
+type Source struct {
+	// BaseURL is the Graph API endpoint. It defaults to DefaultBaseURL.
+	BaseURL string
+
+	// Providers are the video-conferencing services whose links make an event a meeting.
+	// If empty, only Zoom links are recognized.
+	Providers []zoom.Provider
+
+	// Options choose which events are skipped. As for Google calendars, declined,
+	// cancelled, and all-day events are skipped by default. Only the options' rules for
+	// skipping events are used.
+	Options zoom.Options
+
+	client *http.Client
+}
+
+// NewSource returns a Source which uses the client to talk to Microsoft Graph.
+// The client must add a token with the Calendars.Read scope to each request,
+// such as one returned by (*oauth2.Config).Client.
+func NewSource(client *http.Client) *Source {
+	return &Source{BaseURL: DefaultBaseURL, client: client}
+}
+
+// UpcomingEvents returns the meetings in the window, sorted by start time.
+// If the window has no end, the week after it starts is searched.
+func (s *Source) UpcomingEvents(ctx context.Context, window zoom.Window) ([]zoom.Meeting, error) {
+	end := window.End
+	if end.IsZero() {
+		end = window.Start.Add(defaultWindowLength)
+	}
+
+	query := url.Values{}
+	query.Set("startDateTime", window.Start.UTC().Format(time.RFC3339))
+	query.Set("endDateTime", end.UTC().Format(time.RFC3339))
+	query.Set("$orderby", "start/dateTime")
+	query.Set("$top", "50")
+
+	baseURL := s.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	next := baseURL + "/me/calendarView?" + query.Encode()
+
+	var meetings []zoom.Meeting
+	for next != "" {
+		page, err := s.fetchPage(ctx, next)
+		if err != nil {
+			return nil, err
+		}
+		for _, event := range page.Value {
+			calendarEvent := event.toCalendarEvent()
+			if !s.Options.Allows(calendarEvent) {
+				continue
+			}
+			meetings = append(meetings, zoom.MeetingFromEvent(calendarEvent, s.Providers))
+		}
+		next = page.NextLink
+	}
+	return meetings, nil
+}
+
+func (s *Source) fetchPage(ctx context.Context, pageURL string) (*eventPage, error) {
+	req, err := http.NewRequest(http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	// Ask for times in UTC so they can be parsed without a time zone database.
+	req.Header.Set("Prefer", `outlook.timezone="UTC"`)
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return nil, fmt.Errorf("graph: got HTTP response code %d with body: %s", resp.StatusCode, body)
+	}
+
+	page := &eventPage{}
+	if err := json.NewDecoder(resp.Body).Decode(page); err != nil {
+		return nil, err
+	}
+	return page, nil
+}
+
+type eventPage struct {
+	Value    []event `json:"value"`
+	NextLink string  `json:"@odata.nextLink"`
+}
+
+type event struct {
+	ID             string       `json:"id"`
+	Subject        string       `json:"subject"`
+	WebLink        string       `json:"webLink"`
+	IsCancelled    bool         `json:"isCancelled"`
+	IsAllDay       bool         `json:"isAllDay"`
+	Start          dateTimeZone `json:"start"`
+	End            dateTimeZone `json:"end"`
+	ResponseStatus struct {
+		Response string `json:"response"`
+	} `json:"responseStatus"`
+	Body struct {
+		Content string `json:"content"`
+	} `json:"body"`
+	Location struct {
+		DisplayName string `json:"displayName"`
+	} `json:"location"`
+	Organizer struct {
+		EmailAddress emailAddress `json:"emailAddress"`
+	} `json:"organizer"`
+	Attendees []struct {
+		EmailAddress emailAddress `json:"emailAddress"`
+		Type         string       `json:"type"`
+		Status       struct {
+			Response string `json:"response"`
+		} `json:"status"`
+	} `json:"attendees"`
+	OnlineMeeting *struct {
+		JoinURL string `json:"joinUrl"`
+	} `json:"onlineMeeting"`
+}
+
+type dateTimeZone struct {
+	DateTime string `json:"dateTime"`
+	TimeZone string `json:"timeZone"`
+}
+
+type emailAddress struct {
+	Name    string `json:"name"`
+	Address string `json:"address"`
+}
+
+// toCalendarEvent converts the Graph event into the shape of a Google Calendar event so
+// the meeting URL extraction in the zoom package can be reused.
+func (e event) toCalendarEvent() *calendar.Event {
+	calendarEvent := &calendar.Event{
+		Id:          e.ID,
+		Summary:     e.Subject,
+		HtmlLink:    e.WebLink,
+		Location:    e.Location.DisplayName,
+		Description: e.Body.Content,
+		Start:       e.Start.toEventDateTime(),
+		End:         e.End.toEventDateTime(),
+		Organizer: &calendar.EventOrganizer{
+			DisplayName: e.Organizer.EmailAddress.Name,
+			Email:       e.Organizer.EmailAddress.Address,
+		},
+	}
+	if e.IsCancelled {
+		calendarEvent.Status = "cancelled"
+	}
+	if e.IsAllDay {
+		calendarEvent.Start = e.Start.toEventDate()
+		calendarEvent.End = e.End.toEventDate()
+	}
+
+	for _, attendee := range e.Attendees {
+		calendarEvent.Attendees = append(calendarEvent.Attendees, &calendar.EventAttendee{
+			DisplayName:    attendee.EmailAddress.Name,
+			Email:          attendee.EmailAddress.Address,
+			Optional:       attendee.Type == "optional",
+			ResponseStatus: responseStatus(attendee.Status.Response),
+		})
+	}
+
+	// Your own response is listed apart from the other attendees. Google Calendar lists you
+	// among them, marked as yourself, when you were invited rather than organized it.
+	switch e.ResponseStatus.Response {
+	case "", "none", "organizer":
+	default:
+		calendarEvent.Attendees = append(calendarEvent.Attendees, &calendar.EventAttendee{
+			Self:           true,
+			ResponseStatus: responseStatus(e.ResponseStatus.Response),
+		})
+	}
+
+	if e.OnlineMeeting != nil && e.OnlineMeeting.JoinURL != "" {
+		calendarEvent.ConferenceData = &calendar.ConferenceData{
+			EntryPoints: []*calendar.EntryPoint{
+				{EntryPointType: "video", Uri: e.OnlineMeeting.JoinURL},
+			},
+		}
+	}
+
+	return calendarEvent
+}
+
+func (d dateTimeZone) toEventDateTime() *calendar.EventDateTime {
+	location := time.UTC
+	if d.TimeZone != "" && d.TimeZone != "UTC" {
+		if loaded, err := time.LoadLocation(d.TimeZone); err == nil {
+			location = loaded
+		}
+	}
+
+	t, err := time.ParseInLocation(graphDateTimeFormat, d.DateTime, location)
+	if err != nil {
+		return nil
+	}
+	return &calendar.EventDateTime{DateTime: t.Format(time.RFC3339), TimeZone: d.TimeZone}
+}
+
+// toEventDate returns the date of an all-day event's start or end, which Graph gives as
+// midnight in the event's time zone.
+func (d dateTimeZone) toEventDate() *calendar.EventDateTime {
+	t, err := time.Parse(graphDateTimeFormat, d.DateTime)
+	if err != nil {
+		return nil
+	}
+	return &calendar.EventDateTime{Date: t.Format("2006-01-02")}
+}
+
+// responseStatus maps a Graph response type onto the Google Calendar equivalent.
+func responseStatus(response string) string {
+	switch response {
+	case "accepted", "organizer":
+		return "accepted"
+	case "declined":
+		return "declined"
+	case "tentativelyAccepted":
+		return "tentative"
+	case "notResponded", "none":
+		return "needsAction"
+	default:
+		return response
+	}
+}
@@ -0,0 +1,162 @@
+package outlook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benbalter/zoom-go"
+)
+
+var testCalendarViewResponse = `{
+	"value": [
+		{
+			"id": "AAMk1",
+			"subject": "I am a video call",
+			"webLink": "https://outlook.office365.com/owa/?itemid=AAMk1",
+			"isCancelled": false,
+			"start": {"dateTime": "2018-10-11T00:30:00.0000000", "timeZone": "UTC"},
+			"end": {"dateTime": "2018-10-11T01:00:00.0000000", "timeZone": "UTC"},
+			"body": {"contentType": "html", "content": "<p>Join: https://jithub.zoom.us/j/12345?pwd=abc</p>"},
+			"location": {"displayName": "Zoom"},
+			"organizer": {"emailAddress": {"name": "Kevin Jithub", "address": "kevin@jithub.com"}},
+			"attendees": [
+				{"emailAddress": {"name": "Parker Moore", "address": "parkr@jithub.com"}, "type": "required", "status": {"response": "tentativelyAccepted"}}
+			]
+		},
+		{
+			"id": "AAMk2",
+			"subject": "Cancelled sync",
+			"isCancelled": true,
+			"start": {"dateTime": "2018-10-11T02:00:00.0000000", "timeZone": "UTC"},
+			"end": {"dateTime": "2018-10-11T02:30:00.0000000", "timeZone": "UTC"}
+		}
+	],
+	"@odata.nextLink": "%s/me/calendarView?page=2"
+}`
+
+var testCalendarViewSecondPage = `{
+	"value": [
+		{
+			"id": "AAMk3",
+			"subject": "Teams call",
+			"start": {"dateTime": "2018-10-11T03:00:00.0000000", "timeZone": "UTC"},
+			"end": {"dateTime": "2018-10-11T03:30:00.0000000", "timeZone": "UTC"},
+			"onlineMeeting": {"joinUrl": "https://teams.microsoft.com/l/meetup-join/19%3ameeting_abc"}
+		}
+	]
+}`
+
+func TestSourceUpcomingEvents(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	windowStart := time.Date(2018, 10, 10, 0, 0, 0, 0, time.UTC)
+
+	mux.HandleFunc("/me/calendarView", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, `outlook.timezone="UTC"`, r.Header.Get("Prefer"))
+
+		query := r.URL.Query()
+		if query.Get("page") == "2" {
+			fmt.Fprint(w, testCalendarViewSecondPage)
+			return
+		}
+		assert.Equal(t, "2018-10-10T00:00:00Z", query.Get("startDateTime"))
+		assert.Equal(t, "2018-10-17T00:00:00Z", query.Get("endDateTime"))
+		fmt.Fprintf(w, testCalendarViewResponse, server.URL)
+	})
+
+	source := NewSource(server.Client())
+	source.BaseURL = server.URL
+	source.Providers = zoom.AllProviders
+
+	meetings, err := source.UpcomingEvents(context.Background(), zoom.Window{Start: windowStart})
+	require.NoError(t, err)
+	require.Len(t, meetings, 2)
+
+	meeting := meetings[0]
+	assert.Equal(t, "AAMk1", meeting.ID)
+	assert.Equal(t, "I am a video call", meeting.Title)
+	assert.True(t, meeting.Start.Equal(time.Date(2018, 10, 11, 0, 30, 0, 0, time.UTC)))
+	assert.True(t, meeting.End.Equal(time.Date(2018, 10, 11, 1, 0, 0, 0, time.UTC)))
+	assert.Equal(t, zoom.Person{Name: "Kevin Jithub", Email: "kevin@jithub.com"}, meeting.Organizer)
+	assert.Equal(t, "Zoom", meeting.Provider)
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=12345&pwd=abc", meeting.DeepLink.String())
+	assert.Equal(t, "abc", meeting.Passcode)
+	assert.Equal(t, []zoom.Attendee{
+		{Person: zoom.Person{Name: "Parker Moore", Email: "parkr@jithub.com"}, ResponseStatus: "tentative"},
+	}, meeting.Attendees)
+
+	assert.Equal(t, "Teams call", meetings[1].Title)
+	assert.Equal(t, "Microsoft Teams", meetings[1].Provider)
+}
+
+func TestSourceUpcomingEvents_Error(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"error": {"code": "InvalidAuthenticationToken"}}`, http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	source := NewSource(server.Client())
+	source.BaseURL = server.URL
+
+	_, err := source.UpcomingEvents(context.Background(), zoom.Window{Start: time.Now()})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "401")
+}
+
+func TestSourceUpcomingEvents_Skipped(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"value": [
+			{
+				"id": "AAMk1",
+				"subject": "Declined sync",
+				"start": {"dateTime": "2018-10-11T00:30:00.0000000", "timeZone": "UTC"},
+				"end": {"dateTime": "2018-10-11T01:00:00.0000000", "timeZone": "UTC"},
+				"responseStatus": {"response": "declined"},
+				"body": {"content": "https://jithub.zoom.us/j/12345"}
+			},
+			{
+				"id": "AAMk2",
+				"subject": "Offsite",
+				"isAllDay": true,
+				"start": {"dateTime": "2018-10-11T00:00:00.0000000", "timeZone": "UTC"},
+				"end": {"dateTime": "2018-10-12T00:00:00.0000000", "timeZone": "UTC"},
+				"responseStatus": {"response": "accepted"}
+			},
+			{
+				"id": "AAMk3",
+				"subject": "Standup",
+				"start": {"dateTime": "2018-10-11T02:00:00.0000000", "timeZone": "UTC"},
+				"end": {"dateTime": "2018-10-11T02:15:00.0000000", "timeZone": "UTC"},
+				"responseStatus": {"response": "organizer"}
+			}
+		]}`)
+	}))
+	defer server.Close()
+
+	source := NewSource(server.Client())
+	source.BaseURL = server.URL
+	window := zoom.Window{Start: time.Date(2018, 10, 10, 0, 0, 0, 0, time.UTC)}
+
+	meetings, err := source.UpcomingEvents(context.Background(), window)
+	require.NoError(t, err)
+	require.Len(t, meetings, 1, "declined and all-day events are skipped by default")
+	assert.Equal(t, "Standup", meetings[0].Title)
+	assert.Empty(t, meetings[0].Attendees, "organizing a meeting doesn't make you an attendee")
+
+	source.Options = zoom.Options{IncludeDeclined: true, IncludeAllDay: true}
+	meetings, err = source.UpcomingEvents(context.Background(), window)
+	require.NoError(t, err)
+	require.Len(t, meetings, 3)
+	assert.Equal(t, zoom.ResponseDeclined, meetings[0].MyResponse())
+	assert.True(t, meetings[1].AllDay)
+	assert.Equal(t, zoom.ResponseAccepted, meetings[1].MyResponse())
+}
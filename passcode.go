@@ -0,0 +1,53 @@
+package zoom
+
+import (
+	"net/url"
+	"regexp"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// passcodeRegexp matches passcodes written out in meeting invitations, e.g. "Passcode: 123456".
+var passcodeRegexp = regexp.MustCompile(`(?i)\b(?:passcode|password)\s*:\s*([^\s<"']+)`)
+
+// MeetingPasscodeFromEvent returns the Zoom meeting passcode for the event, if one was found.
+func MeetingPasscodeFromEvent(event *calendar.Event) string {
+	webURL, _, _ := meetingURLsFromEvent(event)
+	return meetingPasscode(event, webURL)
+}
+
+// meetingPasscode returns the passcode from the meeting URL's pwd parameter, the event's
+// video entry point, or the text of the event's description, in that order.
+func meetingPasscode(event *calendar.Event, webURL *url.URL) string {
+	if webURL != nil {
+		if pwd := webURL.Query().Get("pwd"); pwd != "" {
+			return pwd
+		}
+	}
+	if event == nil {
+		return ""
+	}
+	if entryPoint := videoEntryPoint(event); entryPoint != nil {
+		if passcode := firstNonEmpty(entryPoint.Passcode, entryPoint.Password, entryPoint.Pin); passcode != "" {
+			return passcode
+		}
+	}
+	if matches := passcodeRegexp.FindStringSubmatch(event.Description); len(matches) == 2 {
+		return matches[1]
+	}
+	return ""
+}
+
+// withPasscode adds the passcode to a zoommtg:// deep link so the client does not prompt for it.
+func withPasscode(deepLink *url.URL, passcode string) *url.URL {
+	if deepLink == nil || passcode == "" {
+		return deepLink
+	}
+
+	query := deepLink.Query()
+	query.Set("pwd", passcode)
+
+	withPasscode := *deepLink
+	withPasscode.RawQuery = query.Encode()
+	return &withPasscode
+}
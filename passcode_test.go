@@ -0,0 +1,63 @@
+package zoom
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestMeetingPasscodeFromEvent(t *testing.T) {
+	testCases := []struct {
+		input    *calendar.Event
+		expected string
+	}{
+		{nil, ""},
+		{&calendar.Event{}, ""},
+		{&calendar.Event{Location: "https://jithub.zoom.us/j/12345"}, ""},
+		{&calendar.Event{Location: "https://jithub.zoom.us/j/12345?pwd=abc123"}, "abc123"},
+		{&calendar.Event{
+			Location:    "https://jithub.zoom.us/j/12345",
+			Description: "Meeting ID: 123 45\nPasscode: 987654\n",
+		}, "987654"},
+		{&calendar.Event{
+			Description: "Join Zoom Meeting<br>https://jithub.zoom.us/j/12345<br>Password: s3cret<br>",
+		}, "s3cret"},
+		{&calendar.Event{ConferenceData: &calendar.ConferenceData{
+			EntryPoints: []*calendar.EntryPoint{
+				{EntryPointType: "video", Uri: "https://jithub.zoom.us/j/12345", Passcode: "424242"},
+			},
+		}}, "424242"},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, MeetingPasscodeFromEvent(testCase.input), "input: %+v", testCase.input)
+	}
+}
+
+func TestMeetingURLFromEvent_Passcode(t *testing.T) {
+	testCases := []struct {
+		input    *calendar.Event
+		expected string
+	}{
+		{&calendar.Event{Location: "https://jithub.zoom.us/j/12345?pwd=abc123"}, "zoommtg://zoom.us/join?confno=12345&pwd=abc123"},
+		{&calendar.Event{
+			Location:    "https://jithub.zoom.us/j/12345",
+			Description: "Passcode: 987654",
+		}, "zoommtg://zoom.us/join?confno=12345&pwd=987654"},
+		{&calendar.Event{ConferenceData: &calendar.ConferenceData{
+			EntryPoints: []*calendar.EntryPoint{
+				{EntryPointType: "video", Uri: "https://jithub.zoom.us/j/12345", Passcode: "424242"},
+			},
+		}}, "zoommtg://zoom.us/join?confno=12345&pwd=424242"},
+		{&calendar.Event{
+			Location:    "https://jithub.zoom.us/my/parkr",
+			Description: "Passcode: 987654",
+		}, "https://jithub.zoom.us/my/parkr"},
+	}
+	for _, testCase := range testCases {
+		u, ok := MeetingURLFromEvent(testCase.input)
+		if assert.True(t, ok, "input: %+v", testCase.input) {
+			assert.Equal(t, testCase.expected, u.String())
+		}
+	}
+}
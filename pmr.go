@@ -0,0 +1,183 @@
+package zoom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultPersonalRoomTTL is how long a resolved personal meeting room is remembered when
+// PersonalRoomResolver.TTL is unset. Personal meeting IDs rarely change.
+const defaultPersonalRoomTTL = 7 * 24 * time.Hour
+
+// maxPersonalRoomRedirects is how many redirects are followed to find a meeting ID.
+const maxPersonalRoomRedirects = 3
+
+// PersonalRoomResolver finds the meeting IDs behind Personal Meeting Room vanity URLs,
+// such as https://zoom.us/my/alice, by following their redirects, so they can be opened
+// with a zoommtg:// deep link rather than a browser interstitial. Set Options.PersonalRooms
+// to use it. Resolved rooms are remembered for the TTL.
+type PersonalRoomResolver struct {
+	// Client makes the requests. Nil means http.DefaultClient's transport. Redirects are
+	// never followed automatically, since the meeting ID is in the first one.
+	Client *http.Client
+
+	// TTL is how long a resolved room is remembered. Zero means a week.
+	TTL time.Duration
+
+	// Path is a file in which resolved rooms are also stored, so they are shared between
+	// separate runs of a program. If empty, they are only remembered in memory.
+	Path string
+
+	mu      sync.Mutex
+	loaded  bool
+	entries map[string]personalRoom
+}
+
+// personalRoom is a resolved personal meeting room, as stored on disk.
+type personalRoom struct {
+	Domain     string    `json:"domain"`
+	MeetingID  string    `json:"meetingId"`
+	Passcode   string    `json:"passcode,omitempty"`
+	ResolvedAt time.Time `json:"resolvedAt"`
+}
+
+// NewPersonalRoomResolver returns a resolver which remembers rooms for the TTL, both in
+// memory and in the file at path. If path is empty, rooms are only remembered in memory.
+func NewPersonalRoomResolver(ttl time.Duration, path string) *PersonalRoomResolver {
+	return &PersonalRoomResolver{TTL: ttl, Path: path}
+}
+
+// DefaultPersonalRoomPath returns the file in your user cache directory in which resolved
+// personal meeting rooms are stored.
+func DefaultPersonalRoomPath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "zoom-go", "personal-rooms.json"), nil
+}
+
+// IsPersonalRoomURL returns true if the URL is a Zoom Personal Meeting Room vanity URL,
+// e.g. https://jithub.zoom.us/my/parkr.
+func IsPersonalRoomURL(u *url.URL) bool {
+	if u == nil {
+		return false
+	}
+	matches := zoomURLRegexp().FindStringSubmatch(u.String())
+	return len(matches) > 0 && matches[4] != ""
+}
+
+// DeepLink returns the zoommtg:// deep link which joins the personal meeting room at the
+// URL, following the URL's redirect to find its meeting ID if it wasn't resolved within
+// the TTL.
+func (r *PersonalRoomResolver) DeepLink(ctx context.Context, roomURL *url.URL) (*url.URL, error) {
+	room, err := r.resolve(ctx, roomURL, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return withPasscode(zoomDeepLink(room.Domain, "join", room.MeetingID), room.Passcode), nil
+}
+
+// ResolveMeeting sets the meeting's DeepLink if it has none and its JoinURL is a personal
+// meeting room. The meeting's own passcode is preferred over one found in the redirect.
+func (r *PersonalRoomResolver) ResolveMeeting(ctx context.Context, meeting *Meeting) error {
+	if meeting.DeepLink != nil || !IsPersonalRoomURL(meeting.JoinURL) {
+		return nil
+	}
+	room, err := r.resolve(ctx, meeting.JoinURL, time.Now())
+	if err != nil {
+		return err
+	}
+	meeting.DeepLink = withPasscode(zoomDeepLink(room.Domain, "join", room.MeetingID), firstNonEmpty(meeting.Passcode, room.Passcode))
+	return nil
+}
+
+// resolve returns the room at the URL, remembered or found by following its redirects.
+func (r *PersonalRoomResolver) resolve(ctx context.Context, roomURL *url.URL, now time.Time) (personalRoom, error) {
+	if !IsPersonalRoomURL(roomURL) {
+		return personalRoom{}, fmt.Errorf("%s is not a personal meeting room", roomURL)
+	}
+	key := strings.ToLower(roomURL.Host + strings.TrimSuffix(roomURL.Path, "/"))
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if !r.loaded {
+		r.loaded = true
+		if r.Path != "" {
+			readJSONFile(r.Path, &r.entries)
+		}
+	}
+	if room, ok := r.entries[key]; ok && !now.Before(room.ResolvedAt) && now.Sub(room.ResolvedAt) < r.ttl() {
+		return room, nil
+	}
+
+	room, err := r.follow(ctx, roomURL)
+	if err != nil {
+		return personalRoom{}, err
+	}
+	room.ResolvedAt = now
+	if r.entries == nil {
+		r.entries = map[string]personalRoom{}
+	}
+	r.entries[key] = room
+	if r.Path != "" {
+		// The room can always be resolved again, so failing to store it isn't an error.
+		writeJSONFile(r.Path, r.entries)
+	}
+	return room, nil
+}
+
+// follow requests the URL, and any personal meeting room it redirects to, until it
+// redirects to a URL with a meeting ID.
+func (r *PersonalRoomResolver) follow(ctx context.Context, roomURL *url.URL) (personalRoom, error) {
+	client := &http.Client{
+		CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+	}
+	if r.Client != nil {
+		client.Transport = r.Client.Transport
+		client.Timeout = r.Client.Timeout
+		client.Jar = r.Client.Jar
+	}
+
+	u := roomURL
+	for i := 0; i < maxPersonalRoomRedirects; i++ {
+		req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+		if err != nil {
+			return personalRoom{}, err
+		}
+		resp, err := client.Do(req.WithContext(ctx))
+		if err != nil {
+			return personalRoom{}, fmt.Errorf("resolving personal meeting room %s: %w", roomURL, err)
+		}
+		resp.Body.Close()
+
+		location, err := resp.Location()
+		if err != nil {
+			return personalRoom{}, fmt.Errorf("personal meeting room %s didn't redirect to a meeting: %s", roomURL, resp.Status)
+		}
+		matches := zoomURLRegexp().FindStringSubmatch(location.String())
+		switch {
+		case len(matches) == 0:
+			return personalRoom{}, fmt.Errorf("personal meeting room %s redirected to %s, which isn't a meeting", roomURL, location)
+		case matches[3] != "":
+			return personalRoom{Domain: matches[1], MeetingID: matches[3], Passcode: location.Query().Get("pwd")}, nil
+		}
+		u = location
+	}
+	return personalRoom{}, fmt.Errorf("personal meeting room %s redirected too many times", roomURL)
+}
+
+func (r *PersonalRoomResolver) ttl() time.Duration {
+	if r.TTL <= 0 {
+		return defaultPersonalRoomTTL
+	}
+	return r.TTL
+}
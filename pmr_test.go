@@ -0,0 +1,137 @@
+package zoom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// handlerTransport serves every request with the handler, whatever its host.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (t handlerTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	w := httptest.NewRecorder()
+	t.handler.ServeHTTP(w, req)
+	resp := w.Result()
+	resp.Request = req
+	return resp, nil
+}
+
+func newPersonalRoomClient(t *testing.T, requests *int) *http.Client {
+	return &http.Client{Transport: handlerTransport{http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*requests++
+		switch r.URL.Host + r.URL.Path {
+		case "jithub.zoom.us/my/parkr":
+			http.Redirect(w, r, "https://jithub.zoom.us/j/12345678901?pwd=abc123", http.StatusFound)
+		case "zoom.us/my/kevin":
+			http.Redirect(w, r, "https://jithub.zoom.us/my/kevin", http.StatusFound)
+		case "jithub.zoom.us/my/kevin":
+			http.Redirect(w, r, "/j/98765", http.StatusMovedPermanently)
+		case "jithub.zoomgov.com/my/mona":
+			http.Redirect(w, r, "https://jithub.zoomgov.com/j/55555", http.StatusFound)
+		default:
+			w.WriteHeader(http.StatusOK)
+		}
+	})}}
+}
+
+func TestPersonalRoomResolver(t *testing.T) {
+	requests := 0
+	resolver := &PersonalRoomResolver{Client: newPersonalRoomClient(t, &requests)}
+	parse := func(s string) *url.URL {
+		u, err := url.Parse(s)
+		require.NoError(t, err)
+		return u
+	}
+
+	deepLink, err := resolver.DeepLink(context.Background(), parse("https://jithub.zoom.us/my/parkr"))
+	require.NoError(t, err)
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=12345678901&pwd=abc123", deepLink.String())
+	assert.Equal(t, 1, requests)
+
+	deepLink, err = resolver.DeepLink(context.Background(), parse("https://jithub.zoom.us/my/Parkr/"))
+	require.NoError(t, err)
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=12345678901&pwd=abc123", deepLink.String())
+	assert.Equal(t, 1, requests, "resolved rooms are remembered")
+
+	deepLink, err = resolver.DeepLink(context.Background(), parse("https://zoom.us/my/kevin"))
+	require.NoError(t, err)
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=98765", deepLink.String())
+
+	deepLink, err = resolver.DeepLink(context.Background(), parse("https://jithub.zoomgov.com/my/mona"))
+	require.NoError(t, err)
+	assert.Equal(t, "zoommtg://zoomgov.com/join?confno=55555", deepLink.String())
+
+	_, err = resolver.DeepLink(context.Background(), parse("https://jithub.zoom.us/my/nobody"))
+	assert.EqualError(t, err, "personal meeting room https://jithub.zoom.us/my/nobody didn't redirect to a meeting: 200 OK")
+
+	_, err = resolver.DeepLink(context.Background(), parse("https://jithub.zoom.us/j/12345"))
+	assert.EqualError(t, err, "https://jithub.zoom.us/j/12345 is not a personal meeting room")
+}
+
+func TestPersonalRoomResolver_File(t *testing.T) {
+	requests := 0
+	path := filepath.Join(t.TempDir(), "personal-rooms.json")
+	room, _ := url.Parse("https://jithub.zoom.us/my/parkr")
+
+	first := &PersonalRoomResolver{Client: newPersonalRoomClient(t, &requests), Path: path}
+	_, err := first.DeepLink(context.Background(), room)
+	require.NoError(t, err)
+
+	second := NewPersonalRoomResolver(time.Hour, path)
+	second.Client = newPersonalRoomClient(t, &requests)
+	deepLink, err := second.DeepLink(context.Background(), room)
+	require.NoError(t, err)
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=12345678901&pwd=abc123", deepLink.String())
+	assert.Equal(t, 1, requests, "resolved rooms are shared through the file")
+
+	_, err = second.resolve(context.Background(), room, time.Now().Add(2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 2, requests, "rooms are resolved again after the TTL")
+}
+
+func TestPersonalRoomResolver_ResolveMeeting(t *testing.T) {
+	requests := 0
+	resolver := &PersonalRoomResolver{Client: newPersonalRoomClient(t, &requests)}
+
+	meeting := MeetingFromEvent(&calendar.Event{Location: "https://jithub.zoom.us/my/parkr"}, nil)
+	meeting.Passcode = "fromInvite"
+	require.Nil(t, meeting.DeepLink)
+	require.NoError(t, resolver.ResolveMeeting(context.Background(), &meeting))
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=12345678901&pwd=fromInvite", meeting.DeepLink.String())
+
+	meeting = MeetingFromEvent(&calendar.Event{Location: "https://jithub.zoom.us/j/12345"}, nil)
+	require.NoError(t, resolver.ResolveMeeting(context.Background(), &meeting))
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=12345", meeting.DeepLink.String())
+	assert.Equal(t, 1, requests, "meetings with a deep link aren't resolved")
+}
+
+func TestNextMeeting_PersonalRooms(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items": [{"summary": "Office hours", "location": "https://jithub.zoom.us/my/parkr", "start": {"dateTime": "2018-10-10T17:30:00-07:00"}}]}`)
+	})
+
+	requests := 0
+	opts := Options{PersonalRooms: &PersonalRoomResolver{Client: newPersonalRoomClient(t, &requests)}}
+	meeting, ok, err := NextMeeting(service, opts)
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "https://jithub.zoom.us/my/parkr", meeting.JoinURL.String())
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=12345678901&pwd=abc123", meeting.DeepLink.String())
+}
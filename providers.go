@@ -0,0 +1,216 @@
+package zoom
+
+import (
+	"net/url"
+	"regexp"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// Provider is a video-conferencing service whose meeting links can be found in calendar events.
+type Provider interface {
+	// Name returns the human-friendly name of the service, e.g. "Zoom".
+	Name() string
+
+	// Match returns the meeting URL if the event contains a link to this service.
+	Match(event *calendar.Event) (*url.URL, bool)
+}
+
+// DeepLinker is implemented by providers whose meetings can be opened directly in a
+// native app. MeetingFromEvent uses it to set Meeting.DeepLink.
+type DeepLinker interface {
+	// DeepLink returns the URL which opens the meeting at the web URL in the provider's
+	// app, or nil if there is none.
+	DeepLink(webURL *url.URL) *url.URL
+}
+
+var (
+	// ZoomProvider matches Zoom meetings, returning the same URL as MeetingURLFromEvent.
+	ZoomProvider Provider = zoomProvider{}
+
+	// GoogleMeetProvider matches Google Meet meetings. Meet has no desktop app, so its
+	// meetings have no deep link; the mobile apps open the web URL themselves.
+	GoogleMeetProvider Provider = &regexpProvider{
+		name:    "Google Meet",
+		regexp:  regexp.MustCompile(`https://meet\.google\.com/[a-z]{3}-[a-z]{4}-[a-z]{3}`),
+		domains: []string{"meet.google.com"},
+	}
+
+	// MicrosoftTeamsProvider matches Microsoft Teams meetings. Its deep links open the
+	// meeting in the Teams app.
+	MicrosoftTeamsProvider Provider = &regexpProvider{
+		name:     "Microsoft Teams",
+		regexp:   regexp.MustCompile(`https://(?:teams\.microsoft\.com/l/meetup-join|teams\.live\.com/meet)/[^\s"'<>]+`),
+		domains:  []string{"teams.microsoft.com", "teams.live.com"},
+		deepLink: teamsDeepLink,
+	}
+
+	// WebexProvider matches Cisco Webex meetings.
+	WebexProvider Provider = &regexpProvider{
+		name:    "Webex",
+		regexp:  regexp.MustCompile(`https://[\w-]+(?:\.[\w-]+)*\.webex\.com/[^\s"'<>]+`),
+		domains: []string{".webex.com"},
+	}
+
+	// GoToMeetingProvider matches GoToMeeting meetings.
+	GoToMeetingProvider Provider = &regexpProvider{
+		name:    "GoToMeeting",
+		regexp:  regexp.MustCompile(`https://(?:(?:global|www)\.)?gotomeeting\.com/join/\d+|https://meet\.goto\.com/\d+`),
+		domains: []string{"gotomeeting.com", "meet.goto.com"},
+	}
+)
+
+// AllProviders lists every built-in provider.
+var AllProviders = []Provider{
+	ZoomProvider,
+	GoogleMeetProvider,
+	MicrosoftTeamsProvider,
+	WebexProvider,
+	GoToMeetingProvider,
+}
+
+// ConferenceURLFromEvent returns the meeting URL from the first provider which matches the event.
+func ConferenceURLFromEvent(event *calendar.Event, providers []Provider) (*url.URL, Provider, bool) {
+	webURL, deepLink, provider, ok := conferenceURLsFromEvent(event, providers)
+	if !ok {
+		return nil, nil, false
+	}
+	if provider == ZoomProvider {
+		// As ZoomProvider.Match does.
+		return URLOptions{PreferDeepLink: true}.choose(webURL, deepLink), provider, true
+	}
+	return webURL, provider, true
+}
+
+// conferenceURLsFromEvent returns the web URL and deep link of the first provider which
+// matches the event. The built-in providers share the event's text, which is cleaned up
+// at most once, rather than once by each of them, since calendars are scanned in bulk.
+func conferenceURLsFromEvent(event *calendar.Event, providers []Provider) (webURL, deepLink *url.URL, provider Provider, ok bool) {
+	if event == nil {
+		return nil, nil, nil, false
+	}
+	text := &lazyEventText{event: event}
+	for _, provider := range providers {
+		switch p := provider.(type) {
+		case zoomProvider:
+			if webURL, deepLink, ok := zoomURLsFromEvent(event, text); ok {
+				return webURL, deepLink, provider, true
+			}
+		case *regexpProvider:
+			if webURL, ok := p.matchEvent(event, text); ok {
+				return webURL, p.DeepLink(webURL), provider, true
+			}
+		default:
+			if webURL, ok := provider.Match(event); ok {
+				if linker, ok := provider.(DeepLinker); ok {
+					deepLink = linker.DeepLink(webURL)
+				}
+				return webURL, deepLink, provider, true
+			}
+		}
+	}
+	return nil, nil, nil, false
+}
+
+type zoomProvider struct{}
+
+func (zoomProvider) Name() string {
+	return "Zoom"
+}
+
+func (zoomProvider) Match(event *calendar.Event) (*url.URL, bool) {
+	return MeetingURLFromEvent(event)
+}
+
+// providerDomains returns the domains one of which every URL of the providers contains,
+// and false if a provider's domains aren't known.
+func providerDomains(providers []Provider) ([]string, bool) {
+	var domains []string
+	for _, provider := range providers {
+		switch p := provider.(type) {
+		case zoomProvider:
+			domains = append(domains, ZoomDomains...)
+		case *regexpProvider:
+			if len(p.domains) == 0 {
+				return nil, false
+			}
+			domains = append(domains, p.domains...)
+		default:
+			return nil, false
+		}
+	}
+	return domains, true
+}
+
+// regexpProvider matches the first URL in the event's conference data, Hangouts link,
+// location, description, or attachments which matches its regexp.
+type regexpProvider struct {
+	name   string
+	regexp *regexp.Regexp
+
+	// deepLink returns the native URL for a matched web URL. If nil, the provider is not
+	// a DeepLinker.
+	deepLink func(webURL *url.URL) *url.URL
+
+	// domains are the domains one of which every match of the regexp contains, so that
+	// events without any of them, like most events, are skipped without running it. They
+	// aren't longer prefixes, since domains survive links being wrapped by one of the
+	// Redirectors, while the slashes after them are escaped.
+	domains []string
+}
+
+func (p *regexpProvider) Name() string {
+	return p.name
+}
+
+func (p *regexpProvider) Match(event *calendar.Event) (*url.URL, bool) {
+	if event == nil {
+		return nil, false
+	}
+	return p.matchEvent(event, &lazyEventText{event: event})
+}
+
+// matchEvent matches the event's conference data, or else its text.
+func (p *regexpProvider) matchEvent(event *calendar.Event, text *lazyEventText) (*url.URL, bool) {
+	if entryPoint := videoEntryPoint(event); entryPoint != nil {
+		if u, ok := p.matchText(entryPoint.Uri); ok {
+			return u, true
+		}
+	}
+	if len(p.domains) > 0 && !text.mentions(p.domains) {
+		return nil, false
+	}
+	return p.matchText(text.String())
+}
+
+// matchText returns the first URL in the text which matches the regexp.
+func (p *regexpProvider) matchText(text string) (*url.URL, bool) {
+	if len(p.domains) > 0 && !containsAny(text, p.domains) {
+		return nil, false
+	}
+	match := trimLink(p.regexp.FindString(text))
+	if match == "" {
+		return nil, false
+	}
+
+	parsedURL, err := url.Parse(match)
+	if err != nil {
+		return nil, false
+	}
+	return parsedURL, true
+}
+
+func (p *regexpProvider) DeepLink(webURL *url.URL) *url.URL {
+	if p.deepLink == nil || webURL == nil {
+		return nil
+	}
+	return p.deepLink(webURL)
+}
+
+// teamsDeepLink returns the msteams:// URL which opens the Teams meeting at the web URL
+// in the Teams app, e.g. msteams://teams.microsoft.com/l/meetup-join/19%3ameeting_abc.
+func teamsDeepLink(webURL *url.URL) *url.URL {
+	deepLink := *webURL
+	deepLink.Scheme = "msteams"
+	return &deepLink
+}
@@ -0,0 +1,110 @@
+package zoom
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestConferenceURLFromEvent(t *testing.T) {
+	testCases := []struct {
+		input    *calendar.Event
+		provider Provider
+		expected string
+	}{
+		{&calendar.Event{Location: "https://jithub.zoom.us/j/12345"}, ZoomProvider, "zoommtg://zoom.us/join?confno=12345"},
+		{&calendar.Event{HangoutLink: "https://meet.google.com/abc-defg-hij"}, GoogleMeetProvider, "https://meet.google.com/abc-defg-hij"},
+		{&calendar.Event{
+			Description: "Join Microsoft Teams Meeting <https://teams.microsoft.com/l/meetup-join/19%3ameeting_abc%40thread.v2/0?context=%7b%7d>",
+		}, MicrosoftTeamsProvider, "https://teams.microsoft.com/l/meetup-join/19%3ameeting_abc%40thread.v2/0?context=%7b%7d"},
+		{&calendar.Event{Location: "https://jithub.webex.com/meet/parkr"}, WebexProvider, "https://jithub.webex.com/meet/parkr"},
+		{&calendar.Event{Description: "Join: https://global.gotomeeting.com/join/123456789"}, GoToMeetingProvider, "https://global.gotomeeting.com/join/123456789"},
+		{&calendar.Event{Location: "https://meet.goto.com/123456789"}, GoToMeetingProvider, "https://meet.goto.com/123456789"},
+	}
+	for _, testCase := range testCases {
+		u, provider, ok := ConferenceURLFromEvent(testCase.input, AllProviders)
+		require.True(t, ok, "input: %+v", testCase.input)
+		assert.Equal(t, testCase.provider, provider, "input: %+v", testCase.input)
+		assert.Equal(t, testCase.expected, u.String(), "input: %+v", testCase.input)
+	}
+}
+
+func TestConferenceURLFromEvent_NoMatch(t *testing.T) {
+	for _, event := range []*calendar.Event{
+		nil,
+		{},
+		{Location: "In a real place!"},
+		{Description: "https://meet.google.com/landing"},
+	} {
+		u, provider, ok := ConferenceURLFromEvent(event, AllProviders)
+		assert.False(t, ok, "input: %+v", event)
+		assert.Nil(t, u)
+		assert.Nil(t, provider)
+	}
+}
+
+func TestNextEventWithOptions_Providers(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items": [
+			{"summary": "In person"},
+			{"summary": "Meet call", "hangoutLink": "https://meet.google.com/abc-defg-hij"},
+			{"summary": "Zoom call", "location": "https://jithub.zoom.us/j/12345"}
+		]}`)
+	})
+
+	event, err := NextEventWithOptions(service, Options{})
+	require.NoError(t, err)
+	assert.Equal(t, "Zoom call", event.Summary)
+
+	event, err = NextEventWithOptions(service, Options{Providers: AllProviders})
+	require.NoError(t, err)
+	assert.Equal(t, "Meet call", event.Summary)
+}
+
+// jitsiProvider is a provider from outside the package, whose domains aren't known.
+type jitsiProvider struct{}
+
+func (jitsiProvider) Name() string { return "Jitsi" }
+
+func (jitsiProvider) Match(event *calendar.Event) (*url.URL, bool) {
+	if !strings.Contains(event.Location, "meet.jit.si") {
+		return nil, false
+	}
+	u, err := url.Parse(event.Location)
+	return u, err == nil
+}
+
+func TestProviderDomains(t *testing.T) {
+	// Events are only skipped without a closer look if they mention none of the domains.
+	for _, link := range []string{
+		"https://jithub.zoom.us/j/12345",
+		"https://meet.google.com/abc-defg-hij",
+		"https://teams.live.com/meet/12345",
+		"https://jithub.webex.com/meet/parkr",
+		"https://meet.goto.com/123456789",
+	} {
+		u, _, ok := ConferenceURLFromEvent(&calendar.Event{Location: link}, AllProviders)
+		require.True(t, ok, link)
+		domains, ok := providerDomains(AllProviders)
+		require.True(t, ok)
+		assert.True(t, containsAny(u.String(), domains) || u.Scheme == "zoommtg", link)
+	}
+
+	_, ok := providerDomains([]Provider{ZoomProvider, jitsiProvider{}})
+	assert.False(t, ok)
+	candidates := MeetingURLCandidates(&calendar.Event{Location: "https://meet.jit.si/standup"}, []Provider{ZoomProvider, jitsiProvider{}})
+	require.Len(t, candidates, 1, "events are looked at closely for providers whose domains aren't known")
+	assert.Equal(t, "Jitsi", candidates[0].Provider.Name())
+	assert.Equal(t, "Jitsi", MeetingFromEvent(&calendar.Event{Location: "https://meet.jit.si/standup"}, []Provider{jitsiProvider{}}).Provider)
+}
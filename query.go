@@ -0,0 +1,173 @@
+package zoom
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// dayPart is a part of the day, as hours from midnight.
+type dayPart struct {
+	start, end int
+}
+
+// dayParts are the parts of the day which a query can name.
+var dayParts = map[string]dayPart{
+	"morning":   {6, 12},
+	"afternoon": {12, 17},
+	"evening":   {17, 24},
+	"night":     {17, 24},
+}
+
+// weekdays are the days a query can name, by their full and short names.
+var weekdays = map[string]time.Weekday{
+	"sunday": time.Sunday, "sun": time.Sunday,
+	"monday": time.Monday, "mon": time.Monday,
+	"tuesday": time.Tuesday, "tue": time.Tuesday, "tues": time.Tuesday,
+	"wednesday": time.Wednesday, "wed": time.Wednesday,
+	"thursday": time.Thursday, "thu": time.Thursday, "thurs": time.Thursday,
+	"friday": time.Friday, "fri": time.Friday,
+	"saturday": time.Saturday, "sat": time.Saturday,
+}
+
+// EventsMatching returns the meetings in your primary calendar in the span of time the
+// query describes, such as "tomorrow morning", "next Monday", or "this afternoon", sorted
+// by start time. See ParseWindow for the queries understood. Meetings which overlap
+// another are marked Conflicting.
+func EventsMatching(service *calendar.Service, query string) ([]Meeting, error) {
+	return EventsMatchingWithOptions(service, query, Options{})
+}
+
+// EventsMatchingWithOptions is like EventsMatching, but meetings are selected by the
+// options. Meetings in progress are listed however long ago they started.
+func EventsMatchingWithOptions(service *calendar.Service, query string, opts Options) ([]Meeting, error) {
+	return EventsMatchingContext(context.Background(), service, query, opts)
+}
+
+// EventsMatchingContext is like EventsMatchingWithOptions, but the calendar API calls are
+// bound to the context.
+func EventsMatchingContext(ctx context.Context, service *calendar.Service, query string, opts Options) ([]Meeting, error) {
+	window, err := ParseWindow(query, time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	opts.SkipInProgressAfter = 0
+	meetings, err := NewGoogleCalendarSource(service, opts).UpcomingEvents(ctx, window)
+	if err != nil {
+		return nil, err
+	}
+	markConflicts(meetings)
+	return meetings, nil
+}
+
+// EventsMatchingFromSource is like EventsMatchingContext, but lists the meetings the
+// source lists, such as an AccountsSource, in the span of time the query describes as of
+// now. The source's options decide which meetings are listed.
+func EventsMatchingFromSource(ctx context.Context, source CalendarSource, query string, now time.Time) ([]Meeting, error) {
+	window, err := ParseWindow(query, now)
+	if err != nil {
+		return nil, err
+	}
+
+	meetings, err := source.UpcomingEvents(ctx, window)
+	if err != nil {
+		return nil, err
+	}
+	markConflicts(meetings)
+	return meetings, nil
+}
+
+// ParseWindow returns the span of time a query describes, as of now, in Location. A query
+// names a day and, optionally, a part of it:
+//
+//   - The day is "today", "tomorrow", "yesterday", a weekday such as "Monday" or "fri",
+//     which is the next one from today, or "next Monday", the next one after today.
+//   - The part is "morning" (6 AM to noon), "afternoon" (noon to 5 PM), or "evening" or
+//     "night" (5 PM to midnight). "This afternoon" and "tonight" are parts of today.
+//
+// A query can also be "this week" or "next week", which start on Monday, or "this
+// weekend". Case and extra spaces are ignored. The window may have passed, e.g. "this
+// morning" in the afternoon.
+func ParseWindow(query string, now time.Time) (Window, error) {
+	words := strings.Fields(strings.ToLower(query))
+	invalid := fmt.Errorf("unrecognized time %q: try e.g. \"tomorrow morning\" or \"next Monday\"", query)
+	if len(words) == 0 {
+		return Window{}, invalid
+	}
+
+	now = InLocation(now)
+	year, month, day := now.Date()
+	today := time.Date(year, month, day, 0, 0, 0, 0, now.Location())
+	days := func(from time.Time, n int) time.Time {
+		return time.Date(from.Year(), from.Month(), from.Day()+n, 0, 0, 0, 0, from.Location())
+	}
+	// daysUntil returns how many days after today the weekday is, from 0 to 6.
+	daysUntil := func(weekday time.Weekday) int {
+		return (int(weekday) - int(today.Weekday()) + 7) % 7
+	}
+	thisWeek := days(today, -((int(today.Weekday()) + 6) % 7))
+
+	switch strings.Join(words, " ") {
+	case "this week":
+		return Window{Start: thisWeek, End: days(thisWeek, 7)}, nil
+	case "next week":
+		return Window{Start: days(thisWeek, 7), End: days(thisWeek, 14)}, nil
+	case "this weekend":
+		saturday := days(thisWeek, 5)
+		return Window{Start: saturday, End: days(saturday, 2)}, nil
+	}
+
+	var start time.Time
+	rest := words[1:]
+	switch first := words[0]; {
+	case first == "today":
+		start = today
+	case first == "tonight":
+		start, rest = today, append([]string{"night"}, rest...)
+	case first == "tomorrow":
+		start = days(today, 1)
+	case first == "yesterday":
+		start = days(today, -1)
+	case first == "this" && len(rest) > 0:
+		if weekday, ok := weekdays[rest[0]]; ok {
+			start, rest = days(today, daysUntil(weekday)), rest[1:]
+		} else if _, ok := dayParts[rest[0]]; ok {
+			start = today
+		} else {
+			return Window{}, invalid
+		}
+	case first == "next" && len(rest) > 0:
+		weekday, ok := weekdays[rest[0]]
+		if !ok {
+			return Window{}, invalid
+		}
+		n := daysUntil(weekday)
+		if n == 0 {
+			n = 7
+		}
+		start, rest = days(today, n), rest[1:]
+	default:
+		weekday, ok := weekdays[first]
+		if !ok {
+			return Window{}, invalid
+		}
+		start = days(today, daysUntil(weekday))
+	}
+
+	switch len(rest) {
+	case 0:
+		return Window{Start: start, End: days(start, 1)}, nil
+	case 1:
+		if part, ok := dayParts[rest[0]]; ok {
+			at := func(hour int) time.Time {
+				return time.Date(start.Year(), start.Month(), start.Day(), hour, 0, 0, 0, start.Location())
+			}
+			return Window{Start: at(part.start), End: at(part.end)}, nil
+		}
+	}
+	return Window{}, invalid
+}
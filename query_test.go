@@ -0,0 +1,114 @@
+package zoom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWindow(t *testing.T) {
+	defer func(location *time.Location) { Location = location }(Location)
+	Location = time.UTC
+
+	// A Wednesday afternoon.
+	now := time.Date(2018, 10, 10, 14, 0, 0, 0, time.UTC)
+	at := func(day, hour int) string {
+		return time.Date(2018, 10, day, hour, 0, 0, 0, time.UTC).Format(time.RFC3339)
+	}
+
+	testCases := []struct {
+		query      string
+		start, end string
+	}{
+		{"today", at(10, 0), at(11, 0)},
+		{"  Tomorrow ", at(11, 0), at(12, 0)},
+		{"yesterday", at(9, 0), at(10, 0)},
+		{"this morning", at(10, 6), at(10, 12)},
+		{"this afternoon", at(10, 12), at(10, 17)},
+		{"tonight", at(10, 17), at(11, 0)},
+		{"tomorrow morning", at(11, 6), at(11, 12)},
+		{"friday", at(12, 0), at(13, 0)},
+		{"wed", at(10, 0), at(11, 0)},
+		{"this Friday evening", at(12, 17), at(13, 0)},
+		{"next Wednesday", at(17, 0), at(18, 0)},
+		{"next Monday afternoon", at(15, 12), at(15, 17)},
+		{"this week", at(8, 0), at(15, 0)},
+		{"next week", at(15, 0), at(22, 0)},
+		{"this weekend", at(13, 0), at(15, 0)},
+	}
+	for _, testCase := range testCases {
+		window, err := ParseWindow(testCase.query, now)
+		if assert.NoError(t, err, "query: %q", testCase.query) {
+			assert.Equal(t, testCase.start, window.Start.Format(time.RFC3339), "query: %q", testCase.query)
+			assert.Equal(t, testCase.end, window.End.Format(time.RFC3339), "query: %q", testCase.query)
+		}
+	}
+
+	for _, query := range []string{"", "soon", "this", "next", "next month", "tomorrow lunchtime", "tonight morning", "monday morning tea"} {
+		_, err := ParseWindow(query, now)
+		assert.Error(t, err, "query: %q", query)
+	}
+	_, err := ParseWindow("soon", now)
+	assert.EqualError(t, err, `unrecognized time "soon": try e.g. "tomorrow morning" or "next Monday"`)
+}
+
+func TestEventsMatching(t *testing.T) {
+	mux := http.NewServeMux()
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	window, err := ParseWindow("tomorrow morning", time.Now())
+	require.NoError(t, err)
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, window.Start.Format(time.RFC3339), query.Get("timeMin"))
+		assert.Equal(t, window.End.Format(time.RFC3339), query.Get("timeMax"))
+		fmt.Fprintf(w, `{"items": [
+			{"summary": "Standup", "start": {"dateTime": %q}, "end": {"dateTime": %q}, "location": "https://jithub.zoom.us/j/12345"}
+		]}`, window.Start.Add(3*time.Hour).Format(time.RFC3339), window.Start.Add(4*time.Hour).Format(time.RFC3339))
+	})
+
+	meetings, err := EventsMatching(service, "tomorrow morning")
+	require.NoError(t, err)
+	require.Len(t, meetings, 1)
+	assert.Equal(t, "Standup", meetings[0].Title)
+
+	_, err = EventsMatching(service, "whenever")
+	assert.Error(t, err)
+}
+
+func TestEventsMatchingFromSource(t *testing.T) {
+	now := time.Now()
+	window, err := ParseWindow("tomorrow morning", now)
+	require.NoError(t, err)
+
+	standup := Meeting{Title: "Standup", Start: window.Start.Add(time.Hour), End: window.Start.Add(2 * time.Hour)}
+	review := Meeting{Title: "Design review", Start: window.Start.Add(90 * time.Minute), End: window.Start.Add(3 * time.Hour)}
+	source := &windowSource{meetings: []Meeting{standup, review}}
+
+	meetings, err := EventsMatchingFromSource(context.Background(), source, "tomorrow morning", now)
+	require.NoError(t, err)
+	assert.Equal(t, []Window{window}, source.windows)
+	require.Len(t, meetings, 2)
+	assert.True(t, meetings[0].Conflicting && meetings[1].Conflicting, "overlapping meetings are marked")
+
+	_, err = EventsMatchingFromSource(context.Background(), source, "whenever", now)
+	assert.Error(t, err)
+}
+
+// windowSource is a CalendarSource which lists the same meetings for every window, and
+// records the windows it was asked for.
+type windowSource struct {
+	meetings []Meeting
+	windows  []Window
+}
+
+func (s *windowSource) UpcomingEvents(ctx context.Context, window Window) ([]Meeting, error) {
+	s.windows = append(s.windows, window)
+	return s.meetings, nil
+}
@@ -0,0 +1,88 @@
+package zoom
+
+import (
+	"sort"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// EventReminders returns how long before the event starts each of its pop-up reminders
+// is set in Google Calendar, earliest first. Email and SMS reminders are left out. It
+// returns false if the event's reminders aren't known, such as when it uses its
+// calendar's default reminders and they weren't listed with it.
+func EventReminders(event *calendar.Event) ([]time.Duration, bool) {
+	if event == nil || event.Reminders == nil {
+		return nil, false
+	}
+	if event.Reminders.UseDefault && len(event.Reminders.Overrides) == 0 {
+		return nil, false
+	}
+	return popupReminders(event.Reminders.Overrides), true
+}
+
+// NextReminderTime returns when the event's next pop-up reminder is set to go off, and
+// false if it has no more reminders or its reminders aren't known.
+func NextReminderTime(event *calendar.Event) (time.Time, bool) {
+	start, err := MeetingStartTime(event)
+	if err != nil {
+		return time.Time{}, false
+	}
+	reminders, ok := EventReminders(event)
+	if !ok {
+		return time.Time{}, false
+	}
+	return Meeting{Start: start, Reminders: reminders}.NextReminder(time.Now())
+}
+
+// ReminderTimes returns when the meeting's reminders go off, earliest first.
+func (m Meeting) ReminderTimes() []time.Time {
+	if m.Start.IsZero() {
+		return nil
+	}
+	times := make([]time.Time, 0, len(m.Reminders))
+	for _, before := range m.Reminders {
+		times = append(times, m.Start.Add(-before))
+	}
+	return times
+}
+
+// NextReminder returns when the meeting's first reminder after now goes off, and false
+// if it has no more reminders.
+func (m Meeting) NextReminder(now time.Time) (time.Time, bool) {
+	for _, at := range m.ReminderTimes() {
+		if at.After(now) {
+			return at, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// popupReminders returns how long before an event each pop-up reminder is set, earliest
+// first, without duplicates.
+func popupReminders(reminders []*calendar.EventReminder) []time.Duration {
+	durations := []time.Duration{}
+	seen := map[int64]bool{}
+	for _, reminder := range reminders {
+		if reminder == nil || reminder.Method != "popup" || seen[reminder.Minutes] {
+			continue
+		}
+		seen[reminder.Minutes] = true
+		durations = append(durations, time.Duration(reminder.Minutes)*time.Minute)
+	}
+	sort.Slice(durations, func(i, j int) bool { return durations[i] > durations[j] })
+	return durations
+}
+
+// withDefaultReminders copies the calendar's default reminders into the events which use
+// them, so they are known once the events are apart from the calendar they were listed in.
+func withDefaultReminders(events []*calendar.Event, defaults []*calendar.EventReminder) {
+	if len(defaults) == 0 {
+		return
+	}
+	for _, event := range events {
+		if event != nil && (event.Reminders == nil || event.Reminders.UseDefault) {
+			event.Reminders = &calendar.EventReminders{UseDefault: true, Overrides: defaults}
+		}
+	}
+}
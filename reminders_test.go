@@ -0,0 +1,69 @@
+package zoom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestEventReminders(t *testing.T) {
+	reminders, ok := EventReminders(&calendar.Event{Reminders: &calendar.EventReminders{Overrides: []*calendar.EventReminder{
+		{Method: "popup", Minutes: 10},
+		{Method: "email", Minutes: 60},
+		{Method: "popup", Minutes: 30},
+		{Method: "popup", Minutes: 10},
+	}}})
+	assert.True(t, ok)
+	assert.Equal(t, []time.Duration{30 * time.Minute, 10 * time.Minute}, reminders)
+
+	reminders, ok = EventReminders(&calendar.Event{Reminders: &calendar.EventReminders{}})
+	assert.True(t, ok)
+	assert.Empty(t, reminders, "the event has no reminders")
+
+	_, ok = EventReminders(&calendar.Event{Reminders: &calendar.EventReminders{UseDefault: true}})
+	assert.False(t, ok, "the calendar's defaults weren't listed")
+	_, ok = EventReminders(&calendar.Event{})
+	assert.False(t, ok)
+	_, ok = EventReminders(nil)
+	assert.False(t, ok)
+}
+
+func TestWithDefaultReminders(t *testing.T) {
+	usesDefault := &calendar.Event{Reminders: &calendar.EventReminders{UseDefault: true}}
+	overridden := &calendar.Event{Reminders: &calendar.EventReminders{Overrides: []*calendar.EventReminder{{Method: "popup", Minutes: 1}}}}
+	withDefaultReminders([]*calendar.Event{usesDefault, overridden}, []*calendar.EventReminder{{Method: "popup", Minutes: 15}})
+
+	reminders, ok := EventReminders(usesDefault)
+	assert.True(t, ok)
+	assert.Equal(t, []time.Duration{15 * time.Minute}, reminders)
+	reminders, _ = EventReminders(overridden)
+	assert.Equal(t, []time.Duration{time.Minute}, reminders)
+}
+
+func TestNextReminderTime(t *testing.T) {
+	start := time.Now().Add(20 * time.Minute).Truncate(time.Second)
+	event := &calendar.Event{
+		Start: &calendar.EventDateTime{DateTime: start.Format(time.RFC3339)},
+		Reminders: &calendar.EventReminders{Overrides: []*calendar.EventReminder{
+			{Method: "popup", Minutes: 30},
+			{Method: "popup", Minutes: 10},
+			{Method: "popup", Minutes: 0},
+		}},
+	}
+	next, ok := NextReminderTime(event)
+	require.True(t, ok)
+	assert.True(t, next.Equal(start.Add(-10*time.Minute)), "the 30-minute reminder has gone off")
+
+	meeting := MeetingFromEvent(event, nil)
+	assert.Equal(t, []time.Duration{30 * time.Minute, 10 * time.Minute, 0}, meeting.Reminders)
+	_, ok = meeting.NextReminder(start)
+	assert.False(t, ok, "every reminder has gone off")
+
+	_, ok = NextReminderTime(&calendar.Event{Start: event.Start})
+	assert.False(t, ok)
+	_, ok = NextReminderTime(nil)
+	assert.False(t, ok)
+}
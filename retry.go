@@ -0,0 +1,133 @@
+package zoom
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+
+	"google.golang.org/api/googleapi"
+)
+
+const (
+	// defaultRetryAttempts is how many times a calendar API call is tried when RetryPolicy.Attempts is unset.
+	defaultRetryAttempts = 3
+
+	// defaultInitialBackoff is the first delay between attempts when RetryPolicy.InitialBackoff is unset.
+	defaultInitialBackoff = 500 * time.Millisecond
+
+	// defaultMaxBackoff is the longest delay between attempts when RetryPolicy.MaxBackoff is unset.
+	defaultMaxBackoff = 30 * time.Second
+)
+
+// RetryPolicy controls how calendar API calls which fail with a rate limit or a
+// temporary server error are retried. The zero value tries each call 3 times.
+type RetryPolicy struct {
+	// Attempts is the number of times to try each call. Zero means 3, and 1 disables retries.
+	Attempts int
+
+	// InitialBackoff is the delay before the first retry, which doubles after every
+	// attempt, plus up to as much again of random jitter. Zero means half a second.
+	InitialBackoff time.Duration
+
+	// MaxBackoff caps the delay between attempts, including any delay the server asks
+	// for with a Retry-After header. Zero means 30 seconds.
+	MaxBackoff time.Duration
+}
+
+// sleep waits for the duration or until the context is done. It is a variable so tests can skip the wait.
+var sleep = func(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// do calls f until it succeeds, returns an error which is not worth retrying, or runs
+// out of attempts, and returns the last error. Each retry is logged to the logger.
+func (p RetryPolicy) do(ctx context.Context, logger Logger, f func() error) error {
+	attempts := p.Attempts
+	if attempts <= 0 {
+		attempts = defaultRetryAttempts
+	}
+	backoff := p.InitialBackoff
+	if backoff <= 0 {
+		backoff = defaultInitialBackoff
+	}
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+
+	for attempt := 1; ; attempt++ {
+		err := f()
+		if err == nil || attempt >= attempts || !isRetryable(err) {
+			return err
+		}
+
+		delay, ok := retryAfter(err, time.Now())
+		if !ok {
+			delay = backoff + time.Duration(rand.Int63n(int64(backoff)+1))
+			backoff *= 2
+		}
+		if delay > maxBackoff {
+			delay = maxBackoff
+		}
+		logger.Debug("retrying calendar request", "attempt", attempt, "delay", delay, "error", err)
+
+		if sleepErr := sleep(ctx, delay); sleepErr != nil {
+			return err
+		}
+	}
+}
+
+// isRetryable returns true if the error is a rate limit or a temporary server error.
+func isRetryable(err error) bool {
+	apiErr, ok := err.(*googleapi.Error)
+	if !ok {
+		return false
+	}
+
+	switch apiErr.Code {
+	case http.StatusTooManyRequests, http.StatusInternalServerError, http.StatusBadGateway,
+		http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	case http.StatusForbidden:
+		// The Calendar API reports exceeded quotas as 403s.
+		for _, item := range apiErr.Errors {
+			if item.Reason == "rateLimitExceeded" || item.Reason == "userRateLimitExceeded" {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// retryAfter returns how long the server asked us to wait before trying again, if it did.
+func retryAfter(err error, now time.Time) (time.Duration, bool) {
+	apiErr, ok := err.(*googleapi.Error)
+	if !ok || apiErr.Header == nil {
+		return 0, false
+	}
+
+	value := apiErr.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+	if at, err := http.ParseTime(value); err == nil {
+		if at.Before(now) {
+			return 0, true
+		}
+		return at.Sub(now), true
+	}
+	return 0, false
+}
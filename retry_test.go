@@ -0,0 +1,137 @@
+package zoom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/api/googleapi"
+)
+
+func stubSleep(t *testing.T) *[]time.Duration {
+	var delays []time.Duration
+	original := sleep
+	sleep = func(ctx context.Context, d time.Duration) error {
+		delays = append(delays, d)
+		return ctx.Err()
+	}
+	t.Cleanup(func() { sleep = original })
+	return &delays
+}
+
+func TestNextEvent_RetriesTransientErrors(t *testing.T) {
+	delays := stubSleep(t)
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	requests := 0
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		switch requests {
+		case 1:
+			w.Header().Set("Retry-After", "2")
+			w.WriteHeader(http.StatusTooManyRequests)
+		case 2:
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			fmt.Fprint(w, testEventResponse)
+		}
+	})
+
+	event, err := NextEvent(service)
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, 3, requests)
+	require.Len(t, *delays, 2)
+	assert.Equal(t, 2*time.Second, (*delays)[0])
+	assert.True(t, (*delays)[1] >= defaultInitialBackoff && (*delays)[1] <= 2*defaultInitialBackoff, "backoff was %s", (*delays)[1])
+}
+
+func TestNextEvent_GivesUpAfterAttempts(t *testing.T) {
+	delays := stubSleep(t)
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	requests := 0
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	_, err := NextEventWithOptions(service, Options{Retry: RetryPolicy{Attempts: 2}})
+	require.Error(t, err)
+	assert.Equal(t, 2, requests)
+	assert.Len(t, *delays, 1)
+}
+
+func TestRetryPolicyDo(t *testing.T) {
+	delays := stubSleep(t)
+
+	calls := 0
+	err := RetryPolicy{}.do(context.Background(), nopLogger{}, func() error {
+		calls++
+		return &googleapi.Error{Code: http.StatusNotFound}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls, "errors which aren't transient aren't retried")
+
+	calls = 0
+	err = RetryPolicy{Attempts: 5, InitialBackoff: time.Second, MaxBackoff: 3 * time.Second}.do(context.Background(), nopLogger{}, func() error {
+		calls++
+		return &googleapi.Error{Code: http.StatusInternalServerError}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 5, calls)
+	for _, delay := range *delays {
+		assert.True(t, delay <= 3*time.Second, "delay %s exceeds MaxBackoff", delay)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	calls = 0
+	err = RetryPolicy{}.do(ctx, nopLogger{}, func() error {
+		calls++
+		return &googleapi.Error{Code: http.StatusBadGateway}
+	})
+	assert.Error(t, err)
+	assert.Equal(t, 1, calls, "retries stop when the context is done")
+}
+
+func TestIsRetryable(t *testing.T) {
+	assert.True(t, isRetryable(&googleapi.Error{Code: http.StatusTooManyRequests}))
+	assert.True(t, isRetryable(&googleapi.Error{Code: http.StatusServiceUnavailable}))
+	assert.True(t, isRetryable(&googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "rateLimitExceeded"}}}))
+	assert.False(t, isRetryable(&googleapi.Error{Code: http.StatusForbidden, Errors: []googleapi.ErrorItem{{Reason: "forbidden"}}}))
+	assert.False(t, isRetryable(&googleapi.Error{Code: http.StatusNotFound}))
+	assert.False(t, isRetryable(errors.New("connection refused")))
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2018, 10, 10, 12, 0, 0, 0, time.UTC)
+	withHeader := func(value string) error {
+		return &googleapi.Error{Code: http.StatusTooManyRequests, Header: http.Header{"Retry-After": []string{value}}}
+	}
+
+	delay, ok := retryAfter(withHeader("120"), now)
+	assert.True(t, ok)
+	assert.Equal(t, 2*time.Minute, delay)
+
+	delay, ok = retryAfter(withHeader("Wed, 10 Oct 2018 12:00:30 GMT"), now)
+	assert.True(t, ok)
+	assert.Equal(t, 30*time.Second, delay)
+
+	_, ok = retryAfter(withHeader("soon"), now)
+	assert.False(t, ok)
+
+	_, ok = retryAfter(&googleapi.Error{Code: http.StatusServiceUnavailable}, now)
+	assert.False(t, ok)
+}
@@ -0,0 +1,112 @@
+package zoom
+
+import (
+	"context"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// DefaultRoomHorizon is how far ahead a room's next booking is looked for.
+const DefaultRoomHorizon = 24 * time.Hour
+
+// RoomStatus is whether a room, or another resource with its own calendar, is in use.
+type RoomStatus struct {
+	// Current is the booking in progress, or nil if the room is free.
+	Current *Meeting
+
+	// Next is the first booking which starts later, or nil if there is none in the horizon.
+	Next *Meeting
+
+	// Until is when the room's status changes: when it is next free if it is in use,
+	// counting back-to-back bookings as one, or when Next starts if it is free. It is the
+	// zero time if the room is free with nothing booked in the horizon.
+	Until time.Time
+}
+
+// Free returns true if no booking is in progress.
+func (s RoomStatus) Free() bool {
+	return s.Current == nil
+}
+
+// For returns how long from now the room stays in its current status, or zero if it is
+// free with nothing booked in the horizon.
+func (s RoomStatus) For(now time.Time) time.Duration {
+	if s.Until.IsZero() {
+		return 0
+	}
+	return s.Until.Sub(now)
+}
+
+// Room returns the status of the room whose calendar has the ID, such as a Google
+// Workspace resource's email, e.g. "c_1234@resource.calendar.google.com".
+func Room(service *calendar.Service, roomID string) (RoomStatus, error) {
+	return RoomWithOptions(service, roomID, Options{})
+}
+
+// RoomWithOptions is like Room, but bookings are selected by the options. Unlike finding
+// your next meeting, every booking occupies the room whether or not it has a meeting link,
+// and bookings in progress, focus time, and bookings outside your working hours still
+// count. Bookings the room declined are skipped, unless IncludeDeclined is set.
+func RoomWithOptions(service *calendar.Service, roomID string, opts Options) (RoomStatus, error) {
+	return RoomContext(context.Background(), service, roomID, opts)
+}
+
+// RoomContext is like RoomWithOptions, but the calendar API calls are bound to the context.
+func RoomContext(ctx context.Context, service *calendar.Service, roomID string, opts Options) (RoomStatus, error) {
+	return RoomStatusFromSource(ctx, NewGoogleCalendarSource(service, RoomOptions(roomID, opts)), time.Now(), DefaultRoomHorizon)
+}
+
+// RoomOptions returns the options for a source of the bookings of the room whose calendar
+// has the ID, for RoomStatusFromSource. Every booking is listed, whether or not it is in
+// your working hours, and however long ago it started.
+func RoomOptions(roomID string, opts Options) Options {
+	opts.CalendarIDs = []string{roomID}
+	opts.AllCalendars = false
+	opts.SkipInProgressAfter = 0
+	opts.IncludeFocusTime = true
+	opts.WorkingHours = nil
+	return opts
+}
+
+// RoomStatusFromSource returns the status at now of the room whose bookings the source
+// lists, looking for its next booking up to the horizon ahead. Bookings without an end
+// time are ignored, since it can't be known how long they occupy the room.
+func RoomStatusFromSource(ctx context.Context, source CalendarSource, now time.Time, horizon time.Duration) (RoomStatus, error) {
+	meetings, err := source.UpcomingEvents(ctx, Window{Start: now, End: now.Add(horizon)})
+	if err != nil {
+		return RoomStatus{}, err
+	}
+
+	var status RoomStatus
+	for i := range meetings {
+		meeting := &meetings[i]
+		if meeting.Start.IsZero() || meeting.End.IsZero() || !meeting.End.After(now) {
+			continue
+		}
+
+		switch {
+		case !meeting.Start.After(now):
+			if status.Current == nil {
+				status.Current = meeting
+			}
+			if meeting.End.After(status.Until) {
+				status.Until = meeting.End
+			}
+		case status.Current != nil && !meeting.Start.After(status.Until):
+			// Back-to-back or overlapping bookings keep the room in use.
+			if meeting.End.After(status.Until) {
+				status.Until = meeting.End
+			}
+			if status.Next == nil {
+				status.Next = meeting
+			}
+		case status.Next == nil:
+			status.Next = meeting
+			if status.Current == nil {
+				status.Until = meeting.Start
+			}
+		}
+	}
+	return status, nil
+}
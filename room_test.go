@@ -0,0 +1,92 @@
+package zoom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// meetingsSource is a CalendarSource which lists the same meetings for every window.
+type meetingsSource []Meeting
+
+func (s meetingsSource) UpcomingEvents(ctx context.Context, window Window) ([]Meeting, error) {
+	return s, nil
+}
+
+func TestRoomStatusFromSource(t *testing.T) {
+	now := time.Date(2018, 10, 10, 10, 0, 0, 0, time.UTC)
+	at := func(hour, minute int) time.Time {
+		return time.Date(2018, 10, 10, hour, minute, 0, 0, time.UTC)
+	}
+	standup := Meeting{Title: "Standup", Start: at(9, 45), End: at(10, 15)}
+	review := Meeting{Title: "Design review", Start: at(10, 15), End: at(11, 0)}
+	lunch := Meeting{Title: "Lunch and learn", Start: at(12, 0), End: at(13, 0)}
+	ended := Meeting{Title: "Breakfast", Start: at(8, 0), End: at(9, 0)}
+	noEnd := Meeting{Title: "Reminder", Start: at(10, 30)}
+
+	testCases := []struct {
+		name     string
+		meetings []Meeting
+		current  string
+		next     string
+		until    time.Time
+	}{
+		{"free", []Meeting{ended, lunch}, "", "Lunch and learn", at(12, 0)},
+		{"free all day", []Meeting{ended}, "", "", time.Time{}},
+		{"in use", []Meeting{standup, lunch}, "Standup", "Lunch and learn", at(10, 15)},
+		{"back to back", []Meeting{standup, review, lunch}, "Standup", "Design review", at(11, 0)},
+		{"no end time", []Meeting{noEnd, lunch}, "", "Lunch and learn", at(12, 0)},
+	}
+	for _, testCase := range testCases {
+		status, err := RoomStatusFromSource(context.Background(), meetingsSource(testCase.meetings), now, DefaultRoomHorizon)
+		require.NoError(t, err, testCase.name)
+
+		title := func(m *Meeting) string {
+			if m == nil {
+				return ""
+			}
+			return m.Title
+		}
+		assert.Equal(t, testCase.current, title(status.Current), testCase.name)
+		assert.Equal(t, testCase.next, title(status.Next), testCase.name)
+		assert.Equal(t, testCase.until, status.Until, testCase.name)
+		assert.Equal(t, testCase.current == "", status.Free(), testCase.name)
+	}
+
+	status, _ := RoomStatusFromSource(context.Background(), meetingsSource{ended, lunch}, now, DefaultRoomHorizon)
+	assert.Equal(t, 2*time.Hour, status.For(now))
+	status, _ = RoomStatusFromSource(context.Background(), meetingsSource{ended}, now, DefaultRoomHorizon)
+	assert.Equal(t, time.Duration(0), status.For(now))
+}
+
+func TestRoomWithOptions(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	now := time.Now().UTC()
+	format := func(d time.Duration) string {
+		return now.Add(d).Format(time.RFC3339)
+	}
+	mux.HandleFunc("/calendars/boardroom@resource.calendar.google.com/events", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"items": [
+			{"summary": "Focus time", "start": {"dateTime": %q}, "end": {"dateTime": %q}},
+			{"summary": "Declined booking", "start": {"dateTime": %q}, "end": {"dateTime": %q},
+				"attendees": [{"email": "boardroom@resource.calendar.google.com", "self": true, "responseStatus": "declined"}]},
+			{"summary": "Planning", "start": {"dateTime": %q}, "end": {"dateTime": %q}}
+		]}`, format(-time.Hour), format(30*time.Minute), format(40*time.Minute), format(time.Hour), format(2*time.Hour), format(3*time.Hour))
+	})
+
+	status, err := RoomWithOptions(service, "boardroom@resource.calendar.google.com", Options{SkipInProgressAfter: time.Minute})
+	require.NoError(t, err)
+	require.NotNil(t, status.Current)
+	assert.Equal(t, "Focus time", status.Current.Title, "bookings in progress and focus time occupy the room")
+	require.NotNil(t, status.Next)
+	assert.Equal(t, "Planning", status.Next.Title, "bookings the room declined don't")
+}
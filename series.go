@@ -0,0 +1,120 @@
+package zoom
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// Series describes the recurring series a calendar event is an instance of.
+type Series struct {
+	// ID is the ID of the recurring event, which each instance refers to.
+	ID string
+
+	// Recurrence are the series' RRULE, EXRULE, RDATE, and EXDATE lines, as in RFC 5545.
+	Recurrence []string
+
+	// Frequency is how often the series repeats according to its RRULE, e.g. "weekly".
+	// It is empty if the series has no RRULE.
+	Frequency string
+
+	// Interval is the number of Frequency periods between occurrences, e.g. 2 for
+	// every other week.
+	Interval int
+
+	// Next is when the next occurrence after the event starts. It is the zero time if
+	// this is the last occurrence.
+	Next time.Time
+}
+
+// frequencyUnits are the RRULE frequencies which Series.Description understands, and
+// their units.
+var frequencyUnits = map[string]string{
+	"daily":   "day",
+	"weekly":  "week",
+	"monthly": "month",
+	"yearly":  "year",
+}
+
+// Description describes how often the series repeats, e.g. "weekly" or "every 2 weeks".
+func (s *Series) Description() string {
+	unit, ok := frequencyUnits[s.Frequency]
+	switch {
+	case !ok:
+		return "recurring"
+	case s.Interval > 1:
+		return fmt.Sprintf("every %d %ss", s.Interval, unit)
+	}
+	return s.Frequency
+}
+
+// IsRecurring returns true if the event is an instance of a recurring series.
+func IsRecurring(event *calendar.Event) bool {
+	return event != nil && event.RecurringEventId != ""
+}
+
+// EventSeries returns the recurring series the event in the calendar is an instance of,
+// or nil if it is not recurring.
+func EventSeries(service *calendar.Service, calendarID string, event *calendar.Event) (*Series, error) {
+	return EventSeriesContext(context.Background(), service, calendarID, event)
+}
+
+// EventSeriesContext is like EventSeries, but the calendar API calls are bound to the context.
+func EventSeriesContext(ctx context.Context, service *calendar.Service, calendarID string, event *calendar.Event) (*Series, error) {
+	if !IsRecurring(event) {
+		return nil, nil
+	}
+
+	recurring, err := service.Events.Get(calendarID, event.RecurringEventId).Context(ctx).Do()
+	if err != nil {
+		return nil, fmt.Errorf("error getting recurring event %s: %w", event.RecurringEventId, calendarError(err))
+	}
+	series := &Series{ID: event.RecurringEventId, Recurrence: recurring.Recurrence, Interval: 1}
+	parseRecurrence(series)
+
+	start, err := MeetingStartTime(event)
+	if err != nil {
+		return series, nil
+	}
+	// Instances which end after the event starts include the event itself, so list two.
+	instances, err := service.Events.Instances(calendarID, series.ID).
+		TimeMin(start.Format(time.RFC3339)).
+		MaxResults(2).
+		Context(ctx).
+		Do()
+	if err != nil {
+		return nil, fmt.Errorf("error listing instances of recurring event %s: %w", series.ID, calendarError(err))
+	}
+	for _, instance := range instances.Items {
+		if next, err := MeetingStartTime(instance); err == nil && next.After(start) {
+			series.Next = next
+			break
+		}
+	}
+	return series, nil
+}
+
+// parseRecurrence sets the series' Frequency and Interval from its RRULE.
+func parseRecurrence(series *Series) {
+	for _, line := range series.Recurrence {
+		if !strings.HasPrefix(line, "RRULE:") {
+			continue
+		}
+		for _, part := range strings.Split(strings.TrimPrefix(line, "RRULE:"), ";") {
+			key, value, _ := strings.Cut(part, "=")
+			switch key {
+			case "FREQ":
+				series.Frequency = strings.ToLower(value)
+			case "INTERVAL":
+				if interval, err := strconv.Atoi(value); err == nil && interval > 0 {
+					series.Interval = interval
+				}
+			}
+		}
+		return
+	}
+}
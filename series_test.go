@@ -0,0 +1,141 @@
+package zoom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func handleStandupSeries(t *testing.T, mux *http.ServeMux, start time.Time, gets *int) {
+	mux.HandleFunc("/calendars/primary/events/standup", func(w http.ResponseWriter, r *http.Request) {
+		*gets++
+		fmt.Fprint(w, `{"id": "standup", "recurrence": ["EXDATE;TZID=America/Los_Angeles:20181012T090000", "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=WE"]}`)
+	})
+	mux.HandleFunc("/calendars/primary/events/standup/instances", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, start.Format(time.RFC3339), r.URL.Query().Get("timeMin"))
+		fmt.Fprintf(w, `{"items": [
+			{"id": "standup_1", "start": {"dateTime": %q}},
+			{"id": "standup_2", "start": {"dateTime": %q}}
+		]}`, start.Format(time.RFC3339), start.AddDate(0, 0, 14).Format(time.RFC3339))
+	})
+}
+
+func TestEventSeries(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	start := time.Date(2018, 10, 10, 9, 0, 0, 0, time.UTC)
+	var gets int
+	handleStandupSeries(t, mux, start, &gets)
+
+	series, err := EventSeries(service, "primary", &calendar.Event{
+		Id:               "standup_1",
+		RecurringEventId: "standup",
+		Start:            &calendar.EventDateTime{DateTime: start.Format(googleCalendarDateTimeFormat)},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, &Series{
+		ID:         "standup",
+		Recurrence: []string{"EXDATE;TZID=America/Los_Angeles:20181012T090000", "RRULE:FREQ=WEEKLY;INTERVAL=2;BYDAY=WE"},
+		Frequency:  "weekly",
+		Interval:   2,
+		Next:       start.AddDate(0, 0, 14),
+	}, series)
+
+	series, err = EventSeries(service, "primary", &calendar.Event{Id: "one-off"})
+	require.NoError(t, err)
+	assert.Nil(t, series)
+}
+
+func TestSeries_Description(t *testing.T) {
+	assert.Equal(t, "weekly", (&Series{Frequency: "weekly", Interval: 1}).Description())
+	assert.Equal(t, "every 2 weeks", (&Series{Frequency: "weekly", Interval: 2}).Description())
+	assert.Equal(t, "daily", (&Series{Frequency: "daily"}).Description())
+	assert.Equal(t, "recurring", (&Series{Frequency: "hourly"}).Description())
+	assert.Equal(t, "recurring", (&Series{}).Description())
+}
+
+func TestGoogleCalendarSource_DescribeSeries(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+	var gets int
+	handleStandupSeries(t, mux, start, &gets)
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"items": [
+			{"id": "standup_1", "summary": "Standup", "recurringEventId": "standup", "start": {"dateTime": %q}},
+			{"id": "one-off", "summary": "Interview", "start": {"dateTime": %q}}
+		]}`, start.Format(time.RFC3339), start.Add(time.Hour).Format(time.RFC3339))
+	})
+
+	source := NewGoogleCalendarSource(service, Options{DescribeSeries: true})
+	for i := 0; i < 2; i++ {
+		meetings, err := source.UpcomingEvents(context.Background(), Window{Start: time.Now()})
+		require.NoError(t, err)
+		require.Len(t, meetings, 2)
+		assert.Equal(t, "standup", meetings[0].SeriesID)
+		require.NotNil(t, meetings[0].Series)
+		assert.Equal(t, "every 2 weeks", meetings[0].Series.Description())
+		assert.Nil(t, meetings[1].Series)
+	}
+	assert.Equal(t, 1, gets, "each series is only looked up once")
+}
+
+func TestGoogleCalendarSource_DescribeSeries_Errors(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+	var gets, goneGets int
+	status := http.StatusServiceUnavailable
+	mux.HandleFunc("/calendars/primary/events/standup", func(w http.ResponseWriter, r *http.Request) {
+		gets++
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		fmt.Fprint(w, `{"id": "standup", "recurrence": ["RRULE:FREQ=DAILY"]}`)
+	})
+	mux.HandleFunc("/calendars/primary/events/standup/instances", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items": []}`)
+	})
+	mux.HandleFunc("/calendars/primary/events/gone", func(w http.ResponseWriter, r *http.Request) {
+		goneGets++
+		w.WriteHeader(http.StatusNotFound)
+	})
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"items": [
+			{"id": "standup_1", "summary": "Standup", "recurringEventId": "standup", "start": {"dateTime": %q}},
+			{"id": "gone_1", "summary": "Retro", "recurringEventId": "gone", "start": {"dateTime": %q}}
+		]}`, start.Format(time.RFC3339), start.Add(time.Hour).Format(time.RFC3339))
+	})
+
+	source := NewGoogleCalendarSource(service, Options{DescribeSeries: true})
+	meetings, err := source.UpcomingEvents(context.Background(), Window{Start: time.Now()})
+	require.NoError(t, err)
+	require.Len(t, meetings, 2)
+	assert.Nil(t, meetings[0].Series, "the series can't be looked up for now")
+	assert.Nil(t, meetings[1].Series)
+
+	status = http.StatusOK
+	meetings, err = source.UpcomingEvents(context.Background(), Window{Start: time.Now()})
+	require.NoError(t, err)
+	require.NotNil(t, meetings[0].Series, "series which couldn't be looked up are tried again")
+	assert.Equal(t, "daily", meetings[0].Series.Description())
+	assert.Nil(t, meetings[1].Series)
+	assert.Equal(t, 2, gets)
+	assert.Equal(t, 1, goneGets, "series which don't exist aren't looked up again")
+}
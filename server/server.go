@@ -0,0 +1,273 @@
+// Package server serves your next meeting and agenda as JSON over HTTP, so other tools,
+// such as Stream Deck plugins and dashboards, can read them without calendar credentials.
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/benbalter/zoom-go"
+	"github.com/benbalter/zoom-go/metrics"
+)
+
+const (
+	// DefaultHorizon is how far ahead the server looks for meetings when Server.Horizon is unset.
+	DefaultHorizon = 24 * time.Hour
+
+	// DefaultCacheFor is how long meetings are reused when Server.CacheFor is unset.
+	DefaultCacheFor = 30 * time.Second
+
+	// DefaultJoinCacheFor is how long meetings are reused by POST /join when
+	// Server.JoinCacheFor is unset.
+	DefaultJoinCacheFor = 5 * time.Minute
+)
+
+// Server serves these endpoints:
+//
+//	GET /next     the next meeting as a zoom.MeetingJSON object, or null
+//	GET /agenda   the meetings within the horizon as an array of zoom.MeetingJSON
+//	GET /healthz  {"status": "ok"}
+//	GET /metrics  the server's metrics, in the Prometheus text format
+//	POST /join    open the current or next meeting, and return it as a zoom.MeetingJSON object
+//
+// Errors are returned as {"error": "..."}. POST /join is meant to be bound to a hotkey or
+// a Stream Deck button, so it answers from the meetings already read if it can, and is
+// refused if Open is unset or the request comes from a web page.
+type Server struct {
+	// Source is the calendar to read.
+	Source zoom.CalendarSource
+
+	// Horizon is how far ahead to look for meetings. Zero means DefaultHorizon.
+	Horizon time.Duration
+
+	// CacheFor is how long to reuse the meetings read from Source, so frequent polling
+	// doesn't exhaust the calendar API's quota. Zero means DefaultCacheFor.
+	CacheFor time.Duration
+
+	// JoinCacheFor is how long POST /join reuses the meetings read from Source, so it can
+	// open your meeting without waiting for your calendar. A meeting rarely moves at the
+	// last minute. Zero means DefaultJoinCacheFor.
+	JoinCacheFor time.Duration
+
+	// Open opens the meeting at the URL for POST /join. If nil, POST /join is refused.
+	Open func(meeting zoom.Meeting, u *url.URL) error
+
+	// URLOptions chooses which of a meeting's URLs POST /join opens.
+	URLOptions zoom.URLOptions
+
+	// Metrics records the server's requests to Source and its cache hits and misses.
+	Metrics *metrics.Metrics
+
+	mux       *http.ServeMux
+	mu        sync.Mutex
+	meetings  []zoom.Meeting
+	fetchedAt time.Time
+}
+
+// New returns a server which reads meetings from the source.
+func New(source zoom.CalendarSource) *Server {
+	s := &Server{Source: source, Metrics: &metrics.Metrics{}, mux: http.NewServeMux()}
+	s.mux.HandleFunc("/next", s.handleNext)
+	s.mux.HandleFunc("/agenda", s.handleAgenda)
+	s.mux.HandleFunc("/healthz", s.handleHealthz)
+	s.mux.HandleFunc("/metrics", s.handleMetrics)
+	s.mux.HandleFunc("/join", s.handleJoin)
+	return s
+}
+
+// ServeHTTP serves the request.
+func (s *Server) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.URL.Path == "/join" {
+		if r.Method != http.MethodPost {
+			w.Header().Set("Allow", "POST")
+			writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+			return
+		}
+	} else if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	s.mux.ServeHTTP(w, r)
+}
+
+func (s *Server) handleNext(w http.ResponseWriter, r *http.Request) {
+	meetings, err := s.upcoming(r.Context(), time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	// The cached meetings can't fail to be listed, so the only error is ErrNoUpcomingEvents.
+	next, _ := zoom.NextMeetingFromSource(r.Context(), meetingList(meetings), zoom.Window{})
+	w.Header().Set("Content-Type", "application/json")
+	zoom.WriteMeetingJSON(w, next)
+}
+
+func (s *Server) handleAgenda(w http.ResponseWriter, r *http.Request) {
+	meetings, err := s.upcoming(r.Context(), time.Now())
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	zoom.WriteMeetingsJSON(w, meetings)
+}
+
+func (s *Server) handleJoin(w http.ResponseWriter, r *http.Request) {
+	// Any web page can POST to localhost, but only you should be able to open your meetings.
+	if r.Header.Get("Origin") != "" {
+		writeError(w, http.StatusForbidden, "joining from a web page is not allowed")
+		return
+	}
+	if s.Open == nil {
+		writeError(w, http.StatusForbidden, "joining is not enabled")
+		return
+	}
+
+	now := time.Now()
+	meetings, err := s.fetchWithin(r.Context(), now, s.joinCacheFor())
+	if err != nil {
+		writeError(w, http.StatusBadGateway, err.Error())
+		return
+	}
+	next, err := zoom.NextMeetingFromSource(r.Context(), meetingList(notEnded(meetings, now)), zoom.Window{})
+	if err != nil {
+		writeError(w, http.StatusNotFound, "no upcoming meetings")
+		return
+	}
+	u := next.URL(s.URLOptions)
+	if u == nil {
+		writeError(w, http.StatusNotFound, fmt.Sprintf("no meeting URL found in %q", next.Title))
+		return
+	}
+	if err := s.Open(*next, u); err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	zoom.WriteMeetingJSON(w, next)
+}
+
+// Warm reads meetings from the source whenever those POST /join would use go stale, until
+// the context is done, so that joining never waits for your calendar. Errors are ignored,
+// since the next request will read the meetings itself.
+func (s *Server) Warm(ctx context.Context) {
+	for {
+		s.fetchWithin(ctx, time.Now(), 0)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(s.joinCacheFor() * 4 / 5):
+		}
+	}
+}
+
+func (s *Server) handleHealthz(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, map[string]string{"status": "ok"})
+}
+
+func (s *Server) handleMetrics(w http.ResponseWriter, r *http.Request) {
+	now := time.Now()
+	// The calendar's metrics are still worth reporting if it can't be read.
+	if meetings, err := s.fetch(r.Context(), now); err == nil {
+		s.Metrics.ObserveMeetings(now, meetings)
+	}
+
+	w.Header().Set("Content-Type", metrics.ContentType)
+	s.Metrics.Write(w, now)
+}
+
+// upcoming returns the meetings which haven't ended as of now.
+func (s *Server) upcoming(ctx context.Context, now time.Time) ([]zoom.Meeting, error) {
+	meetings, err := s.fetch(ctx, now)
+	if err != nil {
+		return nil, err
+	}
+	return notEnded(meetings, now), nil
+}
+
+// notEnded returns the meetings which haven't ended as of now.
+func notEnded(meetings []zoom.Meeting, now time.Time) []zoom.Meeting {
+	var upcoming []zoom.Meeting
+	for _, meeting := range meetings {
+		if meeting.End.IsZero() || meeting.End.After(now) {
+			upcoming = append(upcoming, meeting)
+		}
+	}
+	return upcoming
+}
+
+// fetch returns the meetings from the start of today until the horizon, or the end of
+// today if that is later, reading them from the source if they weren't read within CacheFor.
+func (s *Server) fetch(ctx context.Context, now time.Time) ([]zoom.Meeting, error) {
+	cacheFor := s.CacheFor
+	if cacheFor <= 0 {
+		cacheFor = DefaultCacheFor
+	}
+	return s.fetchWithin(ctx, now, cacheFor)
+}
+
+// fetchWithin is like fetch, but reuses the meetings if they were read within cacheFor.
+func (s *Server) fetchWithin(ctx context.Context, now time.Time, cacheFor time.Duration) ([]zoom.Meeting, error) {
+	horizon := s.Horizon
+	if horizon <= 0 {
+		horizon = DefaultHorizon
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.fetchedAt.IsZero() && now.Sub(s.fetchedAt) < cacheFor {
+		s.Metrics.CacheHit()
+		return s.meetings, nil
+	}
+	s.Metrics.CacheMiss()
+
+	window := zoom.TodayWindow(now)
+	if end := now.Add(horizon); end.After(window.End) {
+		window.End = end
+	}
+	meetings, err := s.Metrics.Instrument(s.Source).UpcomingEvents(ctx, window)
+	if err != nil {
+		return nil, err
+	}
+	s.meetings, s.fetchedAt = meetings, now
+	return meetings, nil
+}
+
+// joinCacheFor returns how long POST /join reuses meetings.
+func (s *Server) joinCacheFor() time.Duration {
+	if s.JoinCacheFor <= 0 {
+		return DefaultJoinCacheFor
+	}
+	return s.JoinCacheFor
+}
+
+// meetingList is a CalendarSource which lists the same meetings for every window.
+type meetingList []zoom.Meeting
+
+func (l meetingList) UpcomingEvents(ctx context.Context, window zoom.Window) ([]zoom.Meeting, error) {
+	return l, nil
+}
+
+// writeJSON writes the value as the JSON response.
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(v)
+}
+
+// writeError writes a JSON error response with the status code.
+func writeError(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	json.NewEncoder(w).Encode(map[string]string{"error": message})
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benbalter/zoom-go"
+)
+
+type fakeSource struct {
+	meetings []zoom.Meeting
+	err      error
+	calls    int
+}
+
+func (s *fakeSource) UpcomingEvents(ctx context.Context, window zoom.Window) ([]zoom.Meeting, error) {
+	s.calls++
+	return s.meetings, s.err
+}
+
+func get(t *testing.T, handler http.Handler, method, path string) *httptest.ResponseRecorder {
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(method, path, nil))
+	return w
+}
+
+func TestServer(t *testing.T) {
+	now := time.Now()
+	joinURL, _ := url.Parse("https://jithub.zoom.us/j/12345")
+	source := &fakeSource{meetings: []zoom.Meeting{
+		{ID: "ended", Title: "Breakfast", Start: now.Add(-time.Hour), End: now.Add(-time.Minute)},
+		{ID: "lunch", Title: "Lunch", Start: now.Add(time.Hour), End: now.Add(2 * time.Hour)},
+		{ID: "standup", Title: "Standup", Start: now.Add(3 * time.Hour), End: now.Add(4 * time.Hour), JoinURL: joinURL},
+	}}
+	s := New(source)
+
+	w := get(t, s, "GET", "/next")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Contains(t, w.Body.String(), `"id":"standup"`)
+	assert.Contains(t, w.Body.String(), `"join_url":"https://jithub.zoom.us/j/12345"`)
+
+	w = get(t, s, "GET", "/agenda")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.NotContains(t, w.Body.String(), "Breakfast", "meetings which have ended are skipped")
+	assert.Contains(t, w.Body.String(), `"title":"Lunch"`)
+	assert.Contains(t, w.Body.String(), `"title":"Standup"`)
+
+	assert.Equal(t, 1, source.calls, "meetings are cached")
+
+	w = get(t, s, "GET", "/healthz")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status": "ok"}`, w.Body.String())
+
+	w = get(t, s, "GET", "/metrics")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "\nzoom_calendar_requests_total 1\n")
+	assert.Contains(t, w.Body.String(), "\nzoom_cache_hits_total 2\n")
+	assert.Contains(t, w.Body.String(), "\nzoom_cache_misses_total 1\n")
+
+	w = get(t, s, "POST", "/next")
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "GET, HEAD", w.Header().Get("Allow"))
+}
+
+func TestServer_NoMeetings(t *testing.T) {
+	s := New(&fakeSource{})
+
+	assert.Equal(t, "null\n", get(t, s, "GET", "/next").Body.String())
+	assert.Equal(t, "[]\n", get(t, s, "GET", "/agenda").Body.String())
+}
+
+func TestServer_Errors(t *testing.T) {
+	source := &fakeSource{err: errors.New("calendar is unreachable")}
+	s := New(source)
+	s.CacheFor = time.Nanosecond
+
+	w := get(t, s, "GET", "/next")
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.JSONEq(t, `{"error": "calendar is unreachable"}`, w.Body.String())
+
+	source.err = nil
+	assert.Equal(t, http.StatusOK, get(t, s, "GET", "/next").Code, "errors aren't cached")
+}
+
+func TestServer_Join(t *testing.T) {
+	now := time.Now()
+	joinURL, _ := url.Parse("https://jithub.zoom.us/j/12345")
+	source := &fakeSource{meetings: []zoom.Meeting{
+		{ID: "ended", Title: "Breakfast", Start: now.Add(-time.Hour), End: now.Add(-time.Minute), JoinURL: joinURL},
+		{ID: "standup", Title: "Standup", Start: now.Add(-time.Minute), End: now.Add(time.Hour), JoinURL: joinURL},
+	}}
+	s := New(source)
+	s.CacheFor = time.Nanosecond
+
+	assert.Equal(t, http.StatusForbidden, get(t, s, "POST", "/join").Code, "joining is off unless Open is set")
+
+	var opened []string
+	s.Open = func(meeting zoom.Meeting, u *url.URL) error {
+		opened = append(opened, meeting.ID+" "+u.String())
+		return nil
+	}
+	get(t, s, "GET", "/next")
+	w := get(t, s, "POST", "/join")
+	require.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `"id":"standup"`)
+	assert.Equal(t, []string{"standup https://jithub.zoom.us/j/12345"}, opened, "the meeting in progress is joined")
+	assert.Equal(t, 1, source.calls, "joining reuses meetings for longer than CacheFor")
+
+	s.URLOptions = zoom.URLOptions{PreferDeepLink: true}
+	source.meetings = []zoom.Meeting{{Title: "Lunch", Start: now.Add(time.Hour)}}
+	s.JoinCacheFor = time.Nanosecond
+	w = get(t, s, "POST", "/join")
+	assert.Equal(t, http.StatusNotFound, w.Code)
+	assert.JSONEq(t, `{"error": "no meeting URL found in \"Lunch\""}`, w.Body.String())
+
+	source.meetings = nil
+	assert.Equal(t, http.StatusNotFound, get(t, s, "POST", "/join").Code)
+
+	w = httptest.NewRecorder()
+	r := httptest.NewRequest("POST", "/join", nil)
+	r.Header.Set("Origin", "https://example.com")
+	s.ServeHTTP(w, r)
+	assert.Equal(t, http.StatusForbidden, w.Code, "web pages can't join meetings")
+
+	w = get(t, s, "GET", "/join")
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+	assert.Equal(t, "POST", w.Header().Get("Allow"))
+	assert.Len(t, opened, 1)
+}
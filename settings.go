@@ -0,0 +1,88 @@
+package zoom
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/benbalter/zoom-go/config"
+)
+
+// OptionsFromSettings returns the options for finding meetings described by the settings.
+// Provider names are matched against AllProviders, ignoring case, spaces, and dashes.
+func OptionsFromSettings(settings config.Settings) (Options, error) {
+	opts := Options{
+		CalendarIDs:  settings.CalendarIDs,
+		AllCalendars: settings.AllCalendars,
+		Horizon:      settings.Horizon,
+		MaxResults:   settings.MaxResults,
+		Concurrency:  settings.Concurrency,
+	}
+
+	include := Filter{OrganizerDomains: settings.IncludeDomains, ColorIDs: settings.IncludeColors}
+	if settings.IncludeTitle != "" {
+		re, err := compileFilterPattern("include_title", settings.IncludeTitle)
+		if err != nil {
+			return opts, err
+		}
+		include.Summary = re
+	}
+	if include.Summary != nil || len(include.OrganizerDomains) > 0 || len(include.ColorIDs) > 0 {
+		opts.Include = []Filter{include}
+	}
+
+	// Each exclusion skips events on its own, rather than only events matching them all.
+	if settings.ExcludeTitle != "" {
+		re, err := compileFilterPattern("exclude_title", settings.ExcludeTitle)
+		if err != nil {
+			return opts, err
+		}
+		opts.Exclude = append(opts.Exclude, Filter{Summary: re})
+	}
+	if len(settings.ExcludeDomains) > 0 {
+		opts.Exclude = append(opts.Exclude, Filter{OrganizerDomains: settings.ExcludeDomains})
+	}
+	if len(settings.ExcludeColors) > 0 {
+		opts.Exclude = append(opts.Exclude, Filter{ColorIDs: settings.ExcludeColors})
+	}
+
+	if len(settings.WorkingHours) > 0 {
+		hours, err := ParseWorkingHours(settings.WorkingHours)
+		if err != nil {
+			return opts, err
+		}
+		opts.WorkingHours = hours
+	}
+
+	for _, name := range settings.Providers {
+		provider, ok := providerNamed(name)
+		if !ok {
+			return opts, fmt.Errorf("unknown provider %q", name)
+		}
+		opts.Providers = append(opts.Providers, provider)
+	}
+	return opts, nil
+}
+
+// URLOptionsFromSettings returns the options for choosing a meeting's URL described by the settings.
+func URLOptionsFromSettings(settings config.Settings) URLOptions {
+	return URLOptions{PreferDeepLink: settings.PreferDeepLink}
+}
+
+// JoinRulesFromSettings returns the rules for joining meetings described by the settings.
+func JoinRulesFromSettings(settings config.Settings) ([]JoinRule, error) {
+	return ParseJoinRules(settings.JoinRules)
+}
+
+// providerNamed returns the built-in provider with the name.
+func providerNamed(name string) (Provider, bool) {
+	for _, provider := range AllProviders {
+		if normalizeProviderName(provider.Name()) == normalizeProviderName(name) {
+			return provider, true
+		}
+	}
+	return nil, false
+}
+
+func normalizeProviderName(name string) string {
+	return strings.NewReplacer(" ", "", "-", "", "_", "").Replace(strings.ToLower(name))
+}
@@ -0,0 +1,69 @@
+package zoom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benbalter/zoom-go/config"
+)
+
+func TestOptionsFromSettings(t *testing.T) {
+	opts, err := OptionsFromSettings(config.Settings{
+		CalendarIDs: []string{"team@jithub.com"},
+		Horizon:     24 * time.Hour,
+		MaxResults:  25,
+		Concurrency: 8,
+		Providers:   []string{"zoom", "Google Meet", "microsoft-teams"},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team@jithub.com"}, opts.CalendarIDs)
+	assert.Equal(t, 24*time.Hour, opts.Horizon)
+	assert.Equal(t, int64(25), opts.MaxResults)
+	assert.Equal(t, 8, opts.Concurrency)
+	assert.Equal(t, []Provider{ZoomProvider, GoogleMeetProvider, MicrosoftTeamsProvider}, opts.Providers)
+
+	_, err = OptionsFromSettings(config.Settings{Providers: []string{"skype"}})
+	assert.EqualError(t, err, `unknown provider "skype"`)
+
+	opts, err = OptionsFromSettings(config.Settings{WorkingHours: []string{"mon-fri 09:00-17:00"}})
+	require.NoError(t, err)
+	assert.Equal(t, []HoursSpan{{9 * time.Hour, 17 * time.Hour}}, opts.WorkingHours[time.Friday])
+
+	opts, err = OptionsFromSettings(config.Settings{
+		IncludeDomains: []string{"jithub.com"},
+		IncludeColors:  []string{"tomato"},
+		ExcludeTitle:   `(?i)\blunch\b`,
+		ExcludeColors:  []string{"basil"},
+	})
+	require.NoError(t, err)
+	require.Len(t, opts.Include, 1)
+	assert.Equal(t, []string{"jithub.com"}, opts.Include[0].OrganizerDomains)
+	assert.Equal(t, []string{"tomato"}, opts.Include[0].ColorIDs)
+	assert.Nil(t, opts.Include[0].Summary)
+	require.Len(t, opts.Exclude, 2)
+	assert.Equal(t, `(?i)\blunch\b`, opts.Exclude[0].Summary.String())
+	assert.Equal(t, []string{"basil"}, opts.Exclude[1].ColorIDs)
+
+	_, err = OptionsFromSettings(config.Settings{IncludeTitle: "(standup"})
+	assert.Contains(t, err.Error(), "invalid include_title")
+
+	_, err = OptionsFromSettings(config.Settings{WorkingHours: []string{"weekdays"}})
+	assert.EqualError(t, err, `working hours "weekdays" should look like "mon-fri 09:00-17:00"`)
+}
+
+func TestURLOptionsFromSettings(t *testing.T) {
+	assert.Equal(t, URLOptions{PreferDeepLink: true}, URLOptionsFromSettings(config.DefaultSettings()))
+	assert.Equal(t, URLOptions{}, URLOptionsFromSettings(config.Settings{}))
+}
+
+func TestJoinRulesFromSettings(t *testing.T) {
+	rules, err := JoinRulesFromSettings(config.Settings{JoinRules: []string{"teams copy"}})
+	require.NoError(t, err)
+	assert.Equal(t, []JoinRule{{Match: "teams", Action: JoinCopy}}, rules)
+
+	_, err = JoinRulesFromSettings(config.Settings{JoinRules: []string{"teams"}})
+	assert.EqualError(t, err, `join rule "teams" should look like "zoom.us app"`)
+}
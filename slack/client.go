@@ -0,0 +1,103 @@
+// Package slack sets your Slack status while you are in a meeting.
+package slack
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"golang.org/x/net/context/ctxhttp"
+)
+
+// DefaultBaseURL is the base URL of Slack's Web API.
+const DefaultBaseURL = "https://slack.com/api/"
+
+// Client calls the Slack Web API on your behalf.
+type Client struct {
+	// Token is a user token with the users.profile:write scope, e.g. "xoxp-...".
+	Token string
+
+	// BaseURL is the base URL of the Web API. Empty means DefaultBaseURL.
+	BaseURL string
+
+	// HTTPClient makes the requests. Nil means http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// NewClient returns a client which authenticates with the user token.
+func NewClient(token string) *Client {
+	return &Client{Token: token}
+}
+
+// Status is a Slack status.
+type Status struct {
+	// Text is the status text, e.g. "In a meeting until 3:30 PM".
+	Text string
+
+	// Emoji is the status emoji, e.g. ":movie_camera:".
+	Emoji string
+
+	// Expiration is when Slack clears the status. The zero time means never.
+	Expiration time.Time
+}
+
+// SetStatus sets your status.
+func (c *Client) SetStatus(ctx context.Context, status Status) error {
+	var expiration int64
+	if !status.Expiration.IsZero() {
+		expiration = status.Expiration.Unix()
+	}
+	return c.setProfile(ctx, map[string]interface{}{
+		"status_text":       status.Text,
+		"status_emoji":      status.Emoji,
+		"status_expiration": expiration,
+	})
+}
+
+// ClearStatus clears your status.
+func (c *Client) ClearStatus(ctx context.Context) error {
+	return c.SetStatus(ctx, Status{})
+}
+
+// setProfile calls users.profile.set with the profile fields.
+func (c *Client) setProfile(ctx context.Context, profile map[string]interface{}) error {
+	body, err := json.Marshal(map[string]interface{}{"profile": profile})
+	if err != nil {
+		return err
+	}
+
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	req, err := http.NewRequest(http.MethodPost, baseURL+"users.profile.set", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+c.Token)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	resp, err := ctxhttp.Do(ctx, c.HTTPClient, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("setting Slack status: %s", resp.Status)
+	}
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+		return fmt.Errorf("setting Slack status: %w", err)
+	}
+	if !result.OK {
+		return fmt.Errorf("setting Slack status: %s", result.Error)
+	}
+	return nil
+}
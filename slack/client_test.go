@@ -0,0 +1,58 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestClient(t *testing.T, handler http.HandlerFunc) *Client {
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+	return &Client{Token: "xoxp-test", BaseURL: server.URL + "/"}
+}
+
+func TestClientSetStatus(t *testing.T) {
+	var profile map[string]interface{}
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/users.profile.set", r.URL.Path)
+		assert.Equal(t, "Bearer xoxp-test", r.Header.Get("Authorization"))
+
+		var body struct {
+			Profile map[string]interface{} `json:"profile"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		profile = body.Profile
+		fmt.Fprint(w, `{"ok": true}`)
+	})
+
+	expiration := time.Date(2018, 10, 10, 15, 30, 0, 0, time.UTC)
+	require.NoError(t, client.SetStatus(context.Background(), Status{Text: "In a meeting", Emoji: ":movie_camera:", Expiration: expiration}))
+	assert.Equal(t, map[string]interface{}{
+		"status_text":       "In a meeting",
+		"status_emoji":      ":movie_camera:",
+		"status_expiration": float64(expiration.Unix()),
+	}, profile)
+
+	require.NoError(t, client.ClearStatus(context.Background()))
+	assert.Equal(t, map[string]interface{}{"status_text": "", "status_emoji": "", "status_expiration": float64(0)}, profile)
+}
+
+func TestClientSetStatus_Errors(t *testing.T) {
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"ok": false, "error": "invalid_auth"}`)
+	})
+	assert.EqualError(t, client.ClearStatus(context.Background()), "setting Slack status: invalid_auth")
+
+	client = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+	assert.EqualError(t, client.ClearStatus(context.Background()), "setting Slack status: 429 Too Many Requests")
+}
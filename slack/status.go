@@ -0,0 +1,123 @@
+package slack
+
+import (
+	"context"
+	"time"
+
+	"github.com/benbalter/zoom-go"
+)
+
+const (
+	// DefaultEmoji is the status emoji used when StatusUpdater.Emoji is unset.
+	DefaultEmoji = ":movie_camera:"
+
+	// DefaultInterval is how often the calendar is checked when StatusUpdater.Interval is unset.
+	DefaultInterval = time.Minute
+)
+
+// StatusUpdater sets your Slack status when each meeting starts, and clears it when the meeting ends.
+type StatusUpdater struct {
+	// Source is the calendar to watch.
+	Source zoom.CalendarSource
+
+	// Client sets the status.
+	Client *Client
+
+	// Interval is how often to check the calendar. Zero means DefaultInterval.
+	Interval time.Duration
+
+	// Emoji is the status emoji. Empty means DefaultEmoji.
+	Emoji string
+
+	// Text returns the status text for a meeting. Nil means StatusText.
+	Text func(zoom.Meeting) string
+
+	// OnError is called when the calendar cannot be read or the status cannot be set.
+	// The updater keeps running afterwards. If nil, errors are ignored.
+	OnError func(error)
+
+	// current identifies the meeting the status was set for, if any.
+	current string
+}
+
+// StatusText returns the default status text for a meeting, e.g. "In a meeting until 3:30 PM".
+func StatusText(meeting zoom.Meeting) string {
+	if meeting.End.IsZero() {
+		return "In a meeting"
+	}
+	return "In a meeting until " + zoom.Formatter.AbsoluteTime(zoom.InLocation(meeting.End))
+}
+
+// Run checks the calendar until the context is done, updating your status as meetings
+// start and end. It always returns the context's error.
+func (u *StatusUpdater) Run(ctx context.Context) error {
+	interval := u.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := u.check(ctx, time.Now()); err != nil && ctx.Err() == nil && u.OnError != nil {
+			u.OnError(err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// check sets your status if a meeting is in progress, or clears it if the meeting it was
+// set for has ended. A status you set yourself is never cleared.
+func (u *StatusUpdater) check(ctx context.Context, now time.Time) error {
+	meetings, err := u.Source.UpcomingEvents(ctx, zoom.Window{Start: now, End: now.Add(time.Second)})
+	if err != nil {
+		return err
+	}
+
+	for _, meeting := range meetings {
+		if !inProgress(meeting, now) {
+			continue
+		}
+		key := meeting.ID + " " + meeting.Start.String()
+		if key == u.current {
+			return nil
+		}
+
+		text := StatusText
+		if u.Text != nil {
+			text = u.Text
+		}
+		emoji := u.Emoji
+		if emoji == "" {
+			emoji = DefaultEmoji
+		}
+		if err := u.Client.SetStatus(ctx, Status{Text: text(meeting), Emoji: emoji, Expiration: meeting.End}); err != nil {
+			return err
+		}
+		u.current = key
+		return nil
+	}
+
+	if u.current == "" {
+		return nil
+	}
+	if err := u.Client.ClearStatus(ctx); err != nil {
+		return err
+	}
+	u.current = ""
+	return nil
+}
+
+// inProgress returns true if the meeting has started and not yet ended.
+func inProgress(meeting zoom.Meeting, now time.Time) bool {
+	if meeting.Start.IsZero() || meeting.Start.After(now) {
+		return false
+	}
+	return meeting.End.IsZero() || now.Before(meeting.End)
+}
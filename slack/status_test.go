@@ -0,0 +1,74 @@
+package slack
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benbalter/zoom-go"
+)
+
+type fakeSource struct {
+	meetings []zoom.Meeting
+}
+
+func (s *fakeSource) UpcomingEvents(ctx context.Context, window zoom.Window) ([]zoom.Meeting, error) {
+	var meetings []zoom.Meeting
+	for _, meeting := range s.meetings {
+		if meeting.End.After(window.Start) && meeting.Start.Before(window.End) {
+			meetings = append(meetings, meeting)
+		}
+	}
+	return meetings, nil
+}
+
+func TestStatusUpdaterCheck(t *testing.T) {
+	var statuses []string
+	client := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		var body struct {
+			Profile struct {
+				Text  string `json:"status_text"`
+				Emoji string `json:"status_emoji"`
+			} `json:"profile"`
+		}
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		statuses = append(statuses, body.Profile.Emoji+" "+body.Profile.Text)
+		fmt.Fprint(w, `{"ok": true}`)
+	})
+
+	now := time.Now()
+	source := &fakeSource{meetings: []zoom.Meeting{
+		{ID: "standup", Title: "Standup", Start: now.Add(time.Minute), End: now.Add(16 * time.Minute)},
+	}}
+	u := &StatusUpdater{
+		Source: source,
+		Client: client,
+		Text:   func(meeting zoom.Meeting) string { return "In " + meeting.Title },
+	}
+
+	require.NoError(t, u.check(context.Background(), now))
+	assert.Empty(t, statuses, "the meeting hasn't started")
+
+	require.NoError(t, u.check(context.Background(), now.Add(2*time.Minute)))
+	require.NoError(t, u.check(context.Background(), now.Add(3*time.Minute)))
+	assert.Equal(t, []string{":movie_camera: In Standup"}, statuses, "the status is set once")
+
+	require.NoError(t, u.check(context.Background(), now.Add(20*time.Minute)))
+	require.NoError(t, u.check(context.Background(), now.Add(21*time.Minute)))
+	assert.Equal(t, []string{":movie_camera: In Standup", " "}, statuses, "the status is cleared once")
+}
+
+func TestStatusText(t *testing.T) {
+	defer func(location *time.Location) { zoom.Location = location }(zoom.Location)
+	zoom.Location = time.UTC
+
+	end := time.Date(2018, 10, 10, 15, 30, 0, 0, time.UTC)
+	assert.Equal(t, "In a meeting until 3:30 PM", StatusText(zoom.Meeting{End: end}))
+	assert.Equal(t, "In a meeting", StatusText(zoom.Meeting{}))
+}
@@ -0,0 +1,180 @@
+package zoom
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+	"google.golang.org/api/googleapi"
+)
+
+// Window is a span of time in which to look for meetings.
+type Window struct {
+	// Start is the beginning of the window.
+	Start time.Time
+
+	// End is the end of the window. The zero time means the window is unbounded.
+	End time.Time
+}
+
+// CalendarSource is a calendar backend from which upcoming meetings can be read.
+type CalendarSource interface {
+	// UpcomingEvents returns the meetings which end after the window starts and begin
+	// before it ends, sorted by start time.
+	UpcomingEvents(ctx context.Context, window Window) ([]Meeting, error)
+}
+
+// GoogleCalendarSource is a CalendarSource backed by Google Calendar.
+type GoogleCalendarSource struct {
+	service     *calendar.Service
+	opts        Options
+	incremental *incrementalSync
+
+	mu      sync.Mutex
+	series  map[string]*Series
+	lookups map[string]*seriesLookup
+}
+
+// seriesLookup is a series being looked up, which other callers wait for rather than
+// looking it up again.
+type seriesLookup struct {
+	done   chan struct{}
+	series *Series
+}
+
+// NewGoogleCalendarSource returns a CalendarSource which lists events using the service.
+// The options select which calendars to read and which events to skip.
+func NewGoogleCalendarSource(service *calendar.Service, opts Options) *GoogleCalendarSource {
+	return &GoogleCalendarSource{service: service, opts: opts}
+}
+
+// UpcomingEvents returns the meetings in the window.
+func (s *GoogleCalendarSource) UpcomingEvents(ctx context.Context, window Window) ([]Meeting, error) {
+	var events []*calendar.Event
+	var err error
+	if s.incremental != nil {
+		events, err = s.incremental.eventsInWindow(ctx, s.service, window, s.opts)
+	} else {
+		events, err = listEventsInWindow(ctx, s.service, window, s.opts, nil)
+	}
+	if err != nil {
+		return nil, err
+	}
+	sortEventsByStartTime(events)
+
+	// The calendars to look series up in are only listed once, when the first series
+	// which isn't known yet is found.
+	var ids []string
+	resolved := false
+	meetings := make([]Meeting, 0, len(events))
+	for _, event := range events {
+		meeting := MeetingFromEvent(event, s.opts.providers())
+		s.opts.resolvePersonalRoom(ctx, &meeting)
+		if s.opts.DescribeSeries && IsRecurring(event) {
+			series, ok := s.knownSeries(event.RecurringEventId)
+			if !ok && !resolved {
+				ids, _ = calendarIDs(ctx, s.service, s.opts)
+				resolved = true
+			}
+			if !ok && len(ids) > 0 {
+				series = s.describeSeries(ctx, ids, event)
+			}
+			meeting.Series = series
+		}
+		meetings = append(meetings, meeting)
+	}
+	return meetings, nil
+}
+
+// knownSeries returns the series with the ID if it has been looked up already. It may be
+// nil if the series doesn't exist.
+func (s *GoogleCalendarSource) knownSeries(id string) (*Series, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	series, ok := s.series[id]
+	return series, ok
+}
+
+// describeSeries returns the series the event is an instance of, looking it up in each of
+// the calendars the first time the series is seen. Series being looked up by another call
+// are waited for rather than looked up again. It returns nil if the series can't be found,
+// which is only remembered if none of the calendars have it, so that series which can't
+// be looked up for now are tried again later.
+func (s *GoogleCalendarSource) describeSeries(ctx context.Context, calendarIDs []string, event *calendar.Event) *Series {
+	id := event.RecurringEventId
+	s.mu.Lock()
+	if series, ok := s.series[id]; ok {
+		s.mu.Unlock()
+		return series
+	}
+	if lookup, ok := s.lookups[id]; ok {
+		s.mu.Unlock()
+		select {
+		case <-lookup.done:
+			return lookup.series
+		case <-ctx.Done():
+			return nil
+		}
+	}
+	lookup := &seriesLookup{done: make(chan struct{})}
+	if s.lookups == nil {
+		s.lookups = map[string]*seriesLookup{}
+	}
+	s.lookups[id] = lookup
+	s.mu.Unlock()
+
+	series, found := s.lookupSeries(ctx, calendarIDs, event)
+
+	s.mu.Lock()
+	delete(s.lookups, id)
+	if found {
+		if s.series == nil {
+			s.series = map[string]*Series{}
+		}
+		s.series[id] = series
+	}
+	s.mu.Unlock()
+	lookup.series = series
+	close(lookup.done)
+	return series
+}
+
+// lookupSeries looks up the series the event is an instance of in each of the calendars
+// until one has it. found is false unless it was found, or every calendar said it doesn't
+// exist.
+func (s *GoogleCalendarSource) lookupSeries(ctx context.Context, calendarIDs []string, event *calendar.Event) (series *Series, found bool) {
+	missing := 0
+	for _, calendarID := range calendarIDs {
+		series, err := EventSeriesContext(ctx, s.service, calendarID, event)
+		if err == nil {
+			return series, true
+		}
+		var apiErr *googleapi.Error
+		if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound {
+			missing++
+		}
+	}
+	return nil, missing == len(calendarIDs)
+}
+
+// NextMeetingFromSource returns the first meeting in the window with a join URL, or the
+// first meeting if none have one. It returns ErrNoUpcomingEvents if the window is empty.
+func NextMeetingFromSource(ctx context.Context, source CalendarSource, window Window) (*Meeting, error) {
+	meetings, err := source.UpcomingEvents(ctx, window)
+	if err != nil {
+		return nil, err
+	}
+	if len(meetings) == 0 {
+		return nil, ErrNoUpcomingEvents
+	}
+
+	for i := range meetings {
+		if meetings[i].JoinURL != nil {
+			return &meetings[i], nil
+		}
+	}
+	return &meetings[0], nil
+}
@@ -0,0 +1,160 @@
+// Package statusbar renders the next meeting in the formats expected by status bars such
+// as waybar, polybar, xbar (formerly BitBar), and tmux.
+package statusbar
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/benbalter/zoom-go"
+)
+
+// Classes describe how soon the meeting is, for styling status bar output.
+const (
+	// ClassNone means there is no upcoming meeting.
+	ClassNone = "none"
+
+	// ClassUpcoming means the meeting starts more than SoonThreshold from now.
+	ClassUpcoming = "upcoming"
+
+	// ClassSoon means the meeting starts within SoonThreshold.
+	ClassSoon = "soon"
+
+	// ClassInProgress means the meeting has started and not yet ended.
+	ClassInProgress = "in-progress"
+)
+
+// SoonThreshold is how close to its start a meeting must be to be in ClassSoon.
+var SoonThreshold = 5 * time.Minute
+
+// URLOptions choose which of a meeting's URLs the status bars link to. By default, its
+// deep link is preferred.
+var URLOptions = zoom.URLOptions{PreferDeepLink: true}
+
+// Class returns the class describing how soon the meeting is as of now.
+func Class(meeting *zoom.Meeting, now time.Time) string {
+	switch {
+	case meeting == nil || meeting.Start.IsZero():
+		return ClassNone
+	case !meeting.Start.After(now):
+		return ClassInProgress
+	case meeting.Start.Sub(now) <= SoonThreshold:
+		return ClassSoon
+	}
+	return ClassUpcoming
+}
+
+// Text returns a short line describing the meeting, such as "Standup in 5m" or
+// "Standup started 2m ago". It is empty if there is no meeting.
+func Text(meeting *zoom.Meeting, now time.Time) string {
+	if meeting == nil {
+		return ""
+	}
+	title := meeting.Title
+	if title == "" {
+		title = "Meeting"
+	}
+	if meeting.Start.IsZero() {
+		return title
+	}
+
+	if until := meeting.Start.Sub(now); until > 0 {
+		return title + " in " + countdown(until)
+	}
+	return title + " started " + countdown(-meeting.Start.Sub(now)) + " ago"
+}
+
+// Waybar returns the meeting as the JSON object waybar's custom modules read, with the
+// text, a tooltip including the join URL, and the meeting's class.
+func Waybar(meeting *zoom.Meeting, now time.Time) ([]byte, error) {
+	out := struct {
+		Text    string `json:"text"`
+		Tooltip string `json:"tooltip"`
+		Class   string `json:"class"`
+	}{
+		Text:  Text(meeting, now),
+		Class: Class(meeting, now),
+	}
+	if meeting != nil {
+		out.Tooltip = out.Text
+		if u := joinURL(meeting); u != "" {
+			out.Tooltip += "\n" + u
+		}
+	}
+
+	b, err := json.Marshal(out)
+	return b, err
+}
+
+// Polybar returns the meeting's text, which opens the join URL with opener (such as
+// "xdg-open") when clicked.
+func Polybar(meeting *zoom.Meeting, now time.Time, opener string) string {
+	text := Text(meeting, now)
+	u := joinURL(meeting)
+	if u == "" || opener == "" {
+		return text
+	}
+	// Colons must be escaped inside polybar's action tags.
+	command := strings.Replace(opener+" "+u, ":", `\:`, -1)
+	return "%{A1:" + command + ":}" + text + "%{A}"
+}
+
+// Xbar returns the meeting as an xbar or BitBar plugin's output: the text in the menu bar,
+// and a menu with items to join the meeting and open it in your calendar.
+func Xbar(meeting *zoom.Meeting, now time.Time) string {
+	if meeting == nil {
+		return "No meetings\n"
+	}
+
+	var output bytes.Buffer
+	fmt.Fprintln(&output, xbarEscape(Text(meeting, now)))
+	fmt.Fprintln(&output, "---")
+	if u := joinURL(meeting); u != "" {
+		fmt.Fprintf(&output, "Join %s | href=%s\n", xbarEscape(meeting.Title), u)
+	}
+	if meeting.CalendarURL != "" {
+		fmt.Fprintf(&output, "Open in Calendar | href=%s\n", meeting.CalendarURL)
+	}
+	return output.String()
+}
+
+// Tmux returns a segment for tmux's status line, such as "Standup in 5m". It is empty if
+// there is no meeting, so the segment disappears.
+func Tmux(meeting *zoom.Meeting, now time.Time) string {
+	// tmux interprets #[...] and #(...) in the status line, so escape any # in the title.
+	return strings.Replace(Text(meeting, now), "#", "##", -1)
+}
+
+// joinURL returns the URL to open the meeting, chosen by URLOptions, or "" if it has none.
+func joinURL(meeting *zoom.Meeting) string {
+	if meeting == nil {
+		return ""
+	}
+	if u := meeting.URL(URLOptions); u != nil {
+		return u.String()
+	}
+	return ""
+}
+
+// xbarEscape keeps a title from being read as xbar parameters.
+func xbarEscape(text string) string {
+	return strings.Replace(text, "|", "¦", -1)
+}
+
+// countdown formats a duration compactly, e.g. "45s", "5m", or "1h20m".
+func countdown(d time.Duration) string {
+	switch {
+	case d < time.Minute:
+		return fmt.Sprintf("%ds", int(d/time.Second))
+	case d < time.Hour:
+		return fmt.Sprintf("%dm", int(d/time.Minute))
+	}
+	hours := int(d / time.Hour)
+	if minutes := int(d%time.Hour) / int(time.Minute); minutes > 0 {
+		return fmt.Sprintf("%dh%dm", hours, minutes)
+	}
+	return fmt.Sprintf("%dh", hours)
+}
@@ -0,0 +1,87 @@
+package statusbar
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benbalter/zoom-go"
+)
+
+func testMeeting(now time.Time) *zoom.Meeting {
+	deepLink, _ := url.Parse("zoommtg://zoom.us/join?confno=12345")
+	return &zoom.Meeting{
+		Title:       "Standup",
+		Start:       now.Add(5 * time.Minute),
+		End:         now.Add(20 * time.Minute),
+		DeepLink:    deepLink,
+		CalendarURL: "https://www.google.com/calendar/event?eid=standup",
+	}
+}
+
+func TestText(t *testing.T) {
+	now := time.Now()
+	meeting := testMeeting(now)
+
+	assert.Equal(t, "Standup in 5m", Text(meeting, now))
+	assert.Equal(t, "Standup in 1h5m", Text(meeting, now.Add(-time.Hour)))
+	assert.Equal(t, "Standup started 2m ago", Text(meeting, now.Add(7*time.Minute)))
+	assert.Equal(t, "", Text(nil, now))
+}
+
+func TestClass(t *testing.T) {
+	now := time.Now()
+	meeting := testMeeting(now)
+
+	assert.Equal(t, ClassSoon, Class(meeting, now))
+	assert.Equal(t, ClassUpcoming, Class(meeting, now.Add(-time.Hour)))
+	assert.Equal(t, ClassInProgress, Class(meeting, now.Add(10*time.Minute)))
+	assert.Equal(t, ClassNone, Class(nil, now))
+}
+
+func TestWaybar(t *testing.T) {
+	now := time.Now()
+
+	b, err := Waybar(testMeeting(now), now)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text": "Standup in 5m", "tooltip": "Standup in 5m\nzoommtg://zoom.us/join?confno=12345", "class": "soon"}`, string(b))
+
+	b, err = Waybar(nil, now)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"text": "", "tooltip": "", "class": "none"}`, string(b))
+}
+
+func TestPolybar(t *testing.T) {
+	now := time.Now()
+	assert.Equal(t, `%{A1:xdg-open zoommtg\://zoom.us/join?confno=12345:}Standup in 5m%{A}`, Polybar(testMeeting(now), now, "xdg-open"))
+	assert.Equal(t, "Standup in 5m", Polybar(testMeeting(now), now, ""))
+}
+
+func TestXbar(t *testing.T) {
+	now := time.Now()
+	assert.Equal(t, "Standup in 5m\n"+
+		"---\n"+
+		"Join Standup | href=zoommtg://zoom.us/join?confno=12345\n"+
+		"Open in Calendar | href=https://www.google.com/calendar/event?eid=standup\n", Xbar(testMeeting(now), now))
+	assert.Equal(t, "No meetings\n", Xbar(nil, now))
+}
+
+func TestTmux(t *testing.T) {
+	now := time.Now()
+	meeting := testMeeting(now)
+	meeting.Title = "#general sync"
+	assert.Equal(t, "##general sync in 5m", Tmux(meeting, now))
+}
+
+func TestURLOptions(t *testing.T) {
+	defer func(opts zoom.URLOptions) { URLOptions = opts }(URLOptions)
+	URLOptions = zoom.URLOptions{}
+
+	now := time.Now()
+	meeting := testMeeting(now)
+	meeting.JoinURL, _ = url.Parse("https://jithub.zoom.us/j/12345")
+	assert.Contains(t, Xbar(meeting, now), "href=https://jithub.zoom.us/j/12345\n", "the web URL is linked when deep links aren't preferred")
+}
@@ -0,0 +1,123 @@
+package zoom
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+	"google.golang.org/api/googleapi"
+)
+
+// EventStore keeps the events from the last successful calendar sync on disk, so that
+// NextEvent can still find your next meeting when the Calendar API can't be reached.
+// Set Options.Store to use it.
+type EventStore struct {
+	// Path is the file in which the events are stored.
+	Path string
+
+	// OnStale is called when the next event is selected from the store because the
+	// calendar couldn't be reached, with the time of the last sync and the error which
+	// prevented a new one. If nil, the stored events are used silently.
+	OnStale func(syncedAt time.Time, err error)
+
+	mu sync.Mutex
+}
+
+// storedEvents is the content of an EventStore's file.
+type storedEvents struct {
+	SyncedAt time.Time         `json:"syncedAt"`
+	Events   []*calendar.Event `json:"events"`
+}
+
+// NewEventStore returns an EventStore which keeps events in the file at path.
+func NewEventStore(path string) *EventStore {
+	return &EventStore{Path: path}
+}
+
+// DefaultEventStorePath returns the file in your user cache directory, such as
+// $XDG_CACHE_HOME/zoom-go on Linux, in which synced events are stored.
+func DefaultEventStorePath() (string, error) {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "zoom-go", "events.json"), nil
+}
+
+// LastSync returns when the stored events were synced, and false if nothing is stored.
+func (s *EventStore) LastSync() (time.Time, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var stored storedEvents
+	if !readJSONFile(s.Path, &stored) {
+		return time.Time{}, false
+	}
+	return stored.SyncedAt, true
+}
+
+// save replaces the stored events with the events synced now.
+func (s *EventStore) save(events []*calendar.Event, now time.Time) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return writeJSONFile(s.Path, storedEvents{SyncedAt: now, Events: events})
+}
+
+// fallback returns the stored events which haven't ended by now, after syncing failed with
+// syncErr. If nothing is stored, it returns syncErr.
+func (s *EventStore) fallback(syncErr error, now time.Time) ([]*calendar.Event, error) {
+	s.mu.Lock()
+	var stored storedEvents
+	ok := readJSONFile(s.Path, &stored)
+	s.mu.Unlock()
+	if !ok {
+		return nil, syncErr
+	}
+
+	var events []*calendar.Event
+	for _, event := range stored.Events {
+		if !hasEnded(event, now) {
+			events = append(events, event)
+		}
+	}
+
+	if s.OnStale != nil {
+		s.OnStale(stored.SyncedAt, syncErr)
+	}
+	return events, nil
+}
+
+// hasEnded returns true if the event ended before now. Events with no end time end when they start.
+func hasEnded(event *calendar.Event, now time.Time) bool {
+	if end, err := MeetingEndTime(event); err == nil {
+		return end.Before(now)
+	}
+	start, err := MeetingStartTime(event)
+	return err == nil && start.Before(now)
+}
+
+// isUnreachable returns true if the error means the calendar couldn't be reached at all,
+// or is temporarily unavailable, rather than refusing the request. Other errors, such as
+// responses which can't be decoded, are mistakes which the store shouldn't hide.
+func isUnreachable(err error) bool {
+	if errors.Is(err, ErrCalendarUnavailable) {
+		return true
+	}
+	if isAuthExpired(err) {
+		return false
+	}
+	var apiErr *googleapi.Error
+	if errors.As(err, &apiErr) {
+		return isRetryable(apiErr)
+	}
+	var netErr net.Error
+	var urlErr *url.Error
+	return errors.As(err, &netErr) || errors.As(err, &urlErr) || errors.Is(err, context.DeadlineExceeded)
+}
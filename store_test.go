@@ -0,0 +1,101 @@
+package zoom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	calendar "google.golang.org/api/calendar/v3"
+	"google.golang.org/api/googleapi"
+)
+
+func TestNextEventWithOptions_Store(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	start := time.Now().Add(time.Hour).Format(time.RFC3339)
+	status := http.StatusOK
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			return
+		}
+		fmt.Fprintf(w, `{"items": [
+			{"summary": "Standup", "location": "https://jithub.zoom.us/j/22222", "start": {"dateTime": %q}}
+		]}`, start)
+	})
+
+	var staleSince time.Time
+	store := NewEventStore(filepath.Join(t.TempDir(), "events.json"))
+	store.OnStale = func(syncedAt time.Time, err error) {
+		staleSince = syncedAt
+		assert.Error(t, err)
+	}
+	opts := Options{Store: store, Retry: RetryPolicy{Attempts: 1}}
+
+	online, err := NextEventWithOptions(service, opts)
+	require.NoError(t, err)
+	require.NotNil(t, online)
+	syncedAt, ok := store.LastSync()
+	require.True(t, ok)
+	assert.True(t, staleSince.IsZero())
+
+	status = http.StatusServiceUnavailable
+	offline, err := NextEventWithOptions(service, opts)
+	require.NoError(t, err)
+	require.NotNil(t, offline)
+	assert.Equal(t, "Standup", offline.Summary)
+	assert.True(t, syncedAt.Equal(staleSince))
+
+	// Requests which the calendar refuses aren't answered from the store.
+	status = http.StatusUnauthorized
+	_, err = NextEventWithOptions(service, opts)
+	assert.Error(t, err)
+}
+
+func TestEventStoreFallback(t *testing.T) {
+	now := time.Date(2018, 10, 10, 12, 0, 0, 0, time.UTC)
+	syncErr := errors.New("no route to host")
+
+	store := NewEventStore(filepath.Join(t.TempDir(), "events.json"))
+	_, err := store.fallback(syncErr, now)
+	assert.Equal(t, syncErr, err, "with nothing stored, the sync error is returned")
+
+	require.NoError(t, store.save([]*calendar.Event{
+		{Summary: "Breakfast", Start: &calendar.EventDateTime{DateTime: "2018-10-10T08:00:00Z"}, End: &calendar.EventDateTime{DateTime: "2018-10-10T09:00:00Z"}},
+		{Summary: "Lunch", Start: &calendar.EventDateTime{DateTime: "2018-10-10T11:30:00Z"}, End: &calendar.EventDateTime{DateTime: "2018-10-10T12:30:00Z"}},
+		{Summary: "Dinner", Start: &calendar.EventDateTime{DateTime: "2018-10-10T18:00:00Z"}},
+	}, now.Add(-3*time.Hour)))
+
+	events, err := store.fallback(syncErr, now)
+	require.NoError(t, err)
+	var summaries []string
+	for _, event := range events {
+		summaries = append(summaries, event.Summary)
+	}
+	assert.Equal(t, []string{"Lunch", "Dinner"}, summaries)
+
+	syncedAt, ok := store.LastSync()
+	assert.True(t, ok)
+	assert.True(t, now.Add(-3*time.Hour).Equal(syncedAt))
+}
+
+func TestIsUnreachable(t *testing.T) {
+	assert.True(t, isUnreachable(&net.OpError{Op: "dial", Net: "tcp", Err: errors.New("no such host")}))
+	assert.True(t, isUnreachable(fmt.Errorf("listing events: %w", &url.Error{Op: "Get", URL: "https://www.googleapis.com/calendar/v3", Err: io.ErrUnexpectedEOF})))
+	assert.True(t, isUnreachable(fmt.Errorf("listing events: %w", context.DeadlineExceeded)))
+	assert.False(t, isUnreachable(errors.New("invalid character '<' looking for beginning of value")), "errors which aren't about the network aren't hidden")
+	assert.True(t, isUnreachable(fmt.Errorf("listing events: %w", &googleapi.Error{Code: http.StatusServiceUnavailable})))
+	assert.False(t, isUnreachable(fmt.Errorf("listing events: %w", &googleapi.Error{Code: http.StatusNotFound})))
+}
@@ -0,0 +1,113 @@
+package zoom
+
+import (
+	"bytes"
+	"text/template"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// SummaryData is the data available to a MeetingSummaryTemplate template.
+type SummaryData struct {
+	// Summary is the title of the meeting.
+	Summary string
+
+	// Organizer is the name of the meeting's organizer, or its creator if the organizer
+	// has no name. It is empty if neither has one.
+	Organizer string
+
+	// Start is when the meeting starts. It is the zero time if unknown.
+	Start time.Time
+
+	// StartsIn is when the meeting starts relative to now, e.g. "5 minutes from now" or
+	// "2 minutes ago". It is empty if the start time is unknown.
+	StartsIn string
+
+	// StartsAt is the time of day the meeting starts, e.g. "3:04 PM". It is empty if the
+	// start time is unknown.
+	StartsAt string
+
+	// URL is the meeting's Zoom URL, preferring the deep link, or "" if it has none.
+	URL string
+
+	// Attendees are the names of the people invited, or their emails if they have no name.
+	Attendees []string
+}
+
+// MeetingSummaryTemplate renders a summary of the meeting using the text/template tmpl,
+// which is executed with a SummaryData. For example:
+//
+//	{{.Summary}} {{.StartsIn}}{{if .URL}} ({{.URL}}){{end}}
+func MeetingSummaryTemplate(event *calendar.Event, tmpl string) (string, error) {
+	return executeSummaryTemplate(tmpl, NewSummaryData(event))
+}
+
+// MeetingTemplate is like MeetingSummaryTemplate, but summarizes a Meeting, such as one
+// listed by a CalendarSource. The meeting may be nil.
+func MeetingTemplate(meeting *Meeting, tmpl string) (string, error) {
+	return executeSummaryTemplate(tmpl, NewSummaryDataFromMeeting(meeting))
+}
+
+func executeSummaryTemplate(tmpl string, data SummaryData) (string, error) {
+	t, err := template.New("summary").Parse(tmpl)
+	if err != nil {
+		return "", err
+	}
+
+	var output bytes.Buffer
+	if err := t.Execute(&output, data); err != nil {
+		return "", err
+	}
+	return output.String(), nil
+}
+
+// NewSummaryData returns the data describing the event which is available to summary templates.
+func NewSummaryData(event *calendar.Event) SummaryData {
+	if event == nil {
+		return SummaryData{}
+	}
+
+	data := SummaryData{Summary: event.Summary}
+	if event.Organizer != nil && event.Organizer.DisplayName != "" {
+		data.Organizer = event.Organizer.DisplayName
+	} else if event.Creator != nil {
+		data.Organizer = event.Creator.DisplayName
+	}
+	if start, err := MeetingStartTime(event); err == nil {
+		data.Start = start
+		data.StartsIn = Formatter.RelativeTime(start, time.Now())
+		data.StartsAt = Formatter.AbsoluteTime(InLocation(start))
+	}
+	if u, ok := MeetingURLFromEvent(event); ok {
+		data.URL = u.String()
+	}
+	for _, attendee := range event.Attendees {
+		if attendee == nil {
+			continue
+		}
+		data.Attendees = append(data.Attendees, firstNonEmpty(attendee.DisplayName, attendee.Email))
+	}
+	return data
+}
+
+// NewSummaryDataFromMeeting is like NewSummaryData, but describes a Meeting.
+func NewSummaryDataFromMeeting(meeting *Meeting) SummaryData {
+	if meeting == nil {
+		return SummaryData{}
+	}
+
+	data := SummaryData{Summary: meeting.Title, Organizer: meeting.Organizer.Name}
+	if !meeting.Start.IsZero() {
+		data.Start = meeting.Start
+		data.StartsIn = Formatter.RelativeTime(meeting.Start, time.Now())
+		data.StartsAt = Formatter.AbsoluteTime(InLocation(meeting.Start))
+	}
+	if u := meeting.URL(URLOptions{PreferDeepLink: true}); u != nil {
+		data.URL = u.String()
+	}
+	for _, attendee := range meeting.Attendees {
+		data.Attendees = append(data.Attendees, firstNonEmpty(attendee.Name, attendee.Email))
+	}
+	return data
+}
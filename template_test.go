@@ -0,0 +1,67 @@
+package zoom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestMeetingSummaryTemplate(t *testing.T) {
+	event := &calendar.Event{
+		Summary:   "Standup",
+		Location:  "https://jithub.zoom.us/j/12345",
+		Organizer: &calendar.EventOrganizer{DisplayName: "Parker Moore"},
+		Start:     &calendar.EventDateTime{DateTime: time.Now().Add(10*time.Minute + 30*time.Second).Format(googleCalendarDateTimeFormat)},
+		Attendees: []*calendar.EventAttendee{
+			{DisplayName: "Parker Moore", Email: "parkr@jithub.com"},
+			{Email: "ben@jithub.com"},
+		},
+	}
+
+	summary, err := MeetingSummaryTemplate(event, `{{.Summary}} by {{.Organizer}} {{.StartsIn}}{{if .URL}} {{.URL}}{{end}} with {{len .Attendees}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "Standup by Parker Moore 10 minutes from now zoommtg://zoom.us/join?confno=12345 with 2", summary)
+
+	summary, err = MeetingSummaryTemplate(event, `{{range .Attendees}}{{.}};{{end}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "Parker Moore;ben@jithub.com;", summary)
+
+	summary, err = MeetingSummaryTemplate(nil, `{{.Summary}}{{if not .URL}}free{{end}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "free", summary)
+}
+
+func TestMeetingSummaryTemplate_Errors(t *testing.T) {
+	_, err := MeetingSummaryTemplate(&calendar.Event{}, `{{.Summary`)
+	assert.Error(t, err)
+
+	_, err = MeetingSummaryTemplate(&calendar.Event{}, `{{.Nope}}`)
+	assert.Error(t, err)
+}
+
+func TestMeetingTemplate(t *testing.T) {
+	meeting := MeetingFromEvent(&calendar.Event{
+		Summary:   "Standup",
+		Location:  "https://jithub.zoom.us/j/12345",
+		Organizer: &calendar.EventOrganizer{DisplayName: "Parker Moore"},
+		Start:     &calendar.EventDateTime{DateTime: time.Now().Add(10*time.Minute + 30*time.Second).Format(googleCalendarDateTimeFormat)},
+		Attendees: []*calendar.EventAttendee{
+			{DisplayName: "Parker Moore", Email: "parkr@jithub.com"},
+			{Email: "ben@jithub.com"},
+		},
+	}, nil)
+
+	summary, err := MeetingTemplate(&meeting, `{{.Summary}} by {{.Organizer}} {{.StartsIn}}{{if .URL}} {{.URL}}{{end}} with {{range .Attendees}}{{.}};{{end}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "Standup by Parker Moore 10 minutes from now zoommtg://zoom.us/join?confno=12345 with Parker Moore;ben@jithub.com;", summary)
+
+	summary, err = MeetingTemplate(nil, `{{.Summary}}{{if not .URL}}free{{end}}`)
+	require.NoError(t, err)
+	assert.Equal(t, "free", summary)
+
+	_, err = MeetingTemplate(&meeting, `{{.Nope}}`)
+	assert.Error(t, err)
+}
@@ -0,0 +1,145 @@
+package zoom
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	humanize "github.com/dustin/go-humanize"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// TimeFormatter formats meeting times for people to read, such as in a particular language.
+type TimeFormatter interface {
+	// RelativeTime describes then relative to now, e.g. "10 minutes from now".
+	RelativeTime(then, now time.Time) string
+
+	// AbsoluteTime formats the time of day, e.g. "3:04 PM".
+	AbsoluteTime(t time.Time) string
+}
+
+var (
+	// Formatter formats the times shown by HumanizedStartTime, FormattedStartTime, and
+	// summary templates. It defaults to English.
+	Formatter TimeFormatter = English
+
+	// Location is the time zone times are shown in. If nil, the local time zone is used.
+	Location *time.Location
+)
+
+// InLocation returns t in the time zone times are shown in, Location.
+func InLocation(t time.Time) time.Time {
+	if Location == nil {
+		return t.Local()
+	}
+	return t.In(Location)
+}
+
+// FormattedStartTime returns the time of day the event starts, formatted by Formatter in
+// the Location time zone.
+func FormattedStartTime(event *calendar.Event) string {
+	startTime, err := MeetingStartTime(event)
+	if err != nil {
+		return err.Error()
+	}
+	return Formatter.AbsoluteTime(InLocation(startTime))
+}
+
+// Locale is a TimeFormatter for a language.
+type Locale struct {
+	// Past and Future label relative times before and after now, e.g. "ago" and "from now".
+	Past, Future string
+
+	// Magnitudes format relative times of increasing size, as for humanize.CustomRelTime.
+	// If nil, go-humanize's English magnitudes are used.
+	Magnitudes []humanize.RelTimeMagnitude
+
+	// TimeLayout is the layout for times of day, as for time.Time.Format.
+	TimeLayout string
+}
+
+// RelativeTime describes then relative to now, e.g. "in 10 Minuten".
+func (l *Locale) RelativeTime(then, now time.Time) string {
+	if l.Magnitudes == nil {
+		return humanize.RelTime(then, now, l.Past, l.Future)
+	}
+	return humanize.CustomRelTime(then, now, l.Past, l.Future, l.Magnitudes)
+}
+
+// AbsoluteTime formats the time of day with the locale's TimeLayout.
+func (l *Locale) AbsoluteTime(t time.Time) string {
+	return t.Format(l.TimeLayout)
+}
+
+// The built-in locales.
+var (
+	English = &Locale{Past: "ago", Future: "from now", TimeLayout: "3:04 PM"}
+
+	German = &Locale{
+		Past: "vor", Future: "in", TimeLayout: "15:04",
+		Magnitudes: labelFirstMagnitudes("jetzt", "langer Zeit", [][2]string{
+			{"Sekunde", "Sekunden"}, {"Minute", "Minuten"}, {"Stunde", "Stunden"},
+			{"Tag", "Tagen"}, {"Woche", "Wochen"}, {"Monat", "Monaten"}, {"Jahr", "Jahren"},
+		}),
+	}
+
+	French = &Locale{
+		Past: "il y a", Future: "dans", TimeLayout: "15:04",
+		Magnitudes: labelFirstMagnitudes("maintenant", "longtemps", [][2]string{
+			{"seconde", "secondes"}, {"minute", "minutes"}, {"heure", "heures"},
+			{"jour", "jours"}, {"semaine", "semaines"}, {"mois", "mois"}, {"an", "ans"},
+		}),
+	}
+
+	Spanish = &Locale{
+		Past: "hace", Future: "dentro de", TimeLayout: "15:04",
+		Magnitudes: labelFirstMagnitudes("ahora", "mucho tiempo", [][2]string{
+			{"segundo", "segundos"}, {"minuto", "minutos"}, {"hora", "horas"},
+			{"día", "días"}, {"semana", "semanas"}, {"mes", "meses"}, {"año", "años"},
+		}),
+	}
+)
+
+// Locales are the built-in locales by language code.
+var Locales = map[string]*Locale{
+	"en": English,
+	"de": German,
+	"fr": French,
+	"es": Spanish,
+}
+
+// LocaleNamed returns the locale for a language code or POSIX locale name, such as "de",
+// "de-DE", or "de_DE.UTF-8".
+func LocaleNamed(name string) (*Locale, bool) {
+	name = strings.ToLower(name)
+	if i := strings.IndexAny(name, ".@"); i >= 0 {
+		name = name[:i]
+	}
+	name = strings.Replace(name, "-", "_", -1)
+
+	if locale, ok := Locales[name]; ok {
+		return locale, true
+	}
+	if i := strings.Index(name, "_"); i >= 0 {
+		locale, ok := Locales[name[:i]]
+		return locale, ok
+	}
+	return nil, false
+}
+
+// labelFirstMagnitudes returns magnitudes for languages which put the past or future
+// label before the quantity, e.g. "vor 10 Minuten". units are the singular and plural
+// names of seconds, minutes, hours, days, weeks, months, and years.
+func labelFirstMagnitudes(now, longTime string, units [][2]string) []humanize.RelTimeMagnitude {
+	sizes := []time.Duration{time.Second, time.Minute, time.Hour, humanize.Day, humanize.Week, humanize.Month, humanize.Year}
+	limits := []time.Duration{time.Minute, time.Hour, humanize.Day, humanize.Week, humanize.Month, humanize.Year, humanize.LongTime}
+
+	magnitudes := []humanize.RelTimeMagnitude{{D: time.Second, Format: now, DivBy: time.Second}}
+	for i, size := range sizes {
+		magnitudes = append(magnitudes,
+			humanize.RelTimeMagnitude{D: 2 * size, Format: "%s 1 " + units[i][0], DivBy: 1},
+			humanize.RelTimeMagnitude{D: limits[i], Format: "%s %d " + units[i][1], DivBy: size},
+		)
+	}
+	return append(magnitudes, humanize.RelTimeMagnitude{D: math.MaxInt64, Format: "%s " + longTime, DivBy: 1})
+}
@@ -0,0 +1,55 @@
+package zoom
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestLocale_RelativeTime(t *testing.T) {
+	now := time.Now()
+
+	assert.Equal(t, "10 minutes from now", English.RelativeTime(now.Add(10*time.Minute), now))
+	assert.Equal(t, "in 10 Minuten", German.RelativeTime(now.Add(10*time.Minute), now))
+	assert.Equal(t, "vor 1 Stunde", German.RelativeTime(now.Add(-time.Hour), now))
+	assert.Equal(t, "il y a 3 jours", French.RelativeTime(now.Add(-72*time.Hour), now))
+	assert.Equal(t, "dentro de 2 semanas", Spanish.RelativeTime(now.Add(15*24*time.Hour), now))
+	assert.Equal(t, "jetzt", German.RelativeTime(now, now))
+}
+
+func TestLocale_AbsoluteTime(t *testing.T) {
+	at := time.Date(2024, 3, 1, 15, 4, 0, 0, time.UTC)
+	assert.Equal(t, "3:04 PM", English.AbsoluteTime(at))
+	assert.Equal(t, "15:04", French.AbsoluteTime(at))
+}
+
+func TestLocaleNamed(t *testing.T) {
+	for _, name := range []string{"de", "DE", "de-DE", "de_AT.UTF-8", "de_DE@euro"} {
+		locale, ok := LocaleNamed(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, German, locale, name)
+	}
+
+	_, ok := LocaleNamed("tlh")
+	assert.False(t, ok)
+}
+
+func TestFormatterAndLocation(t *testing.T) {
+	defer func(formatter TimeFormatter, location *time.Location) {
+		Formatter, Location = formatter, location
+	}(Formatter, Location)
+
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	Formatter, Location = German, berlin
+
+	start := time.Now().Add(12*time.Minute + 30*time.Second)
+	event := &calendar.Event{Start: &calendar.EventDateTime{DateTime: start.Format(googleCalendarDateTimeFormat)}}
+
+	assert.Equal(t, "in 12 Minuten", HumanizedStartTime(event))
+	assert.Equal(t, start.In(berlin).Format("15:04"), FormattedStartTime(event))
+	assert.Equal(t, "event does not have a start datetime", FormattedStartTime(&calendar.Event{}))
+}
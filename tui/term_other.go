@@ -0,0 +1,39 @@
+//go:build !windows
+// +build !windows
+
+package tui
+
+import (
+	"io"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// enterRawMode makes the terminal send each key as it is pressed, without echoing it,
+// using stty. It returns a function which restores the terminal's previous settings, and
+// whether it succeeded. If the input isn't a terminal, it does nothing.
+func enterRawMode(input io.Reader) (restore func(), ok bool) {
+	f, ok := input.(*os.File)
+	if !ok {
+		return func() {}, false
+	}
+	saved, err := stty(f, "-g")
+	if err != nil {
+		return func() {}, false
+	}
+	if _, err := stty(f, "-icanon", "-echo", "min", "1"); err != nil {
+		return func() {}, false
+	}
+	return func() {
+		stty(f, strings.TrimSpace(saved))
+	}, true
+}
+
+// stty runs stty on the terminal.
+func stty(terminal *os.File, args ...string) (string, error) {
+	cmd := exec.Command("stty", args...)
+	cmd.Stdin = terminal
+	out, err := cmd.Output()
+	return string(out), err
+}
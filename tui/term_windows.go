@@ -0,0 +1,8 @@
+package tui
+
+import "io"
+
+// enterRawMode does nothing on Windows, where keys are sent once Enter is pressed.
+func enterRawMode(input io.Reader) (restore func(), ok bool) {
+	return func() {}, false
+}
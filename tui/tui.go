@@ -0,0 +1,428 @@
+// Package tui shows today's meetings full screen in a terminal, with a live countdown to
+// the next one and keys to join a meeting, copy its link or dial-in number, or snooze its
+// notification.
+//
+// It draws with ANSI escape sequences, which every terminal zoom-go runs in understands,
+// rather than depending on a terminal UI library.
+package tui
+
+import (
+	"bufio"
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/benbalter/zoom-go"
+	"github.com/benbalter/zoom-go/notifier"
+)
+
+const (
+	// DefaultRefresh is how often meetings are fetched again when UI.Refresh is unset.
+	DefaultRefresh = time.Minute
+
+	// DefaultSnooze is how long a meeting is snoozed when UI.SnoozeFor is unset.
+	DefaultSnooze = 10 * time.Minute
+
+	// tick is how often the screen is redrawn, to keep the countdown live.
+	tick = time.Second
+)
+
+// ANSI escape sequences.
+const (
+	enterAltScreen = "\x1b[?1049h\x1b[?25l"
+	exitAltScreen  = "\x1b[?25h\x1b[?1049l"
+	clearScreen    = "\x1b[H\x1b[2J"
+	bold           = "\x1b[1m"
+	dim            = "\x1b[2m"
+	reverse        = "\x1b[7m"
+	reset          = "\x1b[0m"
+)
+
+// Keys which the UI handles.
+const (
+	keyUp     = "up"
+	keyDown   = "down"
+	keyEnter  = "enter"
+	keyCopy   = "c"
+	keyDialIn = "d"
+	keySnooze = "s"
+	keyReload = "r"
+	keyQuit   = "q"
+)
+
+// UI is a full-screen view of today's meetings.
+type UI struct {
+	// Source is the calendar whose meetings are shown. It should list meetings which
+	// are in progress, however long ago they started.
+	Source zoom.CalendarSource
+
+	// Refresh is how often meetings are fetched again. Zero means DefaultRefresh.
+	Refresh time.Duration
+
+	// Open joins a meeting. Nil means zoom.Open.
+	Open func(zoom.Meeting) error
+
+	// Clipboard is where meetings' links and dial-in numbers are copied. Nil means the
+	// system clipboard, or, if no clipboard command is installed, such as over SSH, asking
+	// the terminal to with an OSC 52 escape sequence, which most terminals, and tmux,
+	// support.
+	Clipboard *zoom.Clipboard
+
+	// Mutes, if set, are where meetings are snoozed.
+	Mutes *notifier.Mutes
+
+	// SnoozeFor is how long a meeting is snoozed. Zero means DefaultSnooze.
+	SnoozeFor time.Duration
+
+	// Input and Output are the terminal. Nil means os.Stdin and os.Stdout.
+	Input  io.Reader
+	Output io.Writer
+
+	meetings []zoom.Meeting
+	selected int
+	message  string
+	err      error
+	day      zoom.Window
+}
+
+// Run shows the UI until q is pressed or the context is done.
+func (u *UI) Run(ctx context.Context) error {
+	input, output := u.input(), u.output()
+	restore, raw := enterRawMode(input)
+	defer restore()
+	fmt.Fprint(output, enterAltScreen)
+	defer fmt.Fprint(output, exitAltScreen)
+
+	ticker := time.NewTicker(tick)
+	defer ticker.Stop()
+	refresh := time.NewTicker(u.refresh())
+	defer refresh.Stop()
+
+	keys := readKeys(input, !raw)
+	u.reload(ctx, time.Now())
+	for {
+		u.render(output, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case key, ok := <-keys:
+			if !ok {
+				// The input has ended, so only the countdown can change now.
+				keys = nil
+				continue
+			}
+			if key == keyQuit {
+				return nil
+			}
+			u.handleKey(ctx, key, time.Now())
+		case <-refresh.C:
+			u.reload(ctx, time.Now())
+		case <-ticker.C:
+		}
+	}
+}
+
+// reload fetches today's meetings, keeping the same meeting selected if it is still
+// there, or selecting the next meeting the first time.
+func (u *UI) reload(ctx context.Context, now time.Time) {
+	window := zoom.TodayWindow(now)
+	meetings, err := u.Source.UpcomingEvents(ctx, window)
+	u.err = err
+	if err != nil {
+		return
+	}
+
+	var selectedID string
+	if u.selected < len(u.meetings) {
+		selectedID = u.meetings[u.selected].ID
+	}
+	firstLoad := u.meetings == nil || !window.Start.Equal(u.day.Start)
+	u.meetings, u.day = meetings, window
+	if u.meetings == nil {
+		u.meetings = []zoom.Meeting{}
+	}
+
+	u.selected = nextIndex(u.meetings, now)
+	if !firstLoad {
+		for i, meeting := range u.meetings {
+			if selectedID != "" && meeting.ID == selectedID {
+				u.selected = i
+			}
+		}
+	}
+}
+
+// handleKey acts on a key other than quitting.
+func (u *UI) handleKey(ctx context.Context, key string, now time.Time) {
+	u.message = ""
+	switch key {
+	case keyUp:
+		if u.selected > 0 {
+			u.selected--
+		}
+		return
+	case keyDown:
+		if u.selected < len(u.meetings)-1 {
+			u.selected++
+		}
+		return
+	case keyReload:
+		u.reload(ctx, now)
+		return
+	}
+
+	if u.selected >= len(u.meetings) {
+		return
+	}
+	meeting := u.meetings[u.selected]
+	switch key {
+	case keyEnter:
+		open := u.Open
+		if open == nil {
+			open = zoom.Open
+		}
+		if err := open(meeting); err != nil {
+			u.message = fmt.Sprintf("Unable to join %q: %v", meeting.Title, err)
+		} else {
+			u.message = fmt.Sprintf("Joining %q...", meeting.Title)
+		}
+	case keyCopy:
+		if err := u.clipboard().CopyURL(meeting); err != nil {
+			u.message = fmt.Sprintf("Unable to copy the link to %q: %v", meeting.Title, err)
+		} else {
+			u.message = fmt.Sprintf("Copied the link to %q.", meeting.Title)
+		}
+	case keyDialIn:
+		if err := u.clipboard().CopyDialIn(meeting); err != nil {
+			u.message = fmt.Sprintf("Unable to copy the dial-in number for %q: %v", meeting.Title, err)
+		} else {
+			u.message = fmt.Sprintf("Copied the dial-in number for %q.", meeting.Title)
+		}
+	case keySnooze:
+		if u.Mutes == nil {
+			u.message = "Snoozing isn't available."
+			return
+		}
+		snoozeFor := u.snoozeFor()
+		if err := u.Mutes.Snooze(meeting, now.Add(snoozeFor)); err != nil {
+			u.message = fmt.Sprintf("Unable to snooze %q: %v", meeting.Title, err)
+		} else {
+			u.message = fmt.Sprintf("Snoozed %q for %s.", meeting.Title, formatDuration(snoozeFor))
+		}
+	}
+}
+
+// copyWithTerminal asks the terminal to put the text on the clipboard.
+func (u *UI) copyWithTerminal(text string) error {
+	_, err := fmt.Fprintf(u.output(), "\x1b]52;c;%s\a", base64.StdEncoding.EncodeToString([]byte(text)))
+	return err
+}
+
+// render draws the screen as of now.
+func (u *UI) render(w io.Writer, now time.Time) {
+	var b strings.Builder
+	b.WriteString(clearScreen)
+	fmt.Fprintf(&b, "%sToday's meetings%s  %s\r\n", bold, reset, zoom.InLocation(now).Format("Mon Jan 2 15:04:05"))
+	b.WriteString(u.summary(now))
+	b.WriteString("\r\n\r\n")
+
+	if u.meetings != nil && len(u.meetings) == 0 {
+		b.WriteString("No meetings today.\r\n")
+	}
+	for i, meeting := range u.meetings {
+		b.WriteString(u.line(i, meeting, now))
+		b.WriteString("\r\n")
+	}
+
+	b.WriteString("\r\n")
+	if u.err != nil {
+		fmt.Fprintf(&b, "Unable to fetch your meetings: %v\r\n", u.err)
+	}
+	if u.message != "" {
+		fmt.Fprintf(&b, "%s\r\n", u.message)
+	}
+	fmt.Fprintf(&b, "%s↑/↓ select  enter join  c copy link  d copy dial-in  s snooze  r refresh  q quit%s", dim, reset)
+	io.WriteString(w, b.String())
+}
+
+// summary describes the meeting in progress, if there is one, and counts down to the next.
+func (u *UI) summary(now time.Time) string {
+	var parts []string
+	for _, meeting := range u.meetings {
+		if inProgress(meeting, now) && !meeting.End.IsZero() {
+			// Less than a minute left is rounded up, rather than saying it ends in 0m.
+			remaining := meeting.End.Sub(now)
+			if remaining < time.Minute {
+				remaining = time.Minute
+			}
+			parts = append(parts, fmt.Sprintf("Now: %s, ends in %s", meeting.Title, formatDuration(remaining)))
+			break
+		}
+	}
+	for _, meeting := range u.meetings {
+		if meeting.Start.After(now) {
+			parts = append(parts, fmt.Sprintf("Next: %s %s", meeting.Title, zoom.FormatCountdown(meeting.Start.Sub(now))))
+			return strings.Join(parts, "  ·  ")
+		}
+	}
+	if u.meetings != nil {
+		parts = append(parts, "No more meetings today")
+	}
+	return strings.Join(parts, "  ·  ")
+}
+
+// line describes the meeting at index i: when it starts, how long it lasts, its title,
+// and whether it has ended, is in progress, or is snoozed.
+func (u *UI) line(i int, meeting zoom.Meeting, now time.Time) string {
+	start, duration := "", formatDuration(meeting.Duration())
+	if meeting.AllDay {
+		start, duration = "all day", ""
+	} else if !meeting.Start.IsZero() {
+		start = zoom.Formatter.AbsoluteTime(zoom.InLocation(meeting.Start))
+	}
+	text := fmt.Sprintf("%-8s  %-5s  %s", start, duration, meeting.Title)
+	if meeting.Account != "" {
+		text += " [" + meeting.Account + "]"
+	}
+	switch {
+	case inProgress(meeting, now):
+		text += "  (in progress)"
+	case u.Mutes != nil && u.Mutes.Muted(meeting, now):
+		text += "  (snoozed)"
+	}
+	if meeting.URL(zoom.URLOptions{}) == nil {
+		text += "  (no link)"
+	}
+
+	switch {
+	case i == u.selected:
+		return reverse + "> " + text + reset
+	case ended(meeting, now):
+		return dim + "  " + text + reset
+	}
+	return "  " + text
+}
+
+func (u *UI) refresh() time.Duration {
+	if u.Refresh > 0 {
+		return u.Refresh
+	}
+	return DefaultRefresh
+}
+
+func (u *UI) snoozeFor() time.Duration {
+	if u.SnoozeFor > 0 {
+		return u.SnoozeFor
+	}
+	return DefaultSnooze
+}
+
+func (u *UI) clipboard() *zoom.Clipboard {
+	if u.Clipboard != nil {
+		return u.Clipboard
+	}
+	return &zoom.Clipboard{Fallback: u.copyWithTerminal}
+}
+
+func (u *UI) input() io.Reader {
+	if u.Input != nil {
+		return u.Input
+	}
+	return os.Stdin
+}
+
+func (u *UI) output() io.Writer {
+	if u.Output != nil {
+		return u.Output
+	}
+	return os.Stdout
+}
+
+// nextIndex returns the index of the first meeting which hasn't ended, or the number of
+// meetings if they all have.
+func nextIndex(meetings []zoom.Meeting, now time.Time) int {
+	for i, meeting := range meetings {
+		if !ended(meeting, now) {
+			return i
+		}
+	}
+	return len(meetings)
+}
+
+func inProgress(meeting zoom.Meeting, now time.Time) bool {
+	return !meeting.Start.IsZero() && !meeting.Start.After(now) && !ended(meeting, now)
+}
+
+func ended(meeting zoom.Meeting, now time.Time) bool {
+	return !meeting.End.IsZero() && !meeting.End.After(now)
+}
+
+// formatDuration formats a duration compactly, e.g. "45m", "1h", or "1h30m", like zoom
+// agenda does. It is empty for zero.
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Minute)
+	hours, minutes := int(d/time.Hour), int(d%time.Hour/time.Minute)
+	switch {
+	case hours == 0 && minutes == 0:
+		return ""
+	case hours == 0:
+		return fmt.Sprintf("%dm", minutes)
+	case minutes == 0:
+		return fmt.Sprintf("%dh", hours)
+	}
+	return fmt.Sprintf("%dh%dm", hours, minutes)
+}
+
+// readKeys sends the keys read from the input until it ends, then closes the channel. If
+// the input is line buffered, an Enter which ends a line of other keys only sends them.
+func readKeys(input io.Reader, lineBuffered bool) <-chan string {
+	keys := make(chan string)
+	go func() {
+		defer close(keys)
+		r := bufio.NewReader(input)
+		var previous byte
+		lineHasKeys := false
+		for {
+			c, err := r.ReadByte()
+			if err != nil {
+				return
+			}
+			if c == '\r' || c == '\n' {
+				if !(c == '\n' && previous == '\r') && !(lineBuffered && lineHasKeys) {
+					keys <- keyEnter
+				}
+				previous, lineHasKeys = c, false
+				continue
+			}
+			previous, lineHasKeys = c, true
+
+			switch c {
+			case 3, 4: // Ctrl-C and Ctrl-D
+				keys <- keyQuit
+			case 'k':
+				keys <- keyUp
+			case 'j':
+				keys <- keyDown
+			case 0x1b:
+				// Arrow keys are sent as ESC [ A and ESC [ B.
+				if b, err := r.ReadByte(); err != nil || b != '[' {
+					continue
+				}
+				switch b, _ := r.ReadByte(); b {
+				case 'A':
+					keys <- keyUp
+				case 'B':
+					keys <- keyDown
+				}
+			default:
+				keys <- strings.ToLower(string(c))
+			}
+		}
+	}()
+	return keys
+}
@@ -0,0 +1,168 @@
+package tui
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"errors"
+	"net/url"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benbalter/zoom-go"
+	"github.com/benbalter/zoom-go/notifier"
+	"github.com/benbalter/zoom-go/zoomtest"
+)
+
+func testMeetings(t *testing.T, now time.Time) []zoom.Meeting {
+	joinURL, err := url.Parse("https://jithub.zoom.us/j/12345")
+	require.NoError(t, err)
+	return []zoom.Meeting{
+		{ID: "breakfast", Title: "Breakfast", Start: now.Add(-2 * time.Hour), End: now.Add(-time.Hour)},
+		{ID: "standup", Title: "Standup", Start: now.Add(-15 * time.Minute), End: now.Add(15 * time.Minute), JoinURL: joinURL},
+		{ID: "review", Title: "Design review", Start: now.Add(30 * time.Minute), End: now.Add(90 * time.Minute), JoinURL: joinURL, Account: "work"},
+	}
+}
+
+func TestUIRender(t *testing.T) {
+	now := time.Now()
+	u := &UI{Source: zoomtest.NewSource(testMeetings(t, now)...)}
+	u.reload(context.Background(), now)
+
+	var out bytes.Buffer
+	u.render(&out, now)
+	screen := out.String()
+	assert.Contains(t, screen, "Now: Standup, ends in 15m  ·  Next: Design review starts in 30m0s")
+	assert.Contains(t, screen, dim+"  "+zoom.Formatter.AbsoluteTime(zoom.InLocation(now.Add(-2*time.Hour))))
+	assert.Contains(t, screen, "1h     Breakfast  (no link)")
+	assert.Contains(t, screen, reverse+"> ", "the meeting in progress is selected")
+	assert.Contains(t, screen, "30m    Standup  (in progress)")
+	assert.Contains(t, screen, "1h     Design review [work]\r\n")
+
+	out.Reset()
+	later := now.Add(2 * time.Hour)
+	u.render(&out, later)
+	assert.Contains(t, out.String(), "No more meetings today")
+
+	out.Reset()
+	u = &UI{Source: zoomtest.NewSource()}
+	u.reload(context.Background(), now)
+	u.render(&out, now)
+	assert.Contains(t, out.String(), "No meetings today.")
+}
+
+func TestUIRender_Error(t *testing.T) {
+	source := zoomtest.NewSource()
+	source.Fail(zoom.ErrCalendarUnavailable)
+	u := &UI{Source: source}
+	u.reload(context.Background(), time.Now())
+
+	var out bytes.Buffer
+	u.render(&out, time.Now())
+	assert.Contains(t, out.String(), "Unable to fetch your meetings: "+zoom.ErrCalendarUnavailable.Error())
+}
+
+func TestUIHandleKey(t *testing.T) {
+	now := time.Now()
+	var opened []string
+	var out bytes.Buffer
+	u := &UI{
+		Source: zoomtest.NewSource(testMeetings(t, now)...),
+		Open: func(meeting zoom.Meeting) error {
+			opened = append(opened, meeting.Title)
+			return nil
+		},
+		Mutes:  &notifier.Mutes{},
+		Output: &out,
+	}
+	// No clipboard command is installed, so the terminal is asked to copy.
+	u.Clipboard = &zoom.Clipboard{
+		Run: func(input, name string, args ...string) error {
+			return &exec.Error{Name: name, Err: exec.ErrNotFound}
+		},
+		Fallback: u.copyWithTerminal,
+	}
+	ctx := context.Background()
+	u.reload(ctx, now)
+	assert.Equal(t, 1, u.selected)
+
+	u.handleKey(ctx, keyEnter, now)
+	assert.Equal(t, []string{"Standup"}, opened)
+	assert.Equal(t, `Joining "Standup"...`, u.message)
+
+	u.handleKey(ctx, keyDown, now)
+	u.handleKey(ctx, keyDown, now)
+	assert.Equal(t, 2, u.selected, "the selection stops at the last meeting")
+
+	u.handleKey(ctx, keySnooze, now)
+	assert.Equal(t, `Snoozed "Design review" for 10m.`, u.message)
+	assert.True(t, u.Mutes.Muted(u.meetings[2], now))
+
+	u.handleKey(ctx, keyCopy, now)
+	assert.Equal(t, "\x1b]52;c;"+base64.StdEncoding.EncodeToString([]byte("https://jithub.zoom.us/j/12345"))+"\a", out.String())
+
+	u.handleKey(ctx, keyDialIn, now)
+	assert.Equal(t, `Unable to copy the dial-in number for "Design review": meeting does not have a dial-in number`, u.message)
+
+	// The selection follows the meeting when meetings are fetched again.
+	u.Source.(*zoomtest.Source).Set(append(testMeetings(t, now)[1:], zoom.Meeting{ID: "lunch", Title: "Lunch", Start: now.Add(2 * time.Hour)})...)
+	u.handleKey(ctx, keyReload, now)
+	assert.Equal(t, "review", u.meetings[u.selected].ID)
+
+	u.handleKey(ctx, keyUp, now)
+	u.handleKey(ctx, keyUp, now)
+	u.handleKey(ctx, keyUp, now)
+	assert.Equal(t, 0, u.selected, "the selection stops at the first meeting")
+
+	u.Open = func(zoom.Meeting) error { return errors.New("no browser") }
+	u.handleKey(ctx, keyEnter, now)
+	assert.Equal(t, `Unable to join "Standup": no browser`, u.message)
+
+	u.Mutes = nil
+	u.handleKey(ctx, keySnooze, now)
+	assert.Equal(t, "Snoozing isn't available.", u.message)
+}
+
+func TestUIRun(t *testing.T) {
+	now := time.Now()
+	var opened []string
+	var out bytes.Buffer
+	u := &UI{
+		Source: zoomtest.NewSource(testMeetings(t, now)...),
+		Open: func(meeting zoom.Meeting) error {
+			opened = append(opened, meeting.Title)
+			return nil
+		},
+		// The input isn't a terminal, so it is read a line at a time.
+		Input:  strings.NewReader("j\n\nq\n"),
+		Output: &out,
+	}
+	require.NoError(t, u.Run(context.Background()))
+	assert.Equal(t, []string{"Design review"}, opened)
+	assert.True(t, strings.HasPrefix(out.String(), enterAltScreen))
+	assert.True(t, strings.HasSuffix(out.String(), exitAltScreen))
+
+	// It also stops when the context is done, even once the input has ended.
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	u.Input = strings.NewReader("")
+	require.NoError(t, u.Run(ctx))
+}
+
+func TestReadKeys(t *testing.T) {
+	read := func(input string, lineBuffered bool) []string {
+		var keys []string
+		for key := range readKeys(strings.NewReader(input), lineBuffered) {
+			keys = append(keys, key)
+		}
+		return keys
+	}
+
+	assert.Equal(t, []string{keyUp, keyDown, keyEnter, keyCopy, keyUp, keyDown, keyQuit}, read("\x1b[A\x1b[B\rCkj\x03", false))
+	assert.Equal(t, []string{keyDown, keyEnter, keyQuit}, read("j\r\n\r\nq\r\n", true))
+}
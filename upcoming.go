@@ -0,0 +1,63 @@
+package zoom
+
+import (
+	"context"
+	"net/url"
+	"sort"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// UpcomingEvent is a calendar event along with the meeting URL found in it, if any.
+type UpcomingEvent struct {
+	// Event is the underlying calendar event.
+	Event *calendar.Event
+
+	// HasMeetingURL is true if one of the configured providers matched the event.
+	HasMeetingURL bool
+
+	// MeetingURL is the URL used to join the meeting, if one was found.
+	MeetingURL *url.URL
+
+	// Provider is the provider which matched the event, if any.
+	Provider Provider
+}
+
+// NextEvents returns the upcoming events in the calendars selected by the options, sorted by start time.
+// Use opts.MaxResults and opts.Horizon to control how far ahead to look.
+func NextEvents(service *calendar.Service, opts Options) ([]*UpcomingEvent, error) {
+	return NextEventsContext(context.Background(), service, opts)
+}
+
+// NextEventsContext is like NextEvents, but the calendar API call is bound to the context.
+func NextEventsContext(ctx context.Context, service *calendar.Service, opts Options) ([]*UpcomingEvent, error) {
+	events, err := listEvents(ctx, service, opts, nil)
+	if err != nil {
+		return nil, err
+	}
+	sortEventsByStartTime(events)
+
+	upcoming := make([]*UpcomingEvent, 0, len(events))
+	for _, event := range events {
+		meetingURL, provider, ok := ConferenceURLFromEvent(event, opts.providers())
+		upcoming = append(upcoming, &UpcomingEvent{
+			Event:         event,
+			HasMeetingURL: ok,
+			MeetingURL:    meetingURL,
+			Provider:      provider,
+		})
+	}
+	return upcoming, nil
+}
+
+// sortEventsByStartTime sorts the events by start time. Events without a start time sort last.
+func sortEventsByStartTime(events []*calendar.Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		iStart, iErr := MeetingStartTime(events[i])
+		jStart, jErr := MeetingStartTime(events[j])
+		if iErr != nil || jErr != nil {
+			return iErr == nil && jErr != nil
+		}
+		return iStart.Before(jStart)
+	})
+}
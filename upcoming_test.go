@@ -0,0 +1,60 @@
+package zoom
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextEvents(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "25", query.Get("maxResults"))
+		timeMin, err := time.Parse(time.RFC3339, query.Get("timeMin"))
+		require.NoError(t, err)
+		timeMax, err := time.Parse(time.RFC3339, query.Get("timeMax"))
+		require.NoError(t, err)
+		assert.Equal(t, 24*time.Hour, timeMax.Sub(timeMin))
+		fmt.Fprint(w, testEventResponse)
+	})
+
+	events, err := NextEvents(service, Options{MaxResults: 25, Horizon: 24 * time.Hour})
+	require.NoError(t, err)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, "I am an in-person meeting", events[0].Event.Summary)
+	assert.False(t, events[0].HasMeetingURL)
+	assert.Nil(t, events[0].MeetingURL)
+	assert.Nil(t, events[0].Provider)
+
+	assert.Equal(t, "I am a video call", events[1].Event.Summary)
+	assert.True(t, events[1].HasMeetingURL)
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=12345", events[1].MeetingURL.String())
+	assert.Equal(t, ZoomProvider, events[1].Provider)
+}
+
+func TestNextEvents_NoHorizon(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "10", r.URL.Query().Get("maxResults"))
+		assert.Equal(t, "", r.URL.Query().Get("timeMax"))
+		fmt.Fprint(w, `{"items":[]}`)
+	})
+
+	events, err := NextEvents(service, Options{})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
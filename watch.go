@@ -0,0 +1,88 @@
+package zoom
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// defaultWatchInterval is how often the calendar is polled when WatchOptions.Interval is unset.
+const defaultWatchInterval = time.Minute
+
+// WatchOptions controls how Watch polls for changes.
+type WatchOptions struct {
+	// Options selects the next meeting.
+	Options
+
+	// Interval is how often to check for changes. Zero means once a minute.
+	Interval time.Duration
+
+	// OnError is called when the calendar cannot be read. Watch keeps polling afterwards.
+	// If nil, errors are ignored.
+	OnError func(error)
+}
+
+// Watch sends the next meeting in your primary calendar on the channel, and again every
+// time it changes, until the context is done. An empty Meeting is sent when there is no
+// upcoming meeting. It always returns the context's error.
+func Watch(ctx context.Context, service *calendar.Service, ch chan<- Meeting) error {
+	return WatchWithOptions(ctx, service, WatchOptions{}, ch)
+}
+
+// WatchWithOptions is like Watch, but selects the next meeting and polls according to the options.
+func WatchWithOptions(ctx context.Context, service *calendar.Service, opts WatchOptions, ch chan<- Meeting) error {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = defaultWatchInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	var last *Meeting
+	for {
+		meeting, _, err := NextMeetingContext(ctx, service, opts.Options)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			opts.logger().Debug("error checking for the next meeting", "error", err)
+			if opts.OnError != nil {
+				opts.OnError(err)
+			}
+		} else if last == nil || !sameMeeting(*last, meeting) {
+			opts.logger().Debug("next meeting changed", "id", meeting.ID, "title", meeting.Title, "start", meeting.Start)
+			select {
+			case ch <- meeting:
+				last = &meeting
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// sameMeeting returns true if the meetings have the same identity, title, time, and URLs.
+func sameMeeting(a, b Meeting) bool {
+	return a.ID == b.ID &&
+		a.Title == b.Title &&
+		a.Start.Equal(b.Start) &&
+		a.End.Equal(b.End) &&
+		urlString(a.JoinURL) == urlString(b.JoinURL) &&
+		urlString(a.DeepLink) == urlString(b.DeepLink)
+}
+
+func urlString(u *url.URL) string {
+	if u == nil {
+		return ""
+	}
+	return u.String()
+}
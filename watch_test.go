@@ -0,0 +1,66 @@
+package zoom
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWatchWithOptions(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	var mu sync.Mutex
+	responses := []string{
+		testEventResponse,
+		testEventResponse,
+		`{"items": [{"id": "new", "summary": "Rescheduled", "location": "https://jithub.zoom.us/j/67890"}]}`,
+		`{"items": []}`,
+	}
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		if len(responses) == 0 {
+			http.Error(w, "rate limited", http.StatusTooManyRequests)
+			return
+		}
+		fmt.Fprint(w, responses[0])
+		responses = responses[1:]
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	errs := make(chan error, 10)
+	ch := make(chan Meeting)
+	done := make(chan error)
+	go func() {
+		done <- WatchWithOptions(ctx, service, WatchOptions{
+			Options:  Options{Retry: RetryPolicy{Attempts: 1}},
+			Interval: time.Millisecond,
+			OnError: func(err error) {
+				select {
+				case errs <- err:
+				default:
+				}
+			},
+		}, ch)
+	}()
+
+	assert.Equal(t, "I am a video call", (<-ch).Title)
+	assert.Equal(t, "Rescheduled", (<-ch).Title)
+	assert.Equal(t, Meeting{}, <-ch)
+
+	require.Error(t, <-errs)
+
+	cancel()
+	assert.Equal(t, context.Canceled, <-done)
+}
@@ -0,0 +1,309 @@
+// Package webhook POSTs to webhooks as meetings approach, start, and end, e.g. to flash
+// a light when a meeting is about to start.
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"text/template"
+	"time"
+
+	"golang.org/x/net/context/ctxhttp"
+
+	"github.com/benbalter/zoom-go"
+)
+
+// The events in a meeting's lifecycle which hooks are called for.
+const (
+	// EventStarting is sent the Emitter's Lead before a meeting starts.
+	EventStarting = "meeting.starting"
+
+	// EventStarted is sent when a meeting starts.
+	EventStarted = "meeting.started"
+
+	// EventEnded is sent when a meeting ends.
+	EventEnded = "meeting.ended"
+)
+
+const (
+	// DefaultLead is how long before a meeting starts EventStarting is sent when Emitter.Lead is unset.
+	DefaultLead = 5 * time.Minute
+
+	// DefaultInterval is how often the calendar is checked when Emitter.Interval is unset.
+	DefaultInterval = 30 * time.Second
+
+	// DefaultAttempts is how many times each hook is tried when Emitter.Attempts is unset.
+	DefaultAttempts = 3
+
+	// DefaultBackoff is how long to wait before the first retry when Emitter.Backoff is
+	// unset. It doubles after each attempt.
+	DefaultBackoff = time.Second
+)
+
+// sleep waits between attempts. It is a variable so tests don't have to wait.
+var sleep = func(ctx context.Context, d time.Duration) error {
+	select {
+	case <-time.After(d):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Hook is a URL to POST to when meetings approach, start, or end.
+type Hook struct {
+	// URL is where to POST.
+	URL string
+
+	// Events are the events to POST for, e.g. EventStarting. Empty means every event.
+	Events []string
+
+	// Body is a text/template for the request body, executed with a Payload, e.g.
+	// `{"text": "{{.Meeting.Title}} {{.Event}}"}`. Empty means the Payload as JSON.
+	Body string
+
+	// ContentType is the request's Content-Type. Empty means "application/json".
+	ContentType string
+}
+
+// wants returns true if the hook should be called for the event.
+func (h Hook) wants(event string) bool {
+	if len(h.Events) == 0 {
+		return true
+	}
+	for _, e := range h.Events {
+		if e == event {
+			return true
+		}
+	}
+	return false
+}
+
+// Payload is the JSON body POSTed to hooks, and the data their Body templates are executed with.
+type Payload struct {
+	// Event is the event which happened, e.g. EventStarting.
+	Event string `json:"event"`
+
+	// Time is when the event was sent, in RFC 3339 format.
+	Time string `json:"time"`
+
+	// Meeting is the meeting the event is for.
+	Meeting zoom.MeetingJSON `json:"meeting"`
+}
+
+// Emitter calls hooks as meetings approach, start, and end.
+type Emitter struct {
+	// Source is the calendar to watch.
+	Source zoom.CalendarSource
+
+	// Hooks are called for each event.
+	Hooks []Hook
+
+	// Lead is how long before each meeting starts to send EventStarting. Zero means DefaultLead.
+	Lead time.Duration
+
+	// Interval is how often to check the calendar. Zero means DefaultInterval.
+	Interval time.Duration
+
+	// Attempts is how many times to try each hook before giving up. Zero means DefaultAttempts.
+	Attempts int
+
+	// Backoff is how long to wait before retrying a hook, doubling after each attempt.
+	// Zero means DefaultBackoff.
+	Backoff time.Duration
+
+	// HTTPClient makes the requests. Nil means http.DefaultClient.
+	HTTPClient *http.Client
+
+	// OnError is called when the calendar cannot be read or a hook fails. The emitter
+	// keeps running afterwards. If nil, errors are ignored.
+	OnError func(error)
+
+	// sent records the events sent for each meeting, by key.
+	sent map[string]*meetingState
+}
+
+// meetingState is what the emitter remembers about a meeting.
+type meetingState struct {
+	meeting zoom.Meeting
+	events  map[string]bool
+}
+
+// Run checks the calendar until the context is done, calling hooks for each event once.
+// It always returns the context's error.
+func (e *Emitter) Run(ctx context.Context) error {
+	interval := e.Interval
+	if interval <= 0 {
+		interval = DefaultInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if err := e.check(ctx, time.Now()); err != nil && ctx.Err() == nil && e.OnError != nil {
+			e.OnError(err)
+		}
+
+		select {
+		case <-ticker.C:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// check sends the events which are due for meetings in the calendar, and EventEnded for
+// meetings which were started and have since ended.
+func (e *Emitter) check(ctx context.Context, now time.Time) error {
+	lead := e.Lead
+	if lead <= 0 {
+		lead = DefaultLead
+	}
+	if e.sent == nil {
+		e.sent = map[string]*meetingState{}
+	}
+
+	meetings, err := e.Source.UpcomingEvents(ctx, zoom.Window{Start: now, End: now.Add(lead)})
+	if err != nil {
+		return err
+	}
+
+	var firstErr error
+	send := func(state *meetingState, event string) {
+		if state.events[event] {
+			return
+		}
+		state.events[event] = true
+		if err := e.emit(ctx, event, state.meeting, now); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+
+	for _, meeting := range meetings {
+		if meeting.Start.IsZero() {
+			continue
+		}
+		key := meeting.ID + " " + meeting.Start.String()
+		state, ok := e.sent[key]
+		if !ok {
+			state = &meetingState{events: map[string]bool{}}
+			e.sent[key] = state
+		}
+		state.meeting = meeting
+
+		switch {
+		case meeting.Start.After(now):
+			send(state, EventStarting)
+		case meeting.End.IsZero() || now.Before(meeting.End):
+			send(state, EventStarted)
+		}
+	}
+
+	for key, state := range e.sent {
+		end := state.meeting.End
+		switch {
+		case end.IsZero():
+			// Without an end, the meeting is forgotten a day after it starts.
+			if state.meeting.Start.Before(now.Add(-24 * time.Hour)) {
+				delete(e.sent, key)
+			}
+		case !now.Before(end):
+			if state.events[EventStarted] {
+				send(state, EventEnded)
+			}
+			delete(e.sent, key)
+		}
+	}
+
+	return firstErr
+}
+
+// emit calls every hook which wants the event.
+func (e *Emitter) emit(ctx context.Context, event string, meeting zoom.Meeting, now time.Time) error {
+	payload := Payload{
+		Event:   event,
+		Time:    now.Format(time.RFC3339),
+		Meeting: zoom.NewMeetingJSON(meeting, now),
+	}
+
+	var firstErr error
+	for _, hook := range e.Hooks {
+		if !hook.wants(event) {
+			continue
+		}
+		if err := e.call(ctx, hook, payload); err != nil && firstErr == nil {
+			firstErr = fmt.Errorf("calling webhook %s for %s: %w", hook.URL, event, err)
+		}
+	}
+	return firstErr
+}
+
+// call POSTs the payload to the hook, retrying if the request fails or the server
+// responds with a 429 or 5xx status.
+func (e *Emitter) call(ctx context.Context, hook Hook, payload Payload) error {
+	body, err := renderBody(hook, payload)
+	if err != nil {
+		return err
+	}
+	contentType := hook.ContentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	attempts := e.Attempts
+	if attempts <= 0 {
+		attempts = DefaultAttempts
+	}
+	backoff := e.Backoff
+	if backoff <= 0 {
+		backoff = DefaultBackoff
+	}
+
+	for attempt := 1; ; attempt++ {
+		retryable, err := post(ctx, e.HTTPClient, hook.URL, contentType, body)
+		if err == nil || !retryable || attempt >= attempts {
+			return err
+		}
+		if err := sleep(ctx, backoff); err != nil {
+			return err
+		}
+		backoff *= 2
+	}
+}
+
+// post makes a single request. If it fails, it returns whether it is worth retrying.
+func post(ctx context.Context, client *http.Client, url, contentType string, body []byte) (bool, error) {
+	resp, err := ctxhttp.Post(ctx, client, url, contentType, bytes.NewReader(body))
+	if err != nil {
+		return ctx.Err() == nil, err
+	}
+	resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return false, nil
+	}
+	retryable := resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode >= 500
+	return retryable, fmt.Errorf("unexpected response: %s", resp.Status)
+}
+
+// renderBody returns the request body for the hook: its Body template executed with the
+// payload, or the payload as JSON.
+func renderBody(hook Hook, payload Payload) ([]byte, error) {
+	if hook.Body == "" {
+		body, err := json.Marshal(payload)
+		return body, err
+	}
+
+	tmpl, err := template.New("body").Parse(hook.Body)
+	if err != nil {
+		return nil, fmt.Errorf("parsing webhook body template: %w", err)
+	}
+	var body bytes.Buffer
+	if err := tmpl.Execute(&body, payload); err != nil {
+		return nil, fmt.Errorf("executing webhook body template: %w", err)
+	}
+	return body.Bytes(), nil
+}
@@ -0,0 +1,122 @@
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benbalter/zoom-go"
+)
+
+type fakeSource struct {
+	meetings []zoom.Meeting
+}
+
+func (s *fakeSource) UpcomingEvents(ctx context.Context, window zoom.Window) ([]zoom.Meeting, error) {
+	var meetings []zoom.Meeting
+	for _, meeting := range s.meetings {
+		if meeting.End.After(window.Start) && meeting.Start.Before(window.End) {
+			meetings = append(meetings, meeting)
+		}
+	}
+	return meetings, nil
+}
+
+func stubSleep(t *testing.T) *[]time.Duration {
+	var slept []time.Duration
+	original := sleep
+	sleep = func(ctx context.Context, d time.Duration) error {
+		slept = append(slept, d)
+		return nil
+	}
+	t.Cleanup(func() { sleep = original })
+	return &slept
+}
+
+func TestEmitterCheck(t *testing.T) {
+	var payloads []Payload
+	var lights []string
+	mux := http.NewServeMux()
+	mux.HandleFunc("/events", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/json", r.Header.Get("Content-Type"))
+		var payload Payload
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&payload))
+		payloads = append(payloads, payload)
+	})
+	mux.HandleFunc("/light", func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		lights = append(lights, string(body))
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	now := time.Now().Truncate(time.Second)
+	e := &Emitter{
+		Source: &fakeSource{meetings: []zoom.Meeting{
+			{ID: "standup", Title: "Standup", Start: now.Add(3 * time.Minute), End: now.Add(18 * time.Minute)},
+		}},
+		Hooks: []Hook{
+			{URL: server.URL + "/events"},
+			{URL: server.URL + "/light", Events: []string{EventStarting}, Body: `{"flash": "{{.Meeting.Title}}"}`},
+		},
+	}
+
+	require.NoError(t, e.check(context.Background(), now.Add(-10*time.Minute)))
+	assert.Empty(t, payloads, "the meeting is too far away")
+
+	for _, offset := range []time.Duration{0, time.Minute, 4 * time.Minute, 5 * time.Minute, 20 * time.Minute, 21 * time.Minute} {
+		require.NoError(t, e.check(context.Background(), now.Add(offset)))
+	}
+
+	var events []string
+	for _, payload := range payloads {
+		events = append(events, payload.Event)
+		assert.Equal(t, "Standup", payload.Meeting.Title)
+	}
+	assert.Equal(t, []string{EventStarting, EventStarted, EventEnded}, events)
+	assert.Equal(t, now.Format(time.RFC3339), payloads[0].Time)
+	assert.Equal(t, []string{`{"flash": "Standup"}`}, lights)
+}
+
+func TestEmitterCall_Retry(t *testing.T) {
+	slept := stubSleep(t)
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+		}
+	}))
+	defer server.Close()
+
+	e := &Emitter{}
+	require.NoError(t, e.call(context.Background(), Hook{URL: server.URL}, Payload{Event: EventStarted}))
+	assert.Equal(t, 3, attempts)
+	assert.Equal(t, []time.Duration{time.Second, 2 * time.Second}, *slept)
+}
+
+func TestEmitterCall_Errors(t *testing.T) {
+	stubSleep(t)
+
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	e := &Emitter{}
+	assert.EqualError(t, e.call(context.Background(), Hook{URL: server.URL}, Payload{}), "unexpected response: 404 Not Found")
+	assert.Equal(t, 1, attempts, "client errors aren't retried")
+
+	err := e.call(context.Background(), Hook{URL: server.URL, Body: "{{.Nope"}, Payload{})
+	assert.Contains(t, err.Error(), "parsing webhook body template")
+}
@@ -0,0 +1,163 @@
+package zoom
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+var (
+	// focusTimeRegexp matches the titles of Google Calendar's focus time events, and
+	// similar blocks people make by hand, e.g. "Focus time" or "Heads down".
+	focusTimeRegexp = regexp.MustCompile(`(?i)^\s*(focus\s+(time|block)|heads[ -]down|no meetings)\b`)
+
+	// outOfOfficeRegexp matches the titles of out of office events, e.g. "Out of office" or "OOO".
+	outOfOfficeRegexp = regexp.MustCompile(`(?i)^\s*(out of (the )?office|ooo)\b`)
+)
+
+// IsFocusTime returns true if the event is a block of focus time rather than a meeting.
+// The calendar API client this package uses predates event types, so focus time is
+// recognized by its title, which Google Calendar sets to "Focus time" by default.
+func IsFocusTime(event *calendar.Event) bool {
+	return event != nil && focusTimeRegexp.MatchString(event.Summary)
+}
+
+// IsOutOfOffice returns true if the event marks time out of office rather than a meeting,
+// recognized by its title like IsFocusTime.
+func IsOutOfOffice(event *calendar.Event) bool {
+	return event != nil && outOfOfficeRegexp.MatchString(event.Summary)
+}
+
+// WorkingHours are the hours you work on each day of the week, in Location. Days which
+// are missing aren't working days.
+type WorkingHours map[time.Weekday][]HoursSpan
+
+// HoursSpan is a span of time within a day, as offsets from midnight.
+type HoursSpan struct {
+	Start time.Duration
+	End   time.Duration
+}
+
+// weekdayNames are the abbreviations of the days of the week in working hours specs.
+var weekdayNames = map[string]time.Weekday{
+	"sun": time.Sunday, "mon": time.Monday, "tue": time.Tuesday, "wed": time.Wednesday,
+	"thu": time.Thursday, "fri": time.Friday, "sat": time.Saturday,
+}
+
+// ParseWorkingHours parses specs of the days and hours you work, such as
+// "mon-fri 09:00-17:30" or "sat 10:00-12:00". Days may be given more than once, for
+// hours split by a break.
+func ParseWorkingHours(specs []string) (WorkingHours, error) {
+	hours := WorkingHours{}
+	for _, spec := range specs {
+		fields := strings.Fields(spec)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("working hours %q should look like \"mon-fri 09:00-17:00\"", spec)
+		}
+		days, err := parseWeekdays(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("working hours %q: %w", spec, err)
+		}
+		span, err := parseHoursSpan(fields[1])
+		if err != nil {
+			return nil, fmt.Errorf("working hours %q: %w", spec, err)
+		}
+		for _, day := range days {
+			hours[day] = append(hours[day], span)
+		}
+	}
+	return hours, nil
+}
+
+// parseWeekdays parses a day, e.g. "mon", or a range of days, e.g. "mon-fri" or "sat-sun".
+func parseWeekdays(text string) ([]time.Weekday, error) {
+	first, last, isRange := strings.Cut(strings.ToLower(text), "-")
+	if !isRange {
+		last = first
+	}
+	start, ok := weekdayNames[first]
+	if !ok {
+		return nil, fmt.Errorf("unknown day %q", first)
+	}
+	end, ok := weekdayNames[last]
+	if !ok {
+		return nil, fmt.Errorf("unknown day %q", last)
+	}
+
+	days := []time.Weekday{start}
+	for day := start; day != end; {
+		day = (day + 1) % 7
+		days = append(days, day)
+	}
+	return days, nil
+}
+
+// parseHoursSpan parses a span of hours, e.g. "09:00-17:30" or "22:00-24:00".
+func parseHoursSpan(text string) (HoursSpan, error) {
+	startText, endText, ok := strings.Cut(text, "-")
+	if !ok {
+		return HoursSpan{}, fmt.Errorf("hours %q should look like \"09:00-17:00\"", text)
+	}
+	start, ok := parseClock(startText)
+	if !ok {
+		return HoursSpan{}, fmt.Errorf("invalid start time %q", startText)
+	}
+	end, ok := parseClock(endText)
+	if !ok {
+		return HoursSpan{}, fmt.Errorf("invalid end time %q", endText)
+	}
+	if end <= start {
+		return HoursSpan{}, fmt.Errorf("hours %q end before they start", text)
+	}
+	return HoursSpan{Start: start, End: end}, nil
+}
+
+// parseClock parses a time of day, e.g. "9:00" or "17:30", as an offset from midnight.
+// "24:00" is the following midnight.
+func parseClock(text string) (time.Duration, bool) {
+	if text == "24:00" {
+		return 24 * time.Hour, true
+	}
+	t, err := time.Parse("15:04", text)
+	if err != nil {
+		return 0, false
+	}
+	return time.Duration(t.Hour())*time.Hour + time.Duration(t.Minute())*time.Minute, true
+}
+
+// Overlaps returns true if the span of time from start to end overlaps your working hours
+// on the day it starts, in Location. A zero end means the span is the instant it starts.
+func (w WorkingHours) Overlaps(start, end time.Time) bool {
+	start = InLocation(start)
+	if end.IsZero() || end.Before(start) {
+		end = start
+	}
+	year, month, day := start.Date()
+	midnight := time.Date(year, month, day, 0, 0, 0, 0, start.Location())
+
+	for _, span := range w[start.Weekday()] {
+		spanStart, spanEnd := midnight.Add(span.Start), midnight.Add(span.End)
+		if end.Equal(start) {
+			if !start.Before(spanStart) && start.Before(spanEnd) {
+				return true
+			}
+		} else if start.Before(spanEnd) && end.After(spanStart) {
+			return true
+		}
+	}
+	return false
+}
+
+// isOutsideWorkingHours returns true if the event doesn't overlap your working hours.
+// Events without a start time are never outside them.
+func isOutsideWorkingHours(event *calendar.Event, hours WorkingHours) bool {
+	start, err := MeetingStartTime(event)
+	if err != nil {
+		return false
+	}
+	end, _ := MeetingEndTime(event)
+	return !hours.Overlaps(start, end)
+}
@@ -0,0 +1,97 @@
+package zoom
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestIsFocusTime(t *testing.T) {
+	assert.True(t, IsFocusTime(&calendar.Event{Summary: "Focus time"}))
+	assert.True(t, IsFocusTime(&calendar.Event{Summary: "Heads-down: Q4 planning"}))
+	assert.False(t, IsFocusTime(&calendar.Event{Summary: "Focus group readout"}))
+	assert.False(t, IsFocusTime(&calendar.Event{Summary: "Standup"}))
+	assert.False(t, IsFocusTime(nil))
+
+	assert.True(t, IsOutOfOffice(&calendar.Event{Summary: "Out of office"}))
+	assert.True(t, IsOutOfOffice(&calendar.Event{Summary: "OOO - dentist"}))
+	assert.False(t, IsOutOfOffice(&calendar.Event{Summary: "Offsite planning"}))
+}
+
+func TestParseWorkingHours(t *testing.T) {
+	hours, err := ParseWorkingHours([]string{"mon-fri 09:00-12:00", "MON-FRI 13:00-17:30", "sat 22:00-24:00", "fri-mon 08:00-09:00"})
+	require.NoError(t, err)
+	assert.Equal(t, []HoursSpan{
+		{9 * time.Hour, 12 * time.Hour},
+		{13 * time.Hour, 17*time.Hour + 30*time.Minute},
+		{8 * time.Hour, 9 * time.Hour},
+	}, hours[time.Monday])
+	assert.Equal(t, []HoursSpan{{22 * time.Hour, 24 * time.Hour}, {8 * time.Hour, 9 * time.Hour}}, hours[time.Saturday])
+	assert.Len(t, hours[time.Wednesday], 2)
+
+	for _, spec := range []string{"weekdays 09:00-17:00", "mon-fri", "mon 9-5", "mon 17:00-09:00", "mon 09:00-25:00"} {
+		_, err := ParseWorkingHours([]string{spec})
+		assert.Error(t, err, spec)
+	}
+}
+
+func TestWorkingHoursOverlaps(t *testing.T) {
+	defer func(location *time.Location) { Location = location }(Location)
+	Location = time.FixedZone("PDT", -7*60*60)
+
+	hours, err := ParseWorkingHours([]string{"mon-fri 09:00-17:00"})
+	require.NoError(t, err)
+
+	// Wednesday, October 10th, 2018.
+	at := func(hour, minute int) time.Time {
+		return time.Date(2018, 10, 10, hour, minute, 0, 0, Location)
+	}
+	assert.True(t, hours.Overlaps(at(10, 0), at(11, 0)))
+	assert.True(t, hours.Overlaps(at(8, 30), at(9, 30)), "meetings which run into working hours overlap them")
+	assert.False(t, hours.Overlaps(at(7, 0), at(8, 0)))
+	assert.False(t, hours.Overlaps(at(8, 0), at(9, 0)))
+	assert.True(t, hours.Overlaps(at(9, 0), time.Time{}))
+	assert.False(t, hours.Overlaps(at(17, 0), time.Time{}))
+	assert.False(t, hours.Overlaps(at(10, 0).AddDate(0, 0, 3), time.Time{}), "Saturday isn't a working day")
+	assert.True(t, hours.Overlaps(at(10, 0).In(time.UTC), time.Time{}), "times are compared in Location")
+}
+
+func TestNextEventWithOptions_WorkingHours(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	mux.HandleFunc("/calendars/primary/events", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"items": [
+			{"summary": "Gym", "location": "https://jithub.zoom.us/j/11111", "start": {"dateTime": "2018-10-10T07:00:00Z"}, "end": {"dateTime": "2018-10-10T08:00:00Z"}},
+			{"summary": "Focus time", "location": "https://jithub.zoom.us/j/22222", "start": {"dateTime": "2018-10-10T09:00:00Z"}, "end": {"dateTime": "2018-10-10T11:00:00Z"}},
+			{"summary": "Standup", "location": "https://jithub.zoom.us/j/33333", "start": {"dateTime": "2018-10-10T11:00:00Z"}, "end": {"dateTime": "2018-10-10T11:15:00Z"}}
+		]}`)
+	})
+
+	defer func(location *time.Location) { Location = location }(Location)
+	Location = time.UTC
+	hours, err := ParseWorkingHours([]string{"mon-fri 09:00-17:00"})
+	require.NoError(t, err)
+
+	event, err := NextEventWithOptions(service, Options{WorkingHours: hours})
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, "Standup", event.Summary)
+
+	event, err = NextEventWithOptions(service, Options{WorkingHours: hours, IncludeFocusTime: true})
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, "Focus time", event.Summary)
+
+	event, err = NextEventWithOptions(service, Options{})
+	require.NoError(t, err)
+	require.NotNil(t, event)
+	assert.Equal(t, "Gym", event.Summary)
+}
@@ -3,101 +3,246 @@ package zoom
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"net/url"
 	"regexp"
-	"strconv"
 	"time"
 
-	humanize "github.com/dustin/go-humanize"
-	"github.com/pkg/errors"
 	calendar "google.golang.org/api/calendar/v3"
 )
 
 const googleCalendarDateTimeFormat = time.RFC3339
 
-var zoomURLRegexp = regexp.MustCompile(`https://.*?\.zoom\.us/(?:j/(\d+)|my/(\S+))`)
+// googleCalendarDateFormat is the format of all-day events' dates.
+const googleCalendarDateFormat = "2006-01-02"
 
-// NextEvent returns the next calendar event in your primary calendar.
-// It will list at most 10 events, and select the first one with a Zoom URL if one exists.
+// localDateTimeFormat is the format of date-times without a UTC offset, which are in the
+// date-time's time zone.
+const localDateTimeFormat = "2006-01-02T15:04:05"
+
+var cancelledTitleRegexp = regexp.MustCompile(`(?i)\bcancell?ed\b`)
+
+// NextEvent returns the next calendar event in your primary calendar, as
+// NextEventWithOptions does with the default options: of the next 10 events, the first
+// one with a Zoom link, if one exists.
+// It returns nil with no error if there are no upcoming events; use NextMeeting to be
+// told so explicitly.
 func NextEvent(service *calendar.Service) (*calendar.Event, error) {
-	t := time.Now().Format(time.RFC3339)
-
-	events, err := service.Events.
-		List("primary").
-		ShowDeleted(false).
-		SingleEvents(true).
-		TimeMin(t).
-		MaxResults(10).
-		OrderBy("startTime").
-		Do()
+	return NextEventWithOptions(service, Options{})
+}
+
+// NextEventWithOptions returns the next calendar event in the calendars selected by the
+// options, skipping any events excluded by the options.
+// It will list at most opts.MaxResults events (10 by default), or keep listing until the
+// horizon when opts.Paginate is set, and select the first one with a link to one of the
+// configured providers if one exists.
+func NextEventWithOptions(service *calendar.Service, opts Options) (*calendar.Event, error) {
+	return NextEventContext(context.Background(), service, opts)
+}
+
+// NextEventContext is like NextEventWithOptions, but the calendar API call is bound to the context.
+func NextEventContext(ctx context.Context, service *calendar.Service, opts Options) (*calendar.Event, error) {
+	if opts.Cache == nil {
+		return nextEvent(ctx, service, opts)
+	}
+
+	if event, ok := opts.Cache.get(time.Now()); ok {
+		opts.logger().Debug("using cached next event")
+		return event, nil
+	}
+	event, err := nextEvent(ctx, service, opts)
+	if err != nil {
+		return nil, err
+	}
+	opts.Cache.put(event, time.Now())
+	return event, nil
+}
+
+// nextEvent lists the candidate events and selects the next meeting from them.
+func nextEvent(ctx context.Context, service *calendar.Service, opts Options) (*calendar.Event, error) {
+	hasURL := func(event *calendar.Event) bool {
+		_, _, ok := ConferenceURLFromEvent(event, opts.providers())
+		return ok
+	}
+
+	candidates, err := listEvents(ctx, service, opts, hasURL)
+	if opts.Store != nil {
+		if err == nil {
+			// The store only helps when we're offline, so failing to update it isn't fatal.
+			opts.Store.save(candidates, time.Now())
+		} else if ctx.Err() == nil && isUnreachable(err) {
+			opts.logger().Debug("calendar is unreachable, using stored events", "error", err)
+			candidates, err = opts.Store.fallback(err, time.Now())
+		}
+	}
 	if err != nil {
-		return nil, errors.WithStack(err)
+		return nil, err
 	}
 
-	if len(events.Items) == 0 {
+	if len(candidates) == 0 {
+		opts.logger().Debug("no upcoming events")
 		return nil, nil
 	}
 
-	for _, event := range events.Items {
-		if _, ok := MeetingURLFromEvent(event); ok {
+	for _, event := range candidates {
+		if _, provider, ok := ConferenceURLFromEvent(event, opts.providers()); ok {
+			opts.logger().Debug("chose event with a meeting link", "id", event.Id, "summary", event.Summary, "provider", provider.Name())
 			return event, nil
 		}
+		opts.logger().Debug("no meeting link found", "id", event.Id, "summary", event.Summary)
 	}
 
-	// We couldn't find an event with a Zoom URL, so just return the first event.
-	return events.Items[0], nil
+	// We couldn't find an event with a meeting URL, so just return the first event.
+	opts.logger().Debug("no event has a meeting link, choosing the first", "id", candidates[0].Id, "summary", candidates[0].Summary)
+	return candidates[0], nil
+}
+
+// URLOptions controls which kind of URL is returned for a meeting.
+type URLOptions struct {
+	// PreferDeepLink returns the native URL, such as zoommtg:// or msteams://, which opens
+	// the provider's app directly, when one is known. Otherwise the HTTPS URL is returned,
+	// which is better suited to opening in a browser or sharing.
+	PreferDeepLink bool
 }
 
 // MeetingURLFromEvent returns a URL if the event is a Zoom meeting.
+// The zoommtg:// deep link is returned if the meeting ID is known.
 func MeetingURLFromEvent(event *calendar.Event) (*url.URL, bool) {
-	matches := zoomURLRegexp.FindAllStringSubmatch(event.Location+" "+event.Description, -1)
-	if len(matches) == 0 || len(matches[0]) == 0 {
+	return MeetingURLFromEventWithOptions(event, URLOptions{PreferDeepLink: true})
+}
+
+// MeetingURLFromEventWithOptions returns a URL if the event is a Zoom meeting, choosing
+// between the HTTPS URL and the deep link according to the options.
+func MeetingURLFromEventWithOptions(event *calendar.Event, opts URLOptions) (*url.URL, bool) {
+	webURL, deepLink, ok := meetingURLsFromEvent(event)
+	if !ok {
 		return nil, false
 	}
+	return opts.choose(webURL, deepLink), true
+}
 
-	// By default, match the whole URL.
-	stringURL := matches[0][0]
+// choose returns the deep link if it is preferred and available, and the web URL otherwise.
+func (o URLOptions) choose(webURL, deepLink *url.URL) *url.URL {
+	if o.PreferDeepLink && deepLink != nil {
+		return deepLink
+	}
+	return webURL
+}
 
-	// If we have a meeting ID in the URL, then use zoommtg:// instead of the HTTPS URL.
-	if len(matches[0]) >= 2 {
-		if _, err := strconv.Atoi(matches[0][1]); err == nil {
-			stringURL = "zoommtg://zoom.us/join?confno=" + matches[0][1]
-		}
+// meetingURLsFromEvent returns the Zoom URL as it appears in the event along with the
+// zoommtg:// deep link, if the URL contains a meeting ID. The deep link includes the
+// meeting passcode, if one was found.
+// Structured conference data is preferred over the event's location and description, in
+// which HTML is stripped and links wrapped by one of the Redirectors are unwrapped.
+func meetingURLsFromEvent(event *calendar.Event) (webURL, deepLink *url.URL, ok bool) {
+	if event == nil {
+		return nil, nil, false
 	}
+	return zoomURLsFromEvent(event, &lazyEventText{event: event})
+}
 
-	parsedURL, err := url.Parse(stringURL)
-	if err != nil {
-		return nil, false
+// zoomURLsFromEvent is meetingURLsFromEvent, with the event's text only cleaned up if
+// there is no Zoom URL in its conference data.
+func zoomURLsFromEvent(event *calendar.Event, text *lazyEventText) (webURL, deepLink *url.URL, ok bool) {
+	if webURL, deepLink, ok := meetingURLsFromConferenceData(event); ok {
+		return webURL, deepLink, true
+	}
+
+	if !text.mentions(ZoomDomains) {
+		return nil, nil, false
+	}
+	webURL, deepLink, ok = meetingURLsFromText(text.String())
+	if !ok {
+		return nil, nil, false
 	}
-	return parsedURL, true
+	return webURL, withPasscode(deepLink, meetingPasscode(event, webURL)), true
 }
 
-// IsMeetingSoon returns true if the meeting is less than 5 minutes from now.
+// SoonBefore and SoonAfter are the window in which IsMeetingSoon considers a meeting soon:
+// from SoonBefore before it starts until SoonAfter after it starts.
+var (
+	SoonBefore = 5 * time.Minute
+	SoonAfter  = 5 * time.Minute
+)
+
+// IsMeetingSoon returns true if the meeting starts within SoonBefore from now, or started
+// less than SoonAfter ago.
 func IsMeetingSoon(event *calendar.Event) bool {
+	return IsMeetingWithin(event, SoonBefore, SoonAfter)
+}
+
+// IsMeetingWithin returns true if the meeting starts less than before from now, or started
+// less than after ago. All-day events are never within.
+func IsMeetingWithin(event *calendar.Event, before, after time.Duration) bool {
 	startTime, err := MeetingStartTime(event)
-	if err != nil {
+	if err != nil || isAllDay(event) {
+		return false
+	}
+	untilStart := time.Until(startTime)
+	return -after < untilStart && untilStart < before
+}
+
+// IsSoon is like IsMeetingSoon, but for a Meeting, such as one listed by a CalendarSource,
+// as of now.
+func (m Meeting) IsSoon(now time.Time) bool {
+	if m.Start.IsZero() || m.AllDay {
+		return false
+	}
+	untilStart := m.Start.Sub(now)
+	return -SoonAfter < untilStart && untilStart < SoonBefore
+}
+
+// LooksCancelled returns true if the event's title says it was cancelled, e.g. "CANCELLED: Standup".
+// Organizers sometimes cancel a meeting this way rather than deleting it, so this is
+// independent of the event's status.
+func LooksCancelled(event *calendar.Event) bool {
+	if event == nil {
 		return false
 	}
-	minutesUntilStart := time.Until(startTime).Minutes()
-	return -5 < minutesUntilStart && minutesUntilStart < 5
+	return cancelledTitleRegexp.MatchString(event.Summary)
 }
 
-// HumanizedStartTime converts the event's start time to a human-friendly statement.
+// HumanizedStartTime converts the event's start time to a human-friendly statement, such as
+// "10 minutes from now", formatted by Formatter.
 func HumanizedStartTime(event *calendar.Event) string {
 	startTime, err := MeetingStartTime(event)
 	if err != nil {
 		return err.Error()
 	}
-	return humanize.Time(startTime)
+	return Formatter.RelativeTime(startTime, time.Now())
 }
 
-// MeetingStartTime returns the calendar event's start time.
+// MeetingStartTime returns the calendar event's start time, in the event's time zone if
+// it has one. All-day events start at midnight on their first day.
 func MeetingStartTime(event *calendar.Event) (time.Time, error) {
-	if event == nil || event.Start == nil || event.Start.DateTime == "" {
+	if event == nil || event.Start == nil || (event.Start.DateTime == "" && event.Start.Date == "") {
 		return time.Time{}, errors.New("event does not have a start datetime")
 	}
-	return time.Parse(googleCalendarDateTimeFormat, event.Start.DateTime)
+	return parseEventDateTime(event.Start)
+}
+
+// MeetingEndTime returns the calendar event's end time, in the event's time zone if it
+// has one. All-day events end at midnight after their last day.
+func MeetingEndTime(event *calendar.Event) (time.Time, error) {
+	if event == nil || event.End == nil || (event.End.DateTime == "" && event.End.Date == "") {
+		return time.Time{}, errors.New("event does not have an end datetime")
+	}
+	return parseEventDateTime(event.End)
+}
+
+// MeetingDuration returns how long the calendar event is scheduled to last.
+func MeetingDuration(event *calendar.Event) (time.Duration, error) {
+	startTime, err := MeetingStartTime(event)
+	if err != nil {
+		return 0, err
+	}
+	endTime, err := MeetingEndTime(event)
+	if err != nil {
+		return 0, err
+	}
+	return endTime.Sub(startTime), nil
 }
 
 // MeetingSummary generates a one-line summary of the meeting as a string.
@@ -1,6 +1,8 @@
 package zoom
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"net/http"
 	"net/http/httptest"
@@ -75,7 +77,7 @@ func TestNextEvent(t *testing.T) {
 			assert.Equal(t, query.Get("showDeleted"), "false")
 			assert.Equal(t, query.Get("singleEvents"), "true")
 			assert.Equal(t, query.Get("timeMin"), time.Now().Format(time.RFC3339))
-			fmt.Fprintf(w, testEventResponse)
+			fmt.Fprint(w, testEventResponse)
 		} else {
 			t.Fatalf("unexpected request: %s %s", r.Method, r.URL)
 		}
@@ -195,6 +197,53 @@ func TestIsMeetingSoon(t *testing.T) {
 	}
 }
 
+func TestIsMeetingWithin(t *testing.T) {
+	at := func(d time.Duration) *calendar.Event {
+		return &calendar.Event{Start: &calendar.EventDateTime{
+			DateTime: time.Now().Add(d).Format(googleCalendarDateTimeFormat),
+		}}
+	}
+
+	assert.True(t, IsMeetingWithin(at(12*time.Minute), 15*time.Minute, time.Minute))
+	assert.False(t, IsMeetingWithin(at(2*time.Minute), time.Minute, time.Minute))
+	assert.True(t, IsMeetingWithin(at(-5*time.Minute), time.Minute, 10*time.Minute))
+	assert.False(t, IsMeetingWithin(at(-2*time.Minute), 15*time.Minute, time.Minute))
+	assert.False(t, IsMeetingWithin(nil, time.Hour, time.Hour))
+
+	defer func(before, after time.Duration) { SoonBefore, SoonAfter = before, after }(SoonBefore, SoonAfter)
+	SoonBefore = 15 * time.Minute
+	assert.True(t, IsMeetingSoon(at(12*time.Minute)), "IsMeetingSoon uses SoonBefore")
+}
+
+func TestMeetingIsSoon(t *testing.T) {
+	now := time.Now()
+	assert.True(t, Meeting{Start: now.Add(4 * time.Minute)}.IsSoon(now))
+	assert.True(t, Meeting{Start: now.Add(-4 * time.Minute)}.IsSoon(now))
+	assert.False(t, Meeting{Start: now.Add(6 * time.Minute)}.IsSoon(now))
+	assert.False(t, Meeting{Start: now.Add(-6 * time.Minute)}.IsSoon(now))
+	assert.False(t, Meeting{Start: now, AllDay: true}.IsSoon(now), "all-day events are never soon")
+	assert.False(t, Meeting{}.IsSoon(now))
+}
+
+func TestLooksCancelled(t *testing.T) {
+	testCases := []struct {
+		input    *calendar.Event
+		expected bool
+	}{
+		{nil, false},
+		{&calendar.Event{}, false},
+		{&calendar.Event{Summary: "Weekly sync"}, false},
+		{&calendar.Event{Summary: "CANCELLED: Weekly sync"}, true},
+		{&calendar.Event{Summary: "Weekly sync (canceled)"}, true},
+		{&calendar.Event{Summary: "Cancelled - Weekly sync"}, true},
+		{&calendar.Event{Summary: "Cancellation policy review"}, false},
+		{&calendar.Event{Summary: "Discuss uncancelled orders"}, false},
+	}
+	for _, testCase := range testCases {
+		assert.Equal(t, testCase.expected, LooksCancelled(testCase.input), "input: %+v", testCase.input)
+	}
+}
+
 func TestHumanizedStartTime(t *testing.T) {
 	testCases := []struct {
 		input    *calendar.Event
@@ -214,3 +263,42 @@ func TestHumanizedStartTime(t *testing.T) {
 		assert.Equal(t, testCase.expected, HumanizedStartTime(testCase.input))
 	}
 }
+
+func TestMeetingEndTimeAndDuration(t *testing.T) {
+	event := &calendar.Event{
+		Start: &calendar.EventDateTime{DateTime: "2018-10-10T14:00:00-04:00"},
+		End:   &calendar.EventDateTime{DateTime: "2018-10-10T14:30:00-04:00"},
+	}
+	end, err := MeetingEndTime(event)
+	require.NoError(t, err)
+	assert.True(t, end.Equal(time.Date(2018, 10, 10, 18, 30, 0, 0, time.UTC)))
+	duration, err := MeetingDuration(event)
+	require.NoError(t, err)
+	assert.Equal(t, 30*time.Minute, duration)
+
+	_, err = MeetingEndTime(&calendar.Event{End: &calendar.EventDateTime{}})
+	assert.EqualError(t, err, "event does not have an end datetime")
+	_, err = MeetingEndTime(nil)
+	assert.EqualError(t, err, "event does not have an end datetime")
+	_, err = MeetingDuration(&calendar.Event{End: event.End})
+	assert.EqualError(t, err, "event does not have a start datetime")
+}
+
+func TestNextEventContext_Cancelled(t *testing.T) {
+	mux := http.NewServeMux()
+
+	service, shutdown := newFakeGoogleCalendarService(t, mux)
+	defer shutdown()
+
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testEventResponse)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	event, err := NextEventContext(ctx, service, Options{})
+	require.Error(t, err)
+	assert.Nil(t, event)
+	assert.True(t, errors.Is(err, context.Canceled))
+}
@@ -0,0 +1,258 @@
+// Package zoomapi looks up meetings with the Zoom REST API, to add details which calendar
+// events don't contain, such as the meeting's host and whether it has a waiting room, and
+// to start meetings you host.
+//
+// It authenticates as a Server-to-Server OAuth app, which an admin of your Zoom account
+// creates in the Zoom App Marketplace with the meeting:read:admin scope.
+package zoomapi
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/net/context/ctxhttp"
+
+	"github.com/benbalter/zoom-go"
+)
+
+const (
+	// DefaultBaseURL is the base URL of the Zoom REST API.
+	DefaultBaseURL = "https://api.zoom.us/v2/"
+
+	// DefaultTokenURL is where Server-to-Server OAuth apps get access tokens.
+	DefaultTokenURL = "https://zoom.us/oauth/token"
+
+	// tokenExpiryMargin is how long before an access token expires a new one is requested.
+	tokenExpiryMargin = time.Minute
+)
+
+// ErrNotHost indicates that a meeting can't be started because you aren't its host.
+var ErrNotHost = errors.New("you aren't the meeting's host")
+
+// Client calls the Zoom REST API as a Server-to-Server OAuth app.
+type Client struct {
+	// AccountID, ClientID, and ClientSecret are the app's credentials.
+	AccountID    string
+	ClientID     string
+	ClientSecret string
+
+	// Me is your email in Zoom, which decides whether you host a meeting. If empty, the
+	// email you were invited to the meeting with is used.
+	Me string
+
+	// BaseURL is the base URL of the REST API. Empty means DefaultBaseURL.
+	BaseURL string
+
+	// TokenURL is where access tokens are requested. Empty means DefaultTokenURL.
+	TokenURL string
+
+	// HTTPClient makes the requests. Nil means http.DefaultClient.
+	HTTPClient *http.Client
+
+	mu          sync.Mutex
+	accessToken string
+	expiry      time.Time
+}
+
+// NewClient returns a client which authenticates with the app's credentials.
+func NewClient(accountID, clientID, clientSecret string) *Client {
+	return &Client{AccountID: accountID, ClientID: clientID, ClientSecret: clientSecret}
+}
+
+// Meeting is a scheduled Zoom meeting, as described by the REST API.
+type Meeting struct {
+	ID        int64     `json:"id"`
+	UUID      string    `json:"uuid"`
+	Topic     string    `json:"topic"`
+	HostID    string    `json:"host_id"`
+	HostEmail string    `json:"host_email"`
+	Status    string    `json:"status"`
+	StartTime time.Time `json:"start_time"`
+	Timezone  string    `json:"timezone"`
+
+	// Duration is the scheduled length of the meeting, in minutes.
+	Duration int `json:"duration"`
+
+	JoinURL  string `json:"join_url"`
+	StartURL string `json:"start_url"`
+	Password string `json:"password"`
+
+	Settings struct {
+		WaitingRoom bool `json:"waiting_room"`
+	} `json:"settings"`
+}
+
+// Error is an error returned by the REST API.
+type Error struct {
+	// StatusCode is the HTTP status of the response.
+	StatusCode int
+
+	// Code and Message describe the error, e.g. 3001 and "Meeting does not exist".
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("Zoom API: %s", http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("Zoom API: %s (%d)", e.Message, e.Code)
+}
+
+// Meeting returns the meeting with the numeric ID.
+func (c *Client) Meeting(ctx context.Context, meetingID string) (*Meeting, error) {
+	var meeting Meeting
+	if err := c.get(ctx, "meetings/"+url.PathEscape(meetingID), &meeting); err != nil {
+		return nil, fmt.Errorf("error getting Zoom meeting %s: %w", meetingID, err)
+	}
+	return &meeting, nil
+}
+
+// Enrich looks up the meeting in Zoom and sets its Zoom details. Meetings which aren't
+// Zoom meetings, or whose meeting ID isn't known, are left as they are. The details'
+// StartURL is only set if you host the meeting.
+func (c *Client) Enrich(ctx context.Context, meeting *zoom.Meeting) error {
+	meetingID, ok := meeting.ZoomMeetingID()
+	if !ok {
+		return nil
+	}
+	details, err := c.Meeting(ctx, meetingID)
+	if err != nil {
+		return err
+	}
+
+	meeting.Zoom = &zoom.ZoomDetails{
+		Topic:       details.Topic,
+		HostEmail:   details.HostEmail,
+		Duration:    time.Duration(details.Duration) * time.Minute,
+		WaitingRoom: details.Settings.WaitingRoom,
+	}
+	if c.hosts(*meeting, details) && details.StartURL != "" {
+		if startURL, err := url.Parse(details.StartURL); err == nil {
+			meeting.Zoom.StartURL = startURL
+		}
+	}
+	return nil
+}
+
+// StartURL returns the URL which starts the meeting as its host. Start URLs expire after
+// a couple of hours, so get one right before opening it. It returns ErrNotHost if you
+// don't host the meeting, and zoom.ErrNoMeetingURL if its Zoom meeting ID isn't known.
+func (c *Client) StartURL(ctx context.Context, meeting zoom.Meeting) (*url.URL, error) {
+	meetingID, ok := meeting.ZoomMeetingID()
+	if !ok {
+		return nil, zoom.ErrNoMeetingURL
+	}
+	details, err := c.Meeting(ctx, meetingID)
+	if err != nil {
+		return nil, err
+	}
+	if !c.hosts(meeting, details) || details.StartURL == "" {
+		return nil, ErrNotHost
+	}
+	return url.Parse(details.StartURL)
+}
+
+// hosts returns true if you are the host of the meeting.
+func (c *Client) hosts(meeting zoom.Meeting, details *Meeting) bool {
+	me := c.Me
+	if me == "" {
+		for _, attendee := range meeting.Attendees {
+			if attendee.Self {
+				me = attendee.Email
+			}
+		}
+	}
+	return me != "" && strings.EqualFold(me, details.HostEmail)
+}
+
+// get requests the API path and decodes the JSON response into v.
+func (c *Client) get(ctx context.Context, path string, v interface{}) error {
+	token, err := c.token(ctx)
+	if err != nil {
+		return err
+	}
+
+	baseURL := c.BaseURL
+	if baseURL == "" {
+		baseURL = DefaultBaseURL
+	}
+	req, err := http.NewRequest(http.MethodGet, baseURL+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := ctxhttp.Do(ctx, c.HTTPClient, req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		apiErr := &Error{StatusCode: resp.StatusCode}
+		json.NewDecoder(resp.Body).Decode(apiErr)
+		if resp.StatusCode == http.StatusUnauthorized {
+			// The token may have been revoked, so get a new one next time.
+			c.mu.Lock()
+			c.accessToken = ""
+			c.mu.Unlock()
+		}
+		return apiErr
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// token returns an access token, requesting a new one if the last has expired.
+func (c *Client) token(ctx context.Context) (string, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.accessToken != "" && time.Now().Before(c.expiry) {
+		return c.accessToken, nil
+	}
+
+	tokenURL := c.TokenURL
+	if tokenURL == "" {
+		tokenURL = DefaultTokenURL
+	}
+	query := url.Values{"grant_type": {"account_credentials"}, "account_id": {c.AccountID}}
+	req, err := http.NewRequest(http.MethodPost, tokenURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return "", err
+	}
+	req.SetBasicAuth(c.ClientID, c.ClientSecret)
+
+	resp, err := ctxhttp.Do(ctx, c.HTTPClient, req)
+	if err != nil {
+		return "", fmt.Errorf("error getting Zoom access token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	var result struct {
+		AccessToken string `json:"access_token"`
+		ExpiresIn   int    `json:"expires_in"`
+		Reason      string `json:"reason"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&result); err != nil && resp.StatusCode == http.StatusOK {
+		return "", fmt.Errorf("error getting Zoom access token: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK || result.AccessToken == "" {
+		reason := result.Reason
+		if reason == "" {
+			reason = resp.Status
+		}
+		return "", fmt.Errorf("error getting Zoom access token: %s", reason)
+	}
+
+	c.accessToken = result.AccessToken
+	c.expiry = time.Now().Add(time.Duration(result.ExpiresIn)*time.Second - tokenExpiryMargin)
+	return c.accessToken, nil
+}
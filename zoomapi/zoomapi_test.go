@@ -0,0 +1,178 @@
+package zoomapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benbalter/zoom-go"
+)
+
+const testMeetingResponse = `{
+	"id": 12345,
+	"uuid": "aDYlohsHRtCd4ii1uC2+hA==",
+	"topic": "Weekly sync",
+	"host_id": "KdYKjnimT4KPd8FFgQt9FQ",
+	"host_email": "hubot@github.com",
+	"duration": 45,
+	"start_time": "2018-10-10T15:00:00Z",
+	"timezone": "America/New_York",
+	"join_url": "https://jithub.zoom.us/j/12345",
+	"start_url": "https://jithub.zoom.us/s/12345?zak=secret",
+	"status": "waiting",
+	"settings": {"waiting_room": true}
+}`
+
+// newTestClient returns a client whose token and API requests are handled by handler,
+// counting the access tokens it requests.
+func newTestClient(t *testing.T, handler http.HandlerFunc) (*Client, *int) {
+	tokens := 0
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth/token", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "account_credentials", r.URL.Query().Get("grant_type"))
+		assert.Equal(t, "account", r.URL.Query().Get("account_id"))
+		user, password, _ := r.BasicAuth()
+		assert.Equal(t, "client", user)
+		assert.Equal(t, "secret", password)
+
+		tokens++
+		fmt.Fprintf(w, `{"access_token": "token-%d", "token_type": "bearer", "expires_in": 3599}`, tokens)
+	})
+	mux.HandleFunc("/v2/", handler)
+	server := httptest.NewServer(mux)
+	t.Cleanup(server.Close)
+
+	client := NewClient("account", "client", "secret")
+	client.BaseURL = server.URL + "/v2/"
+	client.TokenURL = server.URL + "/oauth/token"
+	return client, &tokens
+}
+
+func testZoomMeeting(t *testing.T) zoom.Meeting {
+	joinURL, err := url.Parse("https://jithub.zoom.us/j/12345")
+	require.NoError(t, err)
+	return zoom.Meeting{
+		Title:     "Weekly sync",
+		JoinURL:   joinURL,
+		Attendees: []zoom.Attendee{{Person: zoom.Person{Email: "hubot@github.com"}, Self: true}},
+	}
+}
+
+func TestClientMeeting(t *testing.T) {
+	client, tokens := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/v2/meetings/12345", r.URL.Path)
+		assert.Equal(t, "Bearer token-1", r.Header.Get("Authorization"))
+		fmt.Fprint(w, testMeetingResponse)
+	})
+
+	meeting, err := client.Meeting(context.Background(), "12345")
+	require.NoError(t, err)
+	assert.Equal(t, int64(12345), meeting.ID)
+	assert.Equal(t, "Weekly sync", meeting.Topic)
+	assert.Equal(t, "hubot@github.com", meeting.HostEmail)
+	assert.Equal(t, 45, meeting.Duration)
+	assert.True(t, meeting.Settings.WaitingRoom)
+	assert.Equal(t, time.Date(2018, 10, 10, 15, 0, 0, 0, time.UTC), meeting.StartTime)
+
+	// The access token is reused until it expires.
+	_, err = client.Meeting(context.Background(), "12345")
+	require.NoError(t, err)
+	assert.Equal(t, 1, *tokens)
+}
+
+func TestClientMeeting_Errors(t *testing.T) {
+	client, tokens := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"code": 3001, "message": "Meeting does not exist: 12345."}`)
+	})
+	_, err := client.Meeting(context.Background(), "12345")
+	assert.EqualError(t, err, "error getting Zoom meeting 12345: Zoom API: Meeting does not exist: 12345. (3001)")
+	var apiErr *Error
+	require.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, http.StatusNotFound, apiErr.StatusCode)
+
+	// An unauthorized response discards the access token.
+	client, tokens = newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+	})
+	_, err = client.Meeting(context.Background(), "12345")
+	assert.EqualError(t, err, "error getting Zoom meeting 12345: Zoom API: Unauthorized")
+	_, err = client.Meeting(context.Background(), "12345")
+	assert.Error(t, err)
+	assert.Equal(t, 2, *tokens)
+}
+
+func TestClientMeeting_TokenError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"reason": "Invalid client_id or client_secret", "error": "invalid_client"}`)
+	}))
+	t.Cleanup(server.Close)
+
+	client := NewClient("account", "client", "wrong")
+	client.BaseURL = server.URL + "/v2/"
+	client.TokenURL = server.URL + "/oauth/token"
+	_, err := client.Meeting(context.Background(), "12345")
+	assert.EqualError(t, err, "error getting Zoom meeting 12345: error getting Zoom access token: Invalid client_id or client_secret")
+}
+
+func TestClientEnrich(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testMeetingResponse)
+	})
+
+	meeting := testZoomMeeting(t)
+	require.NoError(t, client.Enrich(context.Background(), &meeting))
+	require.NotNil(t, meeting.Zoom)
+	assert.Equal(t, "Weekly sync", meeting.Zoom.Topic)
+	assert.Equal(t, "hubot@github.com", meeting.Zoom.HostEmail)
+	assert.Equal(t, 45*time.Minute, meeting.Zoom.Duration)
+	assert.True(t, meeting.Zoom.WaitingRoom)
+	require.NotNil(t, meeting.Zoom.StartURL)
+	assert.Equal(t, "https://jithub.zoom.us/s/12345?zak=secret", meeting.Zoom.StartURL.String())
+
+	// Someone else's meeting has no start URL.
+	meeting = testZoomMeeting(t)
+	meeting.Attendees[0].Email = "octocat@github.com"
+	require.NoError(t, client.Enrich(context.Background(), &meeting))
+	require.NotNil(t, meeting.Zoom)
+	assert.Nil(t, meeting.Zoom.StartURL)
+
+	// Me takes precedence over the meeting's attendees.
+	client.Me = "Hubot@GitHub.com"
+	require.NoError(t, client.Enrich(context.Background(), &meeting))
+	assert.NotNil(t, meeting.Zoom.StartURL)
+
+	// Meetings which aren't in Zoom are left alone.
+	teams, err := url.Parse("https://teams.microsoft.com/l/meetup-join/abc")
+	require.NoError(t, err)
+	meeting = zoom.Meeting{JoinURL: teams}
+	require.NoError(t, client.Enrich(context.Background(), &meeting))
+	assert.Nil(t, meeting.Zoom)
+}
+
+func TestClientStartURL(t *testing.T) {
+	client, _ := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testMeetingResponse)
+	})
+
+	startURL, err := client.StartURL(context.Background(), testZoomMeeting(t))
+	require.NoError(t, err)
+	assert.Equal(t, "https://jithub.zoom.us/s/12345?zak=secret", startURL.String())
+
+	client.Me = "octocat@github.com"
+	_, err = client.StartURL(context.Background(), testZoomMeeting(t))
+	assert.Equal(t, ErrNotHost, err)
+
+	_, err = client.StartURL(context.Background(), zoom.Meeting{})
+	assert.Equal(t, zoom.ErrNoMeetingURL, err)
+}
@@ -0,0 +1,75 @@
+package zoomtest
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// NewCalendarService returns a Calendar API service backed by a fake server, which lists
+// the events in your primary calendar and no events in any other calendar. Events are
+// filtered by the request's timeMin and timeMax, and listed in a single page. The server
+// is closed when the test finishes.
+func NewCalendarService(t testing.TB, events ...*calendar.Event) *calendar.Service {
+	t.Helper()
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		calendarID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/calendars/"), "/events")
+		if calendarID == r.URL.Path || strings.Contains(calendarID, "/") {
+			http.NotFound(w, r)
+			return
+		}
+
+		list := &calendar.Events{Items: []*calendar.Event{}}
+		if calendarID == "primary" {
+			list.Items = eventsBetween(events, r.URL.Query().Get("timeMin"), r.URL.Query().Get("timeMax"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(list)
+	}))
+	t.Cleanup(server.Close)
+
+	service, err := calendar.New(server.Client())
+	if err != nil {
+		t.Fatalf("unexpected error: %+v", err)
+	}
+	service.BasePath = server.URL
+	return service
+}
+
+// eventsBetween returns the events which end after timeMin and start before timeMax, as
+// the Calendar API does. Either bound may be empty.
+func eventsBetween(events []*calendar.Event, timeMin, timeMax string) []*calendar.Event {
+	min, _ := time.Parse(time.RFC3339, timeMin)
+	max, _ := time.Parse(time.RFC3339, timeMax)
+
+	listed := []*calendar.Event{}
+	for _, event := range events {
+		start, end := eventTime(event.Start), eventTime(event.End)
+		if !min.IsZero() && !end.IsZero() && !end.After(min) {
+			continue
+		}
+		if !max.IsZero() && !start.IsZero() && !start.Before(max) {
+			continue
+		}
+		listed = append(listed, event)
+	}
+	return listed
+}
+
+// eventTime returns the time or date of the event's start or end, or the zero time if it has neither.
+func eventTime(dateTime *calendar.EventDateTime) time.Time {
+	if dateTime == nil {
+		return time.Time{}
+	}
+	if t, err := time.Parse(time.RFC3339, dateTime.DateTime); err == nil {
+		return t
+	}
+	t, _ := time.Parse("2006-01-02", dateTime.Date)
+	return t
+}
@@ -0,0 +1,110 @@
+package zoomtest
+
+import (
+	"time"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// DefaultDuration is how long events built by Event last, unless WithDuration is given.
+const DefaultDuration = 30 * time.Minute
+
+// ZoomURL is a Zoom meeting URL for fixtures, whose meeting ID is 12345.
+const ZoomURL = "https://jithub.zoom.us/j/12345"
+
+// EventOption changes an event built by Event.
+type EventOption func(*calendar.Event)
+
+// Event returns an event with the summary, starting at start and lasting DefaultDuration,
+// changed by the options. Without WithZoomURL or WithLocation, it has no meeting URL.
+func Event(summary string, start time.Time, opts ...EventOption) *calendar.Event {
+	event := &calendar.Event{
+		Summary: summary,
+		Status:  "confirmed",
+		Start:   &calendar.EventDateTime{DateTime: start.Format(time.RFC3339)},
+		End:     &calendar.EventDateTime{DateTime: start.Add(DefaultDuration).Format(time.RFC3339)},
+	}
+	for _, opt := range opts {
+		opt(event)
+	}
+	return event
+}
+
+// WithID sets the event's ID.
+func WithID(id string) EventOption {
+	return func(event *calendar.Event) {
+		event.Id = id
+	}
+}
+
+// WithZoomURL sets the event's location to the Zoom URL, e.g. ZoomURL.
+func WithZoomURL(url string) EventOption {
+	return WithLocation(url)
+}
+
+// WithLocation sets the event's location.
+func WithLocation(location string) EventOption {
+	return func(event *calendar.Event) {
+		event.Location = location
+	}
+}
+
+// WithDescription sets the event's description, e.g. to a Zoom invitation.
+func WithDescription(description string) EventOption {
+	return func(event *calendar.Event) {
+		event.Description = description
+	}
+}
+
+// WithDuration makes the event last d.
+func WithDuration(d time.Duration) EventOption {
+	return func(event *calendar.Event) {
+		if start, err := time.Parse(time.RFC3339, event.Start.DateTime); err == nil {
+			event.End = &calendar.EventDateTime{DateTime: start.Add(d).Format(time.RFC3339)}
+		}
+	}
+}
+
+// AllDay makes the event last all day on the day it starts, with a date but no time.
+func AllDay() EventOption {
+	return func(event *calendar.Event) {
+		start, err := time.Parse(time.RFC3339, event.Start.DateTime)
+		if err != nil {
+			return
+		}
+		event.Start = &calendar.EventDateTime{Date: start.Format("2006-01-02")}
+		event.End = &calendar.EventDateTime{Date: start.AddDate(0, 0, 1).Format("2006-01-02")}
+	}
+}
+
+// RecurringInstance makes the event an instance of the recurring event with the ID.
+func RecurringInstance(seriesID string) EventOption {
+	return func(event *calendar.Event) {
+		event.RecurringEventId = seriesID
+		event.OriginalStartTime = event.Start
+	}
+}
+
+// Cancelled marks the event as cancelled.
+func Cancelled() EventOption {
+	return func(event *calendar.Event) {
+		event.Status = "cancelled"
+	}
+}
+
+// WithAttendee adds an attendee with the email and response status, e.g. "accepted".
+// If self is true, the attendee is the owner of the calendar.
+func WithAttendee(email, responseStatus string, self bool) EventOption {
+	return func(event *calendar.Event) {
+		event.Attendees = append(event.Attendees, &calendar.EventAttendee{
+			Email:          email,
+			ResponseStatus: responseStatus,
+			Self:           self,
+		})
+	}
+}
+
+// Declined adds you as an attendee who declined the event.
+func Declined() EventOption {
+	return WithAttendee("you@example.com", "declined", true)
+}
@@ -0,0 +1,64 @@
+package zoomtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+// Update makes Golden write the golden files rather than compare against them. It is set
+// by running the tests with ZOOMTEST_UPDATE=1.
+var Update = os.Getenv("ZOOMTEST_UPDATE") != ""
+
+// LoadEvents reads calendar events from a JSON file, such as testdata/events.json, failing
+// the test if it can't. The file holds either a Calendar API events response, as saved
+// from the API explorer, or an array of events.
+func LoadEvents(t testing.TB, path string) []*calendar.Event {
+	t.Helper()
+
+	b, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading events: %+v", err)
+	}
+
+	if b = bytes.TrimSpace(b); len(b) > 0 && b[0] == '[' {
+		var events []*calendar.Event
+		if err := json.Unmarshal(b, &events); err != nil {
+			t.Fatalf("parsing events in %s: %+v", path, err)
+		}
+		return events
+	}
+	var list calendar.Events
+	if err := json.Unmarshal(b, &list); err != nil {
+		t.Fatalf("parsing events in %s: %+v", path, err)
+	}
+	return list.Items
+}
+
+// Golden fails the test if got differs from the contents of the golden file at path, such
+// as testdata/agenda.golden. If Update is set, it writes got to the file instead.
+func Golden(t testing.TB, path string, got []byte) {
+	t.Helper()
+
+	if Update {
+		if err := os.MkdirAll(filepath.Dir(path), 0755); err != nil {
+			t.Fatalf("updating golden file: %+v", err)
+		}
+		if err := os.WriteFile(path, got, 0644); err != nil {
+			t.Fatalf("updating golden file: %+v", err)
+		}
+		return
+	}
+
+	want, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("reading golden file: %+v (run with ZOOMTEST_UPDATE=1 to create it)", err)
+	}
+	if !bytes.Equal(want, got) {
+		t.Errorf("output differs from %s (run with ZOOMTEST_UPDATE=1 to update it)\nwant:\n%s\ngot:\n%s", path, want, got)
+	}
+}
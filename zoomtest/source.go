@@ -0,0 +1,96 @@
+// Package zoomtest helps test code which uses zoom-go without a Google account: it has a
+// fake CalendarSource, a fake Calendar API server, builders for calendar events, and
+// helpers for fixture and golden files.
+package zoomtest
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	calendar "google.golang.org/api/calendar/v3"
+
+	"github.com/benbalter/zoom-go"
+)
+
+// Source is a zoom.CalendarSource which lists its meetings. It is safe to change them
+// with Set while the source is in use.
+type Source struct {
+	mu       sync.Mutex
+	meetings []zoom.Meeting
+	err      error
+	windows  []zoom.Window
+}
+
+// NewSource returns a source which lists the meetings.
+func NewSource(meetings ...zoom.Meeting) *Source {
+	s := &Source{}
+	s.Set(meetings...)
+	return s
+}
+
+// NewSourceFromEvents returns a source which lists the events as meetings, recognizing
+// only Zoom links, as zoom.MeetingFromEvent does with no providers.
+func NewSourceFromEvents(events ...*calendar.Event) *Source {
+	meetings := make([]zoom.Meeting, 0, len(events))
+	for _, event := range events {
+		meetings = append(meetings, zoom.MeetingFromEvent(event, nil))
+	}
+	return NewSource(meetings...)
+}
+
+// Set replaces the meetings the source lists, and clears any error set with Fail.
+func (s *Source) Set(meetings ...zoom.Meeting) {
+	sorted := append([]zoom.Meeting(nil), meetings...)
+	sort.SliceStable(sorted, func(i, j int) bool { return sorted[i].Start.Before(sorted[j].Start) })
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.meetings, s.err = sorted, nil
+}
+
+// Fail makes the source return err until Set is called, e.g. zoom.ErrCalendarUnavailable.
+func (s *Source) Fail(err error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.err = err
+}
+
+// Windows returns the window of each call to UpcomingEvents so far.
+func (s *Source) Windows() []zoom.Window {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]zoom.Window(nil), s.windows...)
+}
+
+// UpcomingEvents returns the meetings which end after the window starts and start before
+// it ends, sorted by start time. Meetings with no end are listed if they start in the window.
+func (s *Source) UpcomingEvents(ctx context.Context, window zoom.Window) ([]zoom.Meeting, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.windows = append(s.windows, window)
+	if s.err != nil {
+		return nil, s.err
+	}
+
+	var meetings []zoom.Meeting
+	for _, meeting := range s.meetings {
+		if inWindow(meeting, window) {
+			meetings = append(meetings, meeting)
+		}
+	}
+	return meetings, nil
+}
+
+// inWindow returns true if the meeting ends after the window starts, or starts in the
+// window if it has no end, and starts before the window ends.
+func inWindow(meeting zoom.Meeting, window zoom.Window) bool {
+	if !window.End.IsZero() && !meeting.Start.Before(window.End) {
+		return false
+	}
+	if meeting.End.IsZero() {
+		return !meeting.Start.Before(window.Start)
+	}
+	return meeting.End.After(window.Start)
+}
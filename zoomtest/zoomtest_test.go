@@ -0,0 +1,110 @@
+package zoomtest
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/benbalter/zoom-go"
+)
+
+func TestSource(t *testing.T) {
+	now := time.Date(2018, 10, 10, 9, 0, 0, 0, time.UTC)
+	source := NewSourceFromEvents(
+		Event("Lunch", now.Add(3*time.Hour), WithZoomURL(ZoomURL)),
+		Event("Breakfast", now.Add(-time.Hour)),
+		Event("Standup", now.Add(-10*time.Minute)),
+	)
+
+	meetings, err := source.UpcomingEvents(context.Background(), zoom.Window{Start: now, End: now.Add(time.Hour)})
+	require.NoError(t, err)
+	require.Len(t, meetings, 1)
+	assert.Equal(t, "Standup", meetings[0].Title)
+
+	next, err := zoom.NextMeetingFromSource(context.Background(), source, zoom.Window{Start: now})
+	require.NoError(t, err)
+	assert.Equal(t, "Lunch", next.Title)
+	assert.Equal(t, ZoomURL, next.JoinURL.String())
+
+	source.Fail(zoom.ErrCalendarUnavailable)
+	_, err = source.UpcomingEvents(context.Background(), zoom.Window{Start: now})
+	assert.True(t, errors.Is(err, zoom.ErrCalendarUnavailable))
+
+	source.Set()
+	_, err = zoom.NextMeetingFromSource(context.Background(), source, zoom.Window{Start: now})
+	assert.True(t, errors.Is(err, zoom.ErrNoUpcomingEvents))
+
+	assert.Len(t, source.Windows(), 4)
+	assert.Equal(t, zoom.Window{Start: now, End: now.Add(time.Hour)}, source.Windows()[0])
+}
+
+func TestNewCalendarService(t *testing.T) {
+	start := time.Now().Add(time.Hour).Truncate(time.Second)
+	service := NewCalendarService(t,
+		Event("Out of office", start, AllDay(), WithZoomURL(ZoomURL)),
+		Event("Skipped standup", start, Declined(), WithZoomURL(ZoomURL)),
+		Event("Coffee", start.Add(time.Minute)),
+		Event("Retro", start.Add(time.Hour), WithID("retro_1"), RecurringInstance("retro"), WithZoomURL(ZoomURL), WithDuration(time.Hour)),
+		Event("Yesterday's retro", start.Add(-24*time.Hour), WithZoomURL(ZoomURL)),
+	)
+
+	meeting, ok, err := zoom.NextMeeting(service, zoom.Options{})
+	require.NoError(t, err)
+	require.True(t, ok)
+	assert.Equal(t, "retro_1", meeting.ID)
+	assert.Equal(t, "retro", meeting.SeriesID)
+	assert.Equal(t, time.Hour, meeting.End.Sub(meeting.Start))
+
+	_, ok, err = zoom.NextMeeting(service, zoom.Options{CalendarIDs: []string{"team@example.com"}})
+	require.NoError(t, err)
+	assert.False(t, ok, "other calendars are empty")
+}
+
+func TestLoadEvents(t *testing.T) {
+	dir := t.TempDir()
+	response := filepath.Join(dir, "response.json")
+	require.NoError(t, os.WriteFile(response, []byte(`{"items": [{"summary": "Standup"}, {"summary": "Retro"}]}`), 0644))
+	array := filepath.Join(dir, "array.json")
+	require.NoError(t, os.WriteFile(array, []byte(` [{"summary": "Standup"}]`), 0644))
+
+	events := LoadEvents(t, response)
+	require.Len(t, events, 2)
+	assert.Equal(t, "Retro", events[1].Summary)
+
+	events = LoadEvents(t, array)
+	require.Len(t, events, 1)
+	assert.Equal(t, "Standup", events[0].Summary)
+}
+
+func TestGolden(t *testing.T) {
+	defer func(update bool) { Update = update }(Update)
+	path := filepath.Join(t.TempDir(), "testdata", "summary.golden")
+
+	Update = true
+	Golden(t, path, []byte("Standup starts in 5 minutes.\n"))
+	b, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t, "Standup starts in 5 minutes.\n", string(b))
+
+	Update = false
+	Golden(t, path, []byte("Standup starts in 5 minutes.\n"))
+
+	recorder := &failureRecorder{TB: t}
+	Golden(recorder, path, []byte("Standup starts in 10 minutes.\n"))
+	assert.True(t, recorder.failed)
+}
+
+// failureRecorder is a testing.TB which records failures instead of failing the test.
+type failureRecorder struct {
+	testing.TB
+	failed bool
+}
+
+func (r *failureRecorder) Errorf(format string, args ...interface{}) { r.failed = true }
+func (r *failureRecorder) Fatalf(format string, args ...interface{}) { r.failed = true }
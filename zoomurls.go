@@ -0,0 +1,130 @@
+package zoom
+
+import (
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ZoomDomains are the domains whose URLs are recognized as Zoom meetings.
+// Subdomains, such as company vanity domains like "jithub.zoom.us", are recognized
+// automatically. Append to it to recognize other domains.
+var ZoomDomains = []string{"zoom.us", "zoom.com", "zoomgov.com", "zoom.com.cn"}
+
+// zoomURLPaths maps the path prefixes of Zoom URLs which contain a meeting ID to the
+// zoommtg:// action which opens them.
+var zoomURLPaths = []struct {
+	prefix string
+	action string
+}{
+	{"j", "join"},       // Meetings.
+	{"w", "join"},       // Webinars.
+	{"wc/join", "join"}, // The web client.
+	{"s", "start"},      // Host start URLs.
+}
+
+var zoomURLRegexpCache struct {
+	sync.Mutex
+	domains string
+	regexp  *regexp.Regexp
+}
+
+// zoomURLRegexp returns a regexp which matches Zoom URLs on any of the ZoomDomains.
+// Its submatches are the domain, the path prefix, the meeting ID, and the personal
+// meeting room name, in that order.
+func zoomURLRegexp() *regexp.Regexp {
+	zoomURLRegexpCache.Lock()
+	defer zoomURLRegexpCache.Unlock()
+
+	domains := strings.Join(ZoomDomains, " ")
+	if zoomURLRegexpCache.regexp != nil && zoomURLRegexpCache.domains == domains {
+		return zoomURLRegexpCache.regexp
+	}
+
+	quotedDomains := make([]string, 0, len(ZoomDomains))
+	for _, domain := range ZoomDomains {
+		quotedDomains = append(quotedDomains, regexp.QuoteMeta(domain))
+	}
+	prefixes := make([]string, 0, len(zoomURLPaths))
+	for _, path := range zoomURLPaths {
+		prefixes = append(prefixes, regexp.QuoteMeta(path.prefix))
+	}
+
+	zoomURLRegexpCache.domains = domains
+	zoomURLRegexpCache.regexp = regexp.MustCompile(
+		`https://(?:[\w-]+\.)*(` + strings.Join(quotedDomains, "|") + `)/` +
+			`(?:(` + strings.Join(prefixes, "|") + `)/(\d+)(?:\?[^\s"'<>]*)?|my/(\S+))`)
+	return zoomURLRegexpCache.regexp
+}
+
+// ZoomMeetingID returns the numeric meeting ID in a Zoom URL, such as
+// https://jithub.zoom.us/j/12345 or zoommtg://zoom.us/join?confno=12345. It returns false
+// for personal meeting room URLs, whose ID isn't in the URL, and URLs which aren't Zoom's.
+func ZoomMeetingID(u *url.URL) (string, bool) {
+	if u == nil {
+		return "", false
+	}
+	if u.Scheme == "zoommtg" {
+		confno := u.Query().Get("confno")
+		if _, err := strconv.Atoi(confno); err != nil {
+			return "", false
+		}
+		return confno, true
+	}
+	matches := zoomURLRegexp().FindStringSubmatch(u.String())
+	if len(matches) == 0 || matches[3] == "" {
+		return "", false
+	}
+	return matches[3], true
+}
+
+// meetingURLsFromText returns the first Zoom URL in the text along with the zoommtg://
+// deep link, if the URL contains a meeting ID.
+func meetingURLsFromText(text string) (webURL, deepLink *url.URL, ok bool) {
+	// Every Zoom URL contains one of the domains, and it is far quicker to look for them
+	// than to run the regexp over text without any, like most event descriptions.
+	if !containsAny(text, ZoomDomains) {
+		return nil, nil, false
+	}
+	matches := zoomURLRegexp().FindStringSubmatch(text)
+	if len(matches) == 0 {
+		return nil, nil, false
+	}
+
+	webURL, err := url.Parse(matches[0])
+	if err != nil {
+		return nil, nil, false
+	}
+
+	// If we have a meeting ID in the URL, then also build a zoommtg:// URL.
+	for _, path := range zoomURLPaths {
+		if path.prefix == matches[2] {
+			deepLink = zoomDeepLink(matches[1], path.action, matches[3])
+			break
+		}
+	}
+
+	return webURL, deepLink, true
+}
+
+// zoomDeepLink returns the zoommtg:// URL which performs the action for a numeric meeting
+// ID hosted on the domain, or nil if the ID is not numeric.
+func zoomDeepLink(domain, action, meetingID string) *url.URL {
+	if _, err := strconv.Atoi(meetingID); err != nil {
+		return nil
+	}
+
+	// ZoomGov has its own client scheme host; every other domain is served by zoom.us.
+	host := "zoom.us"
+	if domain == "zoomgov.com" || strings.HasSuffix(domain, ".zoomgov.com") {
+		host = "zoomgov.com"
+	}
+
+	deepLink, err := url.Parse("zoommtg://" + host + "/" + action + "?confno=" + meetingID)
+	if err != nil {
+		return nil
+	}
+	return deepLink
+}
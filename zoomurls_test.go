@@ -0,0 +1,108 @@
+package zoom
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	calendar "google.golang.org/api/calendar/v3"
+)
+
+func TestMeetingURLFromEvent_Variants(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"https://zoom.us/j/12345", "zoommtg://zoom.us/join?confno=12345"},
+		{"https://jithub.zoom.us/j/12345", "zoommtg://zoom.us/join?confno=12345"},
+		{"https://us02web.zoom.us/j/12345", "zoommtg://zoom.us/join?confno=12345"},
+		{"https://jithub.zoom.com/j/12345", "zoommtg://zoom.us/join?confno=12345"},
+		{"https://jithub.zoomgov.com/j/12345", "zoommtg://zoomgov.com/join?confno=12345"},
+		{"https://zoom.com.cn/j/12345", "zoommtg://zoom.us/join?confno=12345"},
+		{"https://jithub.zoom.us/w/12345", "zoommtg://zoom.us/join?confno=12345"},
+		{"https://jithub.zoom.us/s/12345", "zoommtg://zoom.us/start?confno=12345"},
+		{"https://zoom.us/wc/join/12345", "zoommtg://zoom.us/join?confno=12345"},
+		{"https://jithub.zoom.us/my/parkr", "https://jithub.zoom.us/my/parkr"},
+		{"https://jithub.zoomgov.com/my/parkr", "https://jithub.zoomgov.com/my/parkr"},
+	}
+	for _, testCase := range testCases {
+		u, ok := MeetingURLFromEvent(&calendar.Event{Location: testCase.input})
+		if assert.True(t, ok, "input: %s", testCase.input) {
+			assert.Equal(t, testCase.expected, u.String(), "input: %s", testCase.input)
+		}
+	}
+}
+
+func TestMeetingURLFromEvent_NotZoom(t *testing.T) {
+	for _, location := range []string{
+		"",
+		"In a real place!",
+		"https://notzoom.us/j/12345",
+		"https://zoom.us.evil.com/j/12345",
+		"https://jithub.zoom.us/",
+		"http://jithub.zoom.us/j/12345",
+	} {
+		_, ok := MeetingURLFromEvent(&calendar.Event{Location: location})
+		assert.False(t, ok, "input: %s", location)
+	}
+}
+
+func TestZoomDomains(t *testing.T) {
+	defer func(original []string) { ZoomDomains = original }(ZoomDomains)
+
+	event := &calendar.Event{Location: "https://meetings.jithub.com/j/12345"}
+	_, ok := MeetingURLFromEvent(event)
+	assert.False(t, ok)
+
+	ZoomDomains = append(ZoomDomains, "meetings.jithub.com")
+	u, ok := MeetingURLFromEvent(event)
+	assert.True(t, ok)
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=12345", u.String())
+}
+
+func TestMeetingURLFromEventWithOptions(t *testing.T) {
+	event := &calendar.Event{Location: "https://jithub.zoom.us/j/12345?pwd=abc"}
+
+	u, ok := MeetingURLFromEventWithOptions(event, URLOptions{})
+	assert.True(t, ok)
+	assert.Equal(t, "https://jithub.zoom.us/j/12345?pwd=abc", u.String())
+
+	u, ok = MeetingURLFromEventWithOptions(event, URLOptions{PreferDeepLink: true})
+	assert.True(t, ok)
+	assert.Equal(t, "zoommtg://zoom.us/join?confno=12345&pwd=abc", u.String())
+
+	u, ok = MeetingURLFromEventWithOptions(&calendar.Event{Location: "https://jithub.zoom.us/my/parkr"}, URLOptions{PreferDeepLink: true})
+	assert.True(t, ok)
+	assert.Equal(t, "https://jithub.zoom.us/my/parkr", u.String())
+
+	_, ok = MeetingURLFromEventWithOptions(&calendar.Event{}, URLOptions{})
+	assert.False(t, ok)
+}
+
+func TestZoomMeetingID(t *testing.T) {
+	testCases := []struct {
+		input    string
+		expected string
+	}{
+		{"https://jithub.zoom.us/j/12345?pwd=abc", "12345"},
+		{"https://zoom.us/wc/join/12345", "12345"},
+		{"zoommtg://zoom.us/join?confno=12345&pwd=abc", "12345"},
+		{"https://jithub.zoom.us/my/parkr", ""},
+		{"zoommtg://zoom.us/join?confno=parkr", ""},
+		{"https://teams.microsoft.com/l/meetup-join/12345", ""},
+	}
+	for _, testCase := range testCases {
+		u, err := url.Parse(testCase.input)
+		assert.NoError(t, err)
+		id, ok := ZoomMeetingID(u)
+		assert.Equal(t, testCase.expected != "", ok, "input: %s", testCase.input)
+		assert.Equal(t, testCase.expected, id, "input: %s", testCase.input)
+	}
+
+	_, ok := ZoomMeetingID(nil)
+	assert.False(t, ok)
+
+	id, ok := MeetingFromEvent(&calendar.Event{Location: "https://jithub.zoom.us/j/12345"}, nil).ZoomMeetingID()
+	assert.True(t, ok)
+	assert.Equal(t, "12345", id)
+}